@@ -5,18 +5,41 @@ import (
 	"regexp"
 
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
+	auditRoutes "github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/audit"
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/authentication"
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/authentication/mfa"
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/businesses"
+	carbonRoutes "github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/carbon"
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/collections"
+	invitationsRoutes "github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/invitations"
+	lockoutsRoutes "github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/lockouts"
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/materials"
+	policiesRoutes "github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/policies"
+	registrationsRoutes "github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/registrations"
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/roles"
+	tokensRoutes "github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/tokens"
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/transactions"
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/routes/users"
 	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/audit"
+	"github.com/connor-davis/threereco-nextgen/internal/carbon"
+	"github.com/connor-davis/threereco-nextgen/internal/custody"
+	"github.com/connor-davis/threereco-nextgen/internal/documents"
+	"github.com/connor-davis/threereco-nextgen/internal/impersonation"
+	"github.com/connor-davis/threereco-nextgen/internal/inventory"
+	"github.com/connor-davis/threereco-nextgen/internal/invitations"
+	"github.com/connor-davis/threereco-nextgen/internal/lifecycle"
+	"github.com/connor-davis/threereco-nextgen/internal/lockouts"
+	"github.com/connor-davis/threereco-nextgen/internal/passwords"
+	"github.com/connor-davis/threereco-nextgen/internal/payouts"
+	"github.com/connor-davis/threereco-nextgen/internal/pricing"
+	"github.com/connor-davis/threereco-nextgen/internal/registrations"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
+	"github.com/connor-davis/threereco-nextgen/internal/sso"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/connor-davis/threereco-nextgen/internal/tokens"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
@@ -28,43 +51,73 @@ type HttpRouter interface {
 }
 
 type httpRouter struct {
-	storage    storage.Storage
-	middleware middleware.Middleware
-	session    *session.Store
-	routes     []routing.Route
+	storage       storage.Storage
+	middleware    middleware.Middleware
+	session       *session.Store
+	passwords     passwords.Passwords
+	lockouts      lockouts.Lockouts
+	tokens        tokens.Tokens
+	sessions      sessions.Manager
+	sso           sso.Sso
+	invitations   invitations.Invitations
+	registrations registrations.Registrations
+	impersonation impersonation.Impersonation
+	audit         audit.Audit
+	routes        []routing.Route
 }
 
-func NewHttpRouter(storage storage.Storage, middleware middleware.Middleware, session *session.Store) HttpRouter {
-	mfaRouter := mfa.NewMfaRouter(storage, middleware, session)
+func NewHttpRouter(storage storage.Storage, middleware middleware.Middleware, session *session.Store, passwords passwords.Passwords, lockouts lockouts.Lockouts, tokens tokens.Tokens, sessions sessions.Manager, sso sso.Sso, invitations invitations.Invitations, registrations registrations.Registrations, impersonation impersonation.Impersonation, audit audit.Audit, carbon carbon.Carbon, pricing pricing.Pricing, lifecycle lifecycle.Lifecycle, inventory inventory.Inventory, custody custody.Custody, payouts payouts.Payouts, documents documents.Documents) HttpRouter {
+	mfaRouter := mfa.NewMfaRouter(storage, middleware, session, lockouts)
 	mfaRoutes := mfaRouter.LoadRoutes()
 
-	authenticationRouter := authentication.NewAuthenticationRouter(storage, middleware, session)
+	authenticationRouter := authentication.NewAuthenticationRouter(storage, middleware, session, passwords, lockouts, sessions, sso, registrations, impersonation, audit)
 	authenticationRoutes := authenticationRouter.LoadRoutes()
 
-	usersRouter := users.NewUsersRouter(storage, middleware)
+	usersRouter := users.NewUsersRouter(storage, middleware, passwords, sessions, session, impersonation, audit)
 	usersRoutes := usersRouter.LoadRoutes()
 
 	rolesRouter := roles.NewRolesRouter(storage, middleware)
 	rolesRoutes := rolesRouter.LoadRoutes()
 
-	materialsRouter := materials.NewMaterialsRouter(storage, middleware)
+	materialsRouter := materials.NewMaterialsRouter(storage, middleware, carbon)
 	materialsRoutes := materialsRouter.LoadRoutes()
 
-	collectionMaterialsRouter := collections.NewCollectionMaterialsRouter(storage, middleware)
+	collectionMaterialsRouter := collections.NewCollectionMaterialsRouter(storage, middleware, pricing)
 	collectionMaterialsRoutes := collectionMaterialsRouter.LoadRoutes()
 
-	collectionsRouter := collections.NewCollectionsRouter(storage, middleware)
+	collectionsRouter := collections.NewCollectionsRouter(storage, middleware, pricing, lifecycle, documents)
 	collectionsRoutes := collectionsRouter.LoadRoutes()
 
 	transactionMaterialsRouter := transactions.NewTransactionMaterialsRouter(storage, middleware)
 	transactionMaterialsRoutes := transactionMaterialsRouter.LoadRoutes()
 
-	transactionsRouter := transactions.NewTransactionsRouter(storage, middleware)
+	transactionsRouter := transactions.NewTransactionsRouter(storage, middleware, lifecycle, inventory, custody, documents)
 	transactionsRoutes := transactionsRouter.LoadRoutes()
 
-	businessesRouter := businesses.NewBusinessesRouter(storage, middleware)
+	businessesRouter := businesses.NewBusinessesRouter(storage, middleware, tokens, invitations, pricing, inventory, payouts, sessions)
 	businessesRoutes := businessesRouter.LoadRoutes()
 
+	lockoutRouter := lockoutsRoutes.NewLockoutsRouter(storage, middleware, lockouts)
+	lockoutRoutes := lockoutRouter.LoadRoutes()
+
+	tokenRouter := tokensRoutes.NewTokensRouter(storage, middleware, tokens)
+	tokenRoutes := tokenRouter.LoadRoutes()
+
+	invitationRouter := invitationsRoutes.NewInvitationsRouter(storage, middleware, session, sessions, passwords, invitations)
+	invitationRoutes := invitationRouter.LoadRoutes()
+
+	registrationRouter := registrationsRoutes.NewRegistrationsRouter(storage, middleware, registrations)
+	registrationRoutes := registrationRouter.LoadRoutes()
+
+	auditRouter := auditRoutes.NewAuditRouter(storage, middleware)
+	auditLogRoutes := auditRouter.LoadRoutes()
+
+	policyRouter := policiesRoutes.NewPoliciesRouter(storage, middleware)
+	policyRoutes := policyRouter.LoadRoutes()
+
+	carbonRouter := carbonRoutes.NewCarbonRouter(storage, middleware, carbon)
+	carbonSavingsRoutes := carbonRouter.LoadRoutes()
+
 	routes := []routing.Route{}
 
 	routes = append(routes, mfaRoutes...)
@@ -77,12 +130,28 @@ func NewHttpRouter(storage storage.Storage, middleware middleware.Middleware, se
 	routes = append(routes, transactionMaterialsRoutes...)
 	routes = append(routes, transactionsRoutes...)
 	routes = append(routes, businessesRoutes...)
+	routes = append(routes, lockoutRoutes...)
+	routes = append(routes, tokenRoutes...)
+	routes = append(routes, invitationRoutes...)
+	routes = append(routes, registrationRoutes...)
+	routes = append(routes, auditLogRoutes...)
+	routes = append(routes, policyRoutes...)
+	routes = append(routes, carbonSavingsRoutes...)
 
 	return &httpRouter{
-		storage:    storage,
-		middleware: middleware,
-		session:    session,
-		routes:     routes,
+		storage:       storage,
+		middleware:    middleware,
+		session:       session,
+		passwords:     passwords,
+		lockouts:      lockouts,
+		tokens:        tokens,
+		sessions:      sessions,
+		sso:           sso,
+		invitations:   invitations,
+		registrations: registrations,
+		impersonation: impersonation,
+		audit:         audit,
+		routes:        routes,
 	}
 }
 
@@ -107,9 +176,32 @@ func (h *httpRouter) InitializeOpenAPI() *openapi3.T {
 	paths := openapi3.NewPaths()
 
 	bodies := openapi3.RequestBodies{
-		"LoginPayload":     schemas.LoginPayloadSchema,
-		"RegisterPayload":  schemas.RegisterPayloadSchema,
-		"VerifyMfaPayload": schemas.VerifyMfaPayloadSchema,
+		"LoginPayload":                 schemas.LoginPayloadSchema,
+		"RegisterPayload":              schemas.RegisterPayloadSchema,
+		"VerifyMfaPayload":             schemas.VerifyMfaPayloadSchema,
+		"ChangePasswordPayload":        schemas.ChangePasswordPayloadSchema,
+		"SetPasswordPayload":           schemas.SetPasswordPayloadSchema,
+		"CreateApiTokenPayload":        schemas.CreateApiTokenPayloadSchema,
+		"CreateServiceAccountPayload":  schemas.CreateServiceAccountPayloadSchema,
+		"CreateInvitationPayload":      schemas.CreateInvitationPayloadSchema,
+		"AcceptInvitationPayload":      schemas.AcceptInvitationPayloadSchema,
+		"VerifyAccountPayload":         schemas.VerifyAccountPayloadSchema,
+		"ResendVerificationPayload":    schemas.ResendVerificationPayloadSchema,
+		"RejectRegistrationPayload":    schemas.RejectRegistrationPayloadSchema,
+		"StartImpersonationPayload":    schemas.StartImpersonationPayloadSchema,
+		"BusinessRolePayload":          schemas.BusinessRolePayloadSchema,
+		"CreateCarbonFactorPayload":    schemas.CreateCarbonFactorPayloadSchema,
+		"CreateMaterialPricePayload":   schemas.CreateMaterialPricePayloadSchema,
+		"CollectorGradePayload":        schemas.CollectorGradePayloadSchema,
+		"CreateCollectionLinePayload":  schemas.CreateCollectionLinePayloadSchema,
+		"StatusTransitionPayload":      schemas.StatusTransitionPayloadSchema,
+		"CreateCollectionPayload":      schemas.CreateCollectionPayloadSchema,
+		"CreateTransactionLinePayload": schemas.CreateTransactionLinePayloadSchema,
+		"CreateTransactionPayload":     schemas.CreateTransactionPayloadSchema,
+		"StockAdjustmentPayload":       schemas.StockAdjustmentPayloadSchema,
+		"CreatePayoutRunPayload":       schemas.CreatePayoutRunPayloadSchema,
+		"ConfirmPayoutRunPayload":      schemas.ConfirmPayoutRunPayloadSchema,
+		"VatSettingsPayload":           schemas.VatSettingsPayloadSchema,
 	}
 
 	schemas := openapi3.Schemas{
@@ -137,6 +229,34 @@ func (h *httpRouter) InitializeOpenAPI() *openapi3.T {
 		"AssignMaterials":            schemas.AssignMaterialsSchema,
 		"CreateMaterial":             schemas.CreateMaterialSchema,
 		"UpdateMaterial":             schemas.UpdateMaterialSchema,
+		"MaterialCarbonFactor":       schemas.MaterialCarbonFactorSchema,
+		"MaterialCarbonFactors":      schemas.MaterialCarbonFactorsSchema,
+		"CarbonSavings":              schemas.CarbonSavingsSchema,
+		"MaterialPrice":              schemas.MaterialPriceSchema,
+		"MaterialPrices":             schemas.MaterialPricesSchema,
+		"CollectorGrade":             schemas.CollectorGradeSchema,
+		"CollectorGrades":            schemas.CollectorGradesSchema,
+		"StockMovement":              schemas.StockMovementSchema,
+		"StockMovements":             schemas.StockMovementsSchema,
+		"StockLevel":                 schemas.StockLevelSchema,
+		"StockShortfall":             schemas.StockShortfallSchema,
+		"Lineage":                    schemas.LineageSchema,
+		"LineageNode":                schemas.LineageNodeSchema,
+		"LineageEdge":                schemas.LineageEdgeSchema,
+		"PayoutRun":                  schemas.PayoutRunSchema,
+		"PayoutRuns":                 schemas.PayoutRunsSchema,
+		"PayoutStatement":            schemas.PayoutStatementSchema,
+		"PayoutStatements":           schemas.PayoutStatementsSchema,
+		"PayoutItem":                 schemas.PayoutItemSchema,
+		"PayoutItems":                schemas.PayoutItemsSchema,
+		"PayoutSkipped":              schemas.PayoutSkippedSchema,
+		"PayoutReconciliation":       schemas.PayoutReconciliationSchema,
+		"PayoutDiscrepancy":          schemas.PayoutDiscrepancySchema,
+		"VatSettings":                schemas.VatSettingsSchema,
+		"Status":                     schemas.StatusSchema,
+		"StatusChange":               schemas.StatusChangeSchema,
+		"StatusChanges":              schemas.StatusChangesSchema,
+		"UnmatchedLine":              schemas.UnmatchedLineSchema,
 		"Collection":                 schemas.CollectionSchema,
 		"Collections":                schemas.CollectionsSchema,
 		"CreateCollection":           schemas.CreateCollectionSchema,
@@ -167,6 +287,22 @@ func (h *httpRouter) InitializeOpenAPI() *openapi3.T {
 		"Permissions":                schemas.PermissionsSchema,
 		"PermissionGroup":            schemas.PermissionGroupSchema,
 		"PermissionGroups":           schemas.PermissionGroupsSchema,
+		"Lockout":                    schemas.LockoutSchema,
+		"Lockouts":                   schemas.LockoutsSchema,
+		"ApiToken":                   schemas.ApiTokenSchema,
+		"ApiTokens":                  schemas.ApiTokensSchema,
+		"UserSession":                schemas.UserSessionSchema,
+		"UserSessions":               schemas.UserSessionsSchema,
+		"Invitation":                 schemas.InvitationSchema,
+		"Invitations":                schemas.InvitationsSchema,
+		"AuditLog":                   schemas.AuditLogSchema,
+		"AuditLogs":                  schemas.AuditLogsSchema,
+		"Impersonation":              schemas.ImpersonationSchema,
+		"Membership":                 schemas.MembershipSchema,
+		"Memberships":                schemas.MembershipsSchema,
+		"Policy":                     schemas.PolicySchema,
+		"Policies":                   schemas.PoliciesSchema,
+		"PolicyDecision":             schemas.PolicyDecisionSchema,
 	}
 
 	for _, route := range h.routes {
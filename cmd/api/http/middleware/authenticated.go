@@ -1,17 +1,27 @@
 package middleware
 
 import (
-	"time"
+	"errors"
+	"strings"
 
+	"github.com/connor-davis/threereco-nextgen/internal/impersonation"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
+	"github.com/connor-davis/threereco-nextgen/internal/tokens"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
+	"github.com/gofiber/fiber/v2/middleware/session"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
 func (m *middleware) Authenticated() fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		if authorization := c.Get(fiber.HeaderAuthorization); strings.HasPrefix(authorization, "Bearer ") {
+			return m.authenticateToken(c, strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer ")))
+		}
+
 		currentSession, err := m.session.Get(c)
 
 		if err != nil {
@@ -43,9 +53,9 @@ func (m *middleware) Authenticated() fiber.Handler {
 			})
 		}
 
-		var currentUser *models.User
+		principal, err := m.principals.Resolve(currentUserIdUUID)
 
-		if err := m.storage.Database().Where("id = ?", currentUserIdUUID).Preload("Roles").Preload("Businesses").First(&currentUser).Error; err != nil {
+		if err != nil {
 			if err == gorm.ErrRecordNotFound {
 				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
 					"error":   "Unauthorized",
@@ -61,14 +71,67 @@ func (m *middleware) Authenticated() fiber.Handler {
 			})
 		}
 
-		c.Locals("user_id", currentUser.Id.String())
-		c.Locals("user", currentUser)
+		currentUser := &principal.User
+
+		if err := m.sessions.Refresh(c, currentSession); err != nil {
+			if errors.Is(err, sessions.ErrSessionExpired) {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error":   "Unauthorized",
+					"message": "Your session has expired. Please log in again.",
+				})
+			}
+
+			log.Errorf("🔥 Failed to refresh session: %s", err.Error())
+
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   "Internal Server Error",
+				"message": err.Error(),
+			})
+		}
+
+		if !safeMethod(c.Method()) && !m.sessions.VerifyCsrf(c, currentSession) {
+			log.Warnf("⚠️ Rejected request with a missing or invalid CSRF token from %s", c.IP())
+
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error":   "Forbidden",
+				"message": "The CSRF token is missing or invalid.",
+			})
+		}
+
+		activeImpersonation, err := m.impersonation.Resolve(currentSession, currentUser.Id)
+
+		if err != nil {
+			if errors.Is(err, impersonation.ErrEnded) {
+				if activeImpersonation != nil {
+					m.audit.Request(c, models.AuditLog{
+						Action:          models.ImpersonationStoppedAudit,
+						ActorId:         currentUser.Id,
+						UserId:          &activeImpersonation.UserId,
+						ImpersonationId: &activeImpersonation.Id,
+						Status:          fiber.StatusUnauthorized,
+					})
+				}
 
-		currentSession.Set("user_id", currentUser.Id.String())
-		currentSession.SetExpiry(1 * time.Hour)
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error":   "Unauthorized",
+					"message": "The impersonation has ended.",
+				})
+			}
+
+			log.Errorf("🔥 Failed to resolve impersonation: %s", err.Error())
+
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   "Internal Server Error",
+				"message": err.Error(),
+			})
+		}
+
+		if activeImpersonation != nil {
+			return m.impersonate(c, currentSession, currentUser, activeImpersonation)
+		}
 
-		if err := currentSession.Save(); err != nil {
-			log.Errorf("🔥 Failed to save session: %s", err.Error())
+		if err := m.activateBusiness(currentUser, activeBusiness(currentUser, m.sessions.ActiveBusiness(currentSession))); err != nil {
+			log.Errorf("🔥 Failed to retrieve business roles: %s", err.Error())
 
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error":   "Internal Server Error",
@@ -76,6 +139,151 @@ func (m *middleware) Authenticated() fiber.Handler {
 			})
 		}
 
+		c.Locals("user_id", currentUser.Id.String())
+		c.Locals("user", currentUser)
+
 		return c.Next()
 	}
 }
+
+// impersonate continues the request as the impersonated user. The real actor
+// stays available in the "actor" local, every response is marked with the
+// impersonation headers and the request is recorded under both identities.
+func (m *middleware) impersonate(c *fiber.Ctx, currentSession *session.Session, actor *models.User, activeImpersonation *models.Impersonation) error {
+	principal, err := m.principals.Resolve(activeImpersonation.UserId)
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error":   "Unauthorized",
+				"message": "The impersonated user no longer exists.",
+			})
+		}
+
+		log.Errorf("🔥 Failed to retrieve impersonated user from database: %s", err.Error())
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	effectiveUser := &principal.User
+
+	if err := m.activateBusiness(effectiveUser, activeBusiness(effectiveUser, m.sessions.ActiveBusiness(currentSession))); err != nil {
+		log.Errorf("🔥 Failed to retrieve business roles: %s", err.Error())
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	c.Set("X-Impersonated-By", actor.Username)
+	c.Set("X-Impersonation-Id", activeImpersonation.Id.String())
+
+	c.Locals("user_id", effectiveUser.Id.String())
+	c.Locals("user", effectiveUser)
+	c.Locals("actor", actor)
+	c.Locals("impersonation", activeImpersonation)
+
+	err = c.Next()
+
+	status := c.Response().StatusCode()
+
+	if err != nil {
+		var fiberError *fiber.Error
+
+		if errors.As(err, &fiberError) {
+			status = fiberError.Code
+		} else {
+			status = fiber.StatusInternalServerError
+		}
+	}
+
+	m.audit.Request(c, models.AuditLog{
+		Action:          models.ImpersonationRequestAudit,
+		ActorId:         actor.Id,
+		UserId:          &effectiveUser.Id,
+		ImpersonationId: &activeImpersonation.Id,
+		Status:          status,
+	})
+
+	return err
+}
+
+// authenticateToken resolves a bearer api token to its owner. The owner's
+// permissions are narrowed to the token's permissions so that Authorized only
+// grants what both the token and its owner currently allow, in whichever
+// business is active.
+func (m *middleware) authenticateToken(c *fiber.Ctx, raw string) error {
+	token, err := m.tokens.Resolve(raw)
+
+	if err != nil {
+		if errors.Is(err, tokens.ErrInvalidToken) {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error":   "Unauthorized",
+				"message": "The API token is invalid, expired or revoked.",
+			})
+		}
+
+		log.Errorf("🔥 Failed to resolve API token: %s", err.Error())
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	principal, err := m.principals.Resolve(token.UserId)
+
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error":   "Unauthorized",
+				"message": "The API token is invalid, expired or revoked.",
+			})
+		}
+
+		log.Errorf("🔥 Failed to retrieve user from database: %s", err.Error())
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	currentUser := &principal.User
+
+	currentUser.TokenPermissions = append(pq.StringArray{}, token.Permissions...)
+
+	if err := m.activateBusiness(currentUser, currentUser.BusinessId); err != nil {
+		log.Errorf("🔥 Failed to retrieve business roles: %s", err.Error())
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": err.Error(),
+		})
+	}
+
+	if err := m.tokens.Touch(token); err != nil {
+		log.Errorf("🔥 Failed to update API token usage: %s", err.Error())
+	}
+
+	c.Locals("user_id", currentUser.Id.String())
+	c.Locals("user", currentUser)
+	c.Locals("token", token)
+
+	return c.Next()
+}
+
+// safeMethod reports whether a request method is read-only and therefore
+// doesn't need csrf protection.
+func safeMethod(method string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions, fiber.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
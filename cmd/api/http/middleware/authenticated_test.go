@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/impersonation"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/principals"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/google/uuid"
+)
+
+// staticPrincipals resolves every user to the same principal, so the tests
+// don't need a database.
+type staticPrincipals struct {
+	principal principals.Principal
+}
+
+func (s *staticPrincipals) Resolve(userId uuid.UUID) (*principals.Principal, error) {
+	principal := s.principal
+
+	return &principal, nil
+}
+
+func (s *staticPrincipals) Invalidate(userIds ...uuid.UUID) {}
+
+func (s *staticPrincipals) InvalidateAll() {}
+
+const csrfToken = "test-csrf-token"
+
+// newCsrfApp serves /login, which signs a session in with a known csrf token,
+// and /write behind Authenticated for every method.
+func newCsrfApp(t *testing.T) *fiber.App {
+	t.Helper()
+
+	config := sessions.Config{
+		KeyLookup:        "cookie:threereco_session",
+		IdleTimeout:      time.Hour,
+		AbsoluteLifetime: 12 * time.Hour,
+		RefreshInterval:  5 * time.Minute,
+		CsrfCookieName:   "threereco_csrf",
+		CsrfHeader:       "X-CSRF-Token",
+	}
+
+	store := session.New(session.Config{KeyLookup: config.KeyLookup})
+	user := models.User{Base: models.Base{Id: uuid.New()}}
+
+	m := New(
+		nil,
+		store,
+		nil,
+		sessions.NewManager(nil, store, config),
+		impersonation.New(nil),
+		nil,
+		&staticPrincipals{principal: principals.Principal{User: user}},
+		nil,
+	)
+
+	app := fiber.New()
+
+	app.Get("/login", func(c *fiber.Ctx) error {
+		currentSession, err := store.Get(c)
+
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().Unix()
+
+		currentSession.Set("user_id", user.Id.String())
+		currentSession.Set("created_at", now)
+		currentSession.Set("refreshed_at", now)
+		currentSession.Set("csrf_token", csrfToken)
+
+		return currentSession.Save()
+	})
+
+	app.All("/write", m.Authenticated(), func(c *fiber.Ctx) error {
+		return c.SendString("OK")
+	})
+
+	return app
+}
+
+func TestAuthenticatedCsrf(t *testing.T) {
+	app := newCsrfApp(t)
+
+	response, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/login", nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cookies := response.Cookies()
+
+	if len(cookies) == 0 {
+		t.Fatal("the login didn't set a session cookie")
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		header string
+		status int
+	}{
+		{name: "read without a token", method: fiber.MethodGet, status: fiber.StatusOK},
+		{name: "write without a token", method: fiber.MethodPost, status: fiber.StatusForbidden},
+		{name: "write with a wrong token", method: fiber.MethodPut, header: "wrong", status: fiber.StatusForbidden},
+		{name: "delete without a token", method: fiber.MethodDelete, status: fiber.StatusForbidden},
+		{name: "write with the token", method: fiber.MethodPost, header: csrfToken, status: fiber.StatusOK},
+		{name: "patch with the token", method: fiber.MethodPatch, header: csrfToken, status: fiber.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(test.method, "/write", nil)
+
+			for _, cookie := range cookies {
+				request.AddCookie(&http.Cookie{Name: cookie.Name, Value: cookie.Value})
+			}
+
+			if test.header != "" {
+				request.Header.Set("X-CSRF-Token", test.header)
+			}
+
+			response, err := app.Test(request)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if response.StatusCode != test.status {
+				t.Errorf("got status %d, want %d", response.StatusCode, test.status)
+			}
+		})
+	}
+}
@@ -1,14 +1,17 @@
 package middleware
 
 import (
-	"strings"
-
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/permissions"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
 )
 
-func (m *middleware) Authorized(permissions ...string) fiber.Handler {
+// Authorized requires at least one of the permissions. Routes with a
+// "businessId" parameter are evaluated against the user's roles in that
+// business instead of the active one.
+func (m *middleware) Authorized(requiredPermissions ...string) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		user, ok := c.Locals("user").(*models.User)
 
@@ -19,18 +22,19 @@ func (m *middleware) Authorized(permissions ...string) fiber.Handler {
 			})
 		}
 
-		combinedPermissions := []string{}
-
-		for _, permission := range user.Permissions {
-			combinedPermissions = append(combinedPermissions, permission)
-		}
+		if businessId, err := uuid.Parse(c.Params("businessId")); err == nil {
+			if err := m.activateBusiness(user, &businessId); err != nil {
+				log.Errorf("🔥 Failed to retrieve business roles: %s", err.Error())
 
-		for _, role := range user.Roles {
-			for _, permission := range role.Permissions {
-				combinedPermissions = append(combinedPermissions, permission)
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
 			}
 		}
 
+		combinedPermissions := permissions.Effective(user)
+
 		if len(combinedPermissions) == 0 {
 			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 				"error":   "Forbidden",
@@ -38,27 +42,11 @@ func (m *middleware) Authorized(permissions ...string) fiber.Handler {
 			})
 		}
 
-		for _, userPermission := range combinedPermissions {
-			if userPermission == "*" {
-				return c.Next()
-			}
-
-			userPermission = strings.TrimSuffix(strings.TrimSpace(userPermission), ".*")
-
-			for _, requiredPermission := range permissions {
-				requiredPermission = strings.TrimSpace(requiredPermission)
-
-				if userPermission == requiredPermission {
-					return c.Next()
-				}
-
-				if strings.HasPrefix(requiredPermission, userPermission) {
-					return c.Next()
-				}
-			}
+		if permissions.Allows(combinedPermissions, requiredPermissions...) {
+			return c.Next()
 		}
 
-		log.Warnf("⚠️ User %s does not have required permissions: %v", user.Username, permissions)
+		log.Warnf("⚠️ User %s does not have required permissions: %v", user.Username, requiredPermissions)
 
 		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
 			"error":   "Forbidden",
@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/google/uuid"
+)
+
+// activateBusiness makes businessId the business that the user's
+// business-scoped roles and policies are evaluated against. Users that aren't
+// a member of the business keep only their global permissions.
+func (m *middleware) activateBusiness(user *models.User, businessId *uuid.UUID) error {
+	user.ActiveBusinessId = nil
+	user.BusinessRoles = []models.Role{}
+
+	if businessId == nil {
+		return nil
+	}
+
+	if !user.MemberOf(*businessId) {
+		return nil
+	}
+
+	principal, err := m.principals.Resolve(user.Id)
+
+	if err != nil {
+		return err
+	}
+
+	activeBusinessId := *businessId
+
+	user.ActiveBusinessId = &activeBusinessId
+	user.BusinessRoles = append(user.BusinessRoles, principal.BusinessRoles[activeBusinessId]...)
+
+	return nil
+}
+
+// activeBusiness picks the business switched to in the session when the user
+// is still a member of it, and the user's default business otherwise.
+func activeBusiness(user *models.User, sessionBusinessId *uuid.UUID) *uuid.UUID {
+	if sessionBusinessId != nil && user.MemberOf(*sessionBusinessId) {
+		return sessionBusinessId
+	}
+
+	return user.BusinessId
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"encoding/json"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// DefaultBusiness fills the named body field with the active business when a
+// business or service user leaves it out, so that records are created for the
+// business the user is currently acting for.
+func (m *middleware) DefaultBusiness(field string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals("user").(*models.User)
+
+		if !ok || user == nil || user.ActiveBusinessId == nil {
+			return c.Next()
+		}
+
+		if user.Type != models.BusinessUser && user.Type != models.ServiceUser {
+			return c.Next()
+		}
+
+		var body map[string]any
+
+		if err := json.Unmarshal(c.Body(), &body); err != nil || body == nil {
+			return c.Next()
+		}
+
+		if value, ok := body[field].(string); ok {
+			if businessId, err := uuid.Parse(value); err == nil && businessId != uuid.Nil {
+				return c.Next()
+			}
+		}
+
+		body[field] = user.ActiveBusinessId.String()
+
+		encoded, err := json.Marshal(body)
+
+		if err != nil {
+			return c.Next()
+		}
+
+		c.Request().SetBody(encoded)
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+// NotImpersonating blocks routes that must only ever be used by the account
+// owner, such as password and MFA changes, while a session is impersonating.
+func (m *middleware) NotImpersonating() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		activeImpersonation, ok := c.Locals("impersonation").(*models.Impersonation)
+
+		if !ok || activeImpersonation == nil {
+			return c.Next()
+		}
+
+		log.Warnf("⚠️ Blocked %s %s while impersonating user %s", c.Method(), c.Path(), activeImpersonation.UserId)
+
+		m.audit.Request(c, models.AuditLog{
+			Action:          models.ImpersonationBlockedAudit,
+			ActorId:         activeImpersonation.ActorId,
+			UserId:          &activeImpersonation.UserId,
+			ImpersonationId: &activeImpersonation.Id,
+			Status:          fiber.StatusForbidden,
+		})
+
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error":   "Forbidden",
+			"message": "This action is not available while impersonating another user.",
+		})
+	}
+}
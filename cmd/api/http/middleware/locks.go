@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+)
+
+// Unlocked rejects the request when the collection or transaction named by
+// the route parameter has been approved, paid or voided.
+func (m *middleware) Unlocked(entity models.PolicyType, param string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params(param))
+
+		if err != nil {
+			return c.Next()
+		}
+
+		locked, err := m.lifecycle.Locked(entity, id)
+
+		return m.locked(c, locked, err)
+	}
+}
+
+// LineUnlocked rejects the request when the line named by the route parameter
+// belongs to a collection or transaction that has been approved, paid or
+// voided.
+func (m *middleware) LineUnlocked(entity models.PolicyType, param string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := uuid.Parse(c.Params(param))
+
+		if err != nil {
+			return c.Next()
+		}
+
+		locked, err := m.lifecycle.LineLocked(entity, id)
+
+		return m.locked(c, locked, err)
+	}
+}
+
+func (m *middleware) locked(c *fiber.Ctx, locked bool, err error) error {
+	if err != nil {
+		log.Errorf("🔥 Error checking lifecycle lock: %s", err.Error())
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": "An error occurred while processing your request.",
+		})
+	}
+
+	if locked {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   "Conflict",
+			"message": "The record has been approved, paid or voided and can no longer be changed.",
+		})
+	}
+
+	return c.Next()
+}
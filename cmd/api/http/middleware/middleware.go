@@ -1,8 +1,15 @@
 package middleware
 
 import (
+	"github.com/connor-davis/threereco-nextgen/internal/audit"
+	"github.com/connor-davis/threereco-nextgen/internal/impersonation"
+	"github.com/connor-davis/threereco-nextgen/internal/lifecycle"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/principals"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/connor-davis/threereco-nextgen/internal/tokens"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/session"
 )
@@ -10,17 +17,34 @@ import (
 type Middleware interface {
 	Authenticated() fiber.Handler
 	Authorized(permissions ...string) fiber.Handler
-	Policies(policies ...models.PolicyType) fiber.Handler
+	Policies(entity models.PolicyType, action policies.Action) fiber.Handler
+	LinePolicies(entity models.PolicyType, action policies.Action) fiber.Handler
+	NotImpersonating() fiber.Handler
+	DefaultBusiness(field string) fiber.Handler
+	Unlocked(entity models.PolicyType, param string) fiber.Handler
+	LineUnlocked(entity models.PolicyType, param string) fiber.Handler
 }
 
 type middleware struct {
-	storage storage.Storage
-	session *session.Store
+	storage       storage.Storage
+	session       *session.Store
+	tokens        tokens.Tokens
+	sessions      sessions.Manager
+	impersonation impersonation.Impersonation
+	audit         audit.Audit
+	principals    principals.Principals
+	lifecycle     lifecycle.Lifecycle
 }
 
-func New(storage storage.Storage, session *session.Store) Middleware {
+func New(storage storage.Storage, session *session.Store, tokens tokens.Tokens, sessions sessions.Manager, impersonation impersonation.Impersonation, audit audit.Audit, principals principals.Principals, lifecycle lifecycle.Lifecycle) Middleware {
 	return &middleware{
-		storage: storage,
-		session: session,
+		storage:       storage,
+		session:       session,
+		tokens:        tokens,
+		sessions:      sessions,
+		impersonation: impersonation,
+		audit:         audit,
+		principals:    principals,
+		lifecycle:     lifecycle,
 	}
 }
@@ -2,12 +2,31 @@ package middleware
 
 import (
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
 	"gorm.io/gorm/clause"
 )
 
-func (m *middleware) Policies(policies ...models.PolicyType) fiber.Handler {
+// Policies evaluates the policy registry for the entity and action. Allowed
+// requests continue with the row filter in the "policies" local, which the
+// generic api handlers apply to every query.
+func (m *middleware) Policies(entity models.PolicyType, action policies.Action) fiber.Handler {
+	return m.policies(entity, action, func(scope clause.Expression) clause.Expression {
+		return scope
+	})
+}
+
+// LinePolicies evaluates the policy registry for the collections or
+// transactions the lines belong to, and limits the lines to those of the
+// records the user may access.
+func (m *middleware) LinePolicies(entity models.PolicyType, action policies.Action) fiber.Handler {
+	return m.policies(entity, action, func(scope clause.Expression) clause.Expression {
+		return policies.Lines(entity, scope)
+	})
+}
+
+func (m *middleware) policies(entity models.PolicyType, action policies.Action, filter func(scope clause.Expression) clause.Expression) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		user, ok := c.Locals("user").(*models.User)
 
@@ -18,62 +37,21 @@ func (m *middleware) Policies(policies ...models.PolicyType) fiber.Handler {
 			})
 		}
 
-		for _, policy := range policies {
-			switch policy {
-			case models.CollectionsPolicy:
-				switch user.Type {
-				case models.CollectorUser:
-					c.Locals("policies", clause.Or(
-						clause.Eq{
-							Column: clause.Column{
-								Name: "seller_id",
-							},
-							Value: user.Id,
-						},
-					))
-				case models.BusinessUser:
-					c.Locals("policies", clause.Or(
-						clause.Eq{
-							Column: clause.Column{
-								Name: "buyer_id",
-							},
-							Value: user.BusinessId,
-						},
-					))
-				}
+		decision := policies.Evaluate(policies.Registry, user, entity, action)
 
-				return c.Next()
-			case models.TransactionsPolicy:
-				if user.Type == models.BusinessUser {
-					c.Locals("policies", clause.Or(
-						clause.Eq{
-							Column: clause.Column{
-								Name: "seller_id",
-							},
-							Value: user.BusinessId,
-						},
-						clause.Eq{
-							Column: clause.Column{
-								Name: "buyer_id",
-							},
-							Value: user.BusinessId,
-						},
-					))
+		if !decision.Allowed {
+			log.Warnf("⚠️ User %s does not satisfy the %s %s policies: %s", user.Username, action, entity, decision.Reason)
 
-					return c.Next()
-				}
-			case models.SystemPolicy:
-				if user.Type == models.SystemUser {
-					return c.Next()
-				}
-			}
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error":   "Forbidden",
+				"message": "You do not have permission to access this resource.",
+			})
 		}
 
-		log.Warnf("⚠️ User %s does not satisfy required policies: %v", user.Username, policies)
+		if expression := filter(decision.Expression()); expression != nil {
+			c.Locals("policies", expression)
+		}
 
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
-			"error":   "Forbidden",
-			"message": "You do not have permission to access this resource.",
-		})
+		return c.Next()
 	}
 }
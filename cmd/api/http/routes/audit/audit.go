@@ -0,0 +1,45 @@
+package audit
+
+import (
+	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
+	"github.com/connor-davis/threereco-nextgen/internal/api"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+)
+
+type AuditRouter struct {
+	storage    storage.Storage
+	middleware middleware.Middleware
+}
+
+func NewAuditRouter(storage storage.Storage, middleware middleware.Middleware) Router {
+	return &AuditRouter{
+		storage:    storage,
+		middleware: middleware,
+	}
+}
+
+func (r *AuditRouter) LoadRoutes() []routing.Route {
+	api := api.NewBaseApi[models.AuditLog](
+		r.storage,
+		"/audit-logs",
+		"Audit Log",
+		"",
+		"",
+	)
+
+	getAllRoute := api.GetAllRoute(
+		r.middleware.Authenticated(),
+		r.middleware.Authorized("audit.view"),
+	)
+	getOneRoute := api.GetOneRoute(
+		r.middleware.Authenticated(),
+		r.middleware.Authorized("audit.view"),
+	)
+
+	return []routing.Route{
+		getAllRoute,
+		getOneRoute,
+	}
+}
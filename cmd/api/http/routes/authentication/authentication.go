@@ -2,22 +2,43 @@ package authentication
 
 import (
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
+	"github.com/connor-davis/threereco-nextgen/internal/audit"
+	"github.com/connor-davis/threereco-nextgen/internal/impersonation"
+	"github.com/connor-davis/threereco-nextgen/internal/lockouts"
+	"github.com/connor-davis/threereco-nextgen/internal/passwords"
+	"github.com/connor-davis/threereco-nextgen/internal/registrations"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
+	"github.com/connor-davis/threereco-nextgen/internal/sso"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
 	"github.com/gofiber/fiber/v2/middleware/session"
 )
 
 type AuthenticationRouter struct {
-	storage    storage.Storage
-	middleware middleware.Middleware
-	session    *session.Store
+	storage       storage.Storage
+	middleware    middleware.Middleware
+	session       *session.Store
+	passwords     passwords.Passwords
+	lockouts      lockouts.Lockouts
+	sessions      sessions.Manager
+	sso           sso.Sso
+	registrations registrations.Registrations
+	impersonation impersonation.Impersonation
+	audit         audit.Audit
 }
 
-func NewAuthenticationRouter(storage storage.Storage, middleware middleware.Middleware, session *session.Store) Router {
+func NewAuthenticationRouter(storage storage.Storage, middleware middleware.Middleware, session *session.Store, passwords passwords.Passwords, lockouts lockouts.Lockouts, sessions sessions.Manager, sso sso.Sso, registrations registrations.Registrations, impersonation impersonation.Impersonation, audit audit.Audit) Router {
 	return &AuthenticationRouter{
-		storage:    storage,
-		middleware: middleware,
-		session:    session,
+		storage:       storage,
+		middleware:    middleware,
+		session:       session,
+		passwords:     passwords,
+		lockouts:      lockouts,
+		sessions:      sessions,
+		sso:           sso,
+		registrations: registrations,
+		impersonation: impersonation,
+		audit:         audit,
 	}
 }
 
@@ -25,14 +46,42 @@ func (r *AuthenticationRouter) LoadRoutes() []routing.Route {
 	checkRoute := r.CheckRoute()
 	loginRoute := r.LoginRoute()
 	registerRoute := r.RegisterRoute()
+	verifyRoute := r.VerifyRoute()
+	resendVerificationRoute := r.ResendVerificationRoute()
 	permissionsRoute := r.PermissionsRoute()
 	logoutRoute := r.LogoutRoute()
+	changePasswordRoute := r.ChangePasswordRoute()
+	listSessionsRoute := r.ListSessionsRoute()
+	revokeSessionRoute := r.RevokeSessionRoute()
+	revokeSessionsRoute := r.RevokeSessionsRoute()
+	oidcProvidersRoute := r.OidcProvidersRoute()
+	oidcLoginRoute := r.OidcLoginRoute()
+	oidcCallbackRoute := r.OidcCallbackRoute()
+	stopImpersonationRoute := r.StopImpersonationRoute()
+	listBusinessesRoute := r.ListBusinessesRoute()
+	switchBusinessRoute := r.SwitchBusinessRoute()
+	myPermissionsRoute := r.MyPermissionsRoute()
+	canRoute := r.CanRoute()
 
 	return []routing.Route{
 		checkRoute,
 		loginRoute,
 		registerRoute,
+		verifyRoute,
+		resendVerificationRoute,
 		permissionsRoute,
 		logoutRoute,
+		changePasswordRoute,
+		listSessionsRoute,
+		revokeSessionRoute,
+		revokeSessionsRoute,
+		oidcProvidersRoute,
+		oidcLoginRoute,
+		oidcCallbackRoute,
+		stopImpersonationRoute,
+		listBusinessesRoute,
+		switchBusinessRoute,
+		myPermissionsRoute,
+		canRoute,
 	}
 }
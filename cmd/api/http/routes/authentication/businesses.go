@@ -0,0 +1,270 @@
+package authentication
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type SwitchBusinessParams struct {
+	BusinessId uuid.UUID `param:"businessId"`
+}
+
+func (r *AuthenticationRouter) ListBusinessesRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Businesses retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Get My Businesses",
+			Description: "Retrieves the businesses you are a member of together with the one you are currently acting for.",
+			Tags:        []string{"Authentication"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/authentication/businesses",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			businessIds := []uuid.UUID{}
+
+			for _, business := range currentUser.Businesses {
+				businessIds = append(businessIds, business.Id)
+			}
+
+			if currentUser.BusinessId != nil {
+				businessIds = append(businessIds, *currentUser.BusinessId)
+			}
+
+			businesses := []models.Business{}
+
+			if len(businessIds) > 0 {
+				if err := r.storage.Database().
+					Where("id IN ?", businessIds).
+					Order("name ASC").
+					Find(&businesses).Error; err != nil {
+					log.Errorf("🔥 Error retrieving businesses: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
+					})
+				}
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items":            businesses,
+				"activeBusinessId": currentUser.ActiveBusinessId,
+			})
+		},
+	}
+}
+
+func (r *AuthenticationRouter) SwitchBusinessRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Business switched successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Switch Business",
+			Description: "Makes a business you are a member of the active business for the rest of the session. Business-scoped permissions, policies and defaults all follow the active business.",
+			Tags:        []string{"Authentication"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.POST,
+		Path:   "/authentication/businesses/{businessId}/switch",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			if c.Locals("token") != nil {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "Switching business is only available to signed in users.",
+				})
+			}
+
+			var params SwitchBusinessParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var business models.Business
+
+			if err := r.storage.Database().Where("id = ?", params.BusinessId).First(&business).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The business was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving business: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if !currentUser.MemberOf(business.Id) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You are not a member of this business.",
+				})
+			}
+
+			currentSession, err := r.session.Get(c)
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.sessions.SwitchBusiness(currentSession, business.Id); err != nil {
+				log.Errorf("🔥 Error switching business: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
@@ -63,7 +63,7 @@ func (r *AuthenticationRouter) CheckRoute() routing.Route {
 	return routing.Route{
 		OpenAPIMetadata: routing.OpenAPIMetadata{
 			Summary:     "Check Authentication",
-			Description: "Checks if the user is authenticated.",
+			Description: "Checks if the user is authenticated. While impersonating, the real user and the impersonation are returned alongside the impersonated user.",
 			Tags:        []string{"Authentication"},
 			Parameters:  nil,
 			RequestBody: nil,
@@ -84,6 +84,14 @@ func (r *AuthenticationRouter) CheckRoute() routing.Route {
 				})
 			}
 
+			if actor, ok := c.Locals("actor").(*models.User); ok && actor != nil {
+				return c.Status(fiber.StatusOK).JSON(fiber.Map{
+					"item":           user,
+					"impersonatedBy": actor,
+					"impersonation":  c.Locals("impersonation"),
+				})
+			}
+
 			return c.Status(fiber.StatusOK).JSON(fiber.Map{
 				"item": user,
 			})
@@ -0,0 +1,121 @@
+package authentication
+
+import (
+	"errors"
+
+	"github.com/connor-davis/threereco-nextgen/internal/impersonation"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+func (r *AuthenticationRouter) StopImpersonationRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Impersonation stopped successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Stop Impersonation",
+			Description: "Stops impersonating and returns the session to the real user.",
+			Tags:        []string{"Authentication"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.POST,
+		Path:   "/authentication/impersonation/stop",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			if _, ok := c.Locals("impersonation").(*models.Impersonation); !ok {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "You are not impersonating anyone.",
+				})
+			}
+
+			if err := r.stopImpersonation(c); err != nil {
+				log.Errorf("🔥 Error stopping impersonation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
+
+// stopImpersonation ends the session's impersonation, if any, and records it
+// in the audit log.
+func (r *AuthenticationRouter) stopImpersonation(c *fiber.Ctx) error {
+	currentSession, err := r.session.Get(c)
+
+	if err != nil {
+		return err
+	}
+
+	stopped, err := r.impersonation.Stop(currentSession)
+
+	if err != nil {
+		if errors.Is(err, impersonation.ErrNotImpersonating) {
+			return nil
+		}
+
+		return err
+	}
+
+	r.audit.Request(c, models.AuditLog{
+		Action:          models.ImpersonationStoppedAudit,
+		ActorId:         stopped.ActorId,
+		UserId:          &stopped.UserId,
+		ImpersonationId: &stopped.Id,
+		Status:          fiber.StatusOK,
+	})
+
+	return nil
+}
@@ -1,9 +1,11 @@
 package authentication
 
 import (
-	"time"
+	"errors"
 
+	"github.com/connor-davis/threereco-nextgen/internal/lockouts"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/registrations"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
 	"github.com/getkin/kin-openapi/openapi3"
@@ -13,6 +15,10 @@ import (
 	"gorm.io/gorm"
 )
 
+// dummyPassword is compared against when the username does not exist so that
+// unknown and known usernames take the same amount of time to reject.
+var dummyPassword, _ = bcrypt.GenerateFromPassword([]byte("threereco-dummy-password"), bcrypt.DefaultCost)
+
 func (r *AuthenticationRouter) LoginRoute() routing.Route {
 	responses := openapi3.NewResponses()
 
@@ -55,6 +61,16 @@ func (r *AuthenticationRouter) LoginRoute() routing.Route {
 			}),
 	})
 
+	responses.Set("429", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Too Many Requests").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
 	responses.Set("500", &openapi3.ResponseRef{
 		Value: openapi3.NewResponse().
 			WithJSONSchemaRef(schemas.ErrorSchema).
@@ -91,24 +107,85 @@ func (r *AuthenticationRouter) LoginRoute() routing.Route {
 				})
 			}
 
+			accountKey := lockouts.Account(models.LoginAccountLockout, payload.Username)
+			ipKey := lockouts.Ip(models.LoginIpLockout, c.IP())
+
+			retryAfter, err := r.lockouts.Check(accountKey, ipKey)
+
+			if err != nil {
+				log.Errorf("🔥 Error checking lockouts: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if retryAfter > 0 {
+				c.Set(fiber.HeaderRetryAfter, lockouts.RetryAfterSeconds(retryAfter))
+
+				return c.Status(fiber.StatusTooManyRequests).JSON(&fiber.Map{
+					"error":   "Too Many Requests",
+					"message": "Too many failed login attempts. Please try again later.",
+				})
+			}
+
 			var existingUser models.User
 
+			found := true
+
 			if err := r.storage.Database().
 				Where(
 					"username = ?",
 					payload.Username,
 				).
 				First(&existingUser).Error; err != nil {
-				if err == gorm.ErrRecordNotFound {
-					log.Warnf("⚠️ User with username %s not found", payload.Username)
+				if err != gorm.ErrRecordNotFound {
+					log.Errorf("🔥 Error retrieving user: %s", err.Error())
 
-					return c.Status(fiber.StatusUnauthorized).JSON(&fiber.Map{
-						"error":   "Unauthorized",
-						"message": "You are not authorized to access this resource.",
+					return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
 					})
 				}
 
-				log.Errorf("🔥 Error retrieving user: %s", err.Error())
+				found = false
+				existingUser.Password = dummyPassword
+			}
+
+			if err := bcrypt.CompareHashAndPassword(existingUser.Password, []byte(payload.Password)); err != nil || !found || existingUser.Type == models.ServiceUser {
+				log.Warnf("⚠️ Failed login attempt from %s", c.IP())
+
+				// Unknown usernames are counted exactly like real ones, so
+				// lockouts don't reveal which accounts exist.
+				retryAfter, err := r.lockouts.Fail(accountKey, ipKey)
+
+				if err != nil {
+					log.Errorf("🔥 Error recording failed login attempt: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
+					})
+				}
+
+				if retryAfter > 0 {
+					c.Set(fiber.HeaderRetryAfter, lockouts.RetryAfterSeconds(retryAfter))
+
+					return c.Status(fiber.StatusTooManyRequests).JSON(&fiber.Map{
+						"error":   "Too Many Requests",
+						"message": "Too many failed login attempts. Please try again later.",
+					})
+				}
+
+				return c.Status(fiber.StatusUnauthorized).JSON(&fiber.Map{
+					"error":   "Unauthorized",
+					"message": "You are not authorized to access this resource.",
+				})
+			}
+
+			if err := r.lockouts.Reset(accountKey); err != nil {
+				log.Errorf("🔥 Error resetting lockout: %s", err.Error())
 
 				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
 					"error":   "Internal Server Error",
@@ -116,12 +193,19 @@ func (r *AuthenticationRouter) LoginRoute() routing.Route {
 				})
 			}
 
-			if err := bcrypt.CompareHashAndPassword(existingUser.Password, []byte(payload.Password)); err != nil {
-				log.Warnf("⚠️ Invalid password for user %s: %s", payload.Username, err.Error())
+			if err := r.registrations.Active(&existingUser); err != nil {
+				message := "Your account is waiting for approval."
 
-				return c.Status(fiber.StatusUnauthorized).JSON(&fiber.Map{
-					"error":   "Unauthorized",
-					"message": "You are not authorized to access this resource.",
+				switch {
+				case errors.Is(err, registrations.ErrUnverified):
+					message = "Your account has not been verified yet."
+				case errors.Is(err, registrations.ErrRejected):
+					message = "Your account registration was rejected."
+				}
+
+				return c.Status(fiber.StatusForbidden).JSON(&fiber.Map{
+					"error":   "Forbidden",
+					"message": message,
 				})
 			}
 
@@ -136,11 +220,8 @@ func (r *AuthenticationRouter) LoginRoute() routing.Route {
 				})
 			}
 
-			currentSession.Set("user_id", existingUser.Id.String())
-			currentSession.SetExpiry(1 * time.Hour)
-
-			if err := currentSession.Save(); err != nil {
-				log.Errorf("🔥 Error saving session: %s", err.Error())
+			if err := r.sessions.Start(c, currentSession, existingUser.Id); err != nil {
+				log.Errorf("🔥 Error starting session: %s", err.Error())
 
 				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
 					"error":   "Internal Server Error",
@@ -5,6 +5,7 @@ import (
 	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
 )
 
 func (r *AuthenticationRouter) LogoutRoute() routing.Route {
@@ -74,6 +75,15 @@ func (r *AuthenticationRouter) LogoutRoute() routing.Route {
 			r.middleware.Authenticated(),
 		},
 		Handler: func(c *fiber.Ctx) error {
+			if err := r.stopImpersonation(c); err != nil {
+				log.Errorf("🔥 Error stopping impersonation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
 			session, err := r.session.Get(c)
 
 			if err != nil {
@@ -83,9 +93,9 @@ func (r *AuthenticationRouter) LogoutRoute() routing.Route {
 				})
 			}
 
-			err = session.Destroy()
+			if err := r.sessions.End(c, session); err != nil {
+				log.Errorf("🔥 Error ending session: %s", err.Error())
 
-			if err != nil {
 				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"error":   "Internal Server Error",
 					"message": "An error occurred while processing your request.",
@@ -0,0 +1,394 @@
+package authentication
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/permissions"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CanQueryParams struct {
+	Permission string     `query:"permission"`
+	Resource   string     `query:"resource"`
+	BusinessId *uuid.UUID `query:"businessId"`
+}
+
+func (r *AuthenticationRouter) MyPermissionsRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Permissions retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "My Permissions",
+			Description: "Retrieves your effective, de-duplicated permissions in the active business, together with your permissions in every business you belong to.",
+			Tags:        []string{"Authentication"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/authentication/me/permissions",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var membershipRoles []models.MembershipRole
+
+			if err := r.storage.Database().
+				Preload("Role").
+				Where("user_id = ?", currentUser.Id).
+				Find(&membershipRoles).Error; err != nil {
+				log.Errorf("🔥 Error retrieving business roles: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			businessIds := []uuid.UUID{}
+
+			if currentUser.BusinessId != nil {
+				businessIds = append(businessIds, *currentUser.BusinessId)
+			}
+
+			for _, business := range currentUser.Businesses {
+				if !slices.Contains(businessIds, business.Id) {
+					businessIds = append(businessIds, business.Id)
+				}
+			}
+
+			businesses := []fiber.Map{}
+
+			for _, businessId := range businessIds {
+				businessUser := *currentUser
+				businessUser.BusinessRoles = []models.Role{}
+
+				for _, membershipRole := range membershipRoles {
+					if membershipRole.BusinessId == businessId {
+						businessUser.BusinessRoles = append(businessUser.BusinessRoles, membershipRole.Role)
+					}
+				}
+
+				businesses = append(businesses, fiber.Map{
+					"businessId":  businessId,
+					"permissions": distinct(permissions.Effective(&businessUser)),
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items":            distinct(permissions.Effective(currentUser)),
+				"activeBusinessId": currentUser.ActiveBusinessId,
+				"businesses":       businesses,
+			})
+		},
+	}
+}
+
+func (r *AuthenticationRouter) CanRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Permission check evaluated successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Can",
+			Description: "Evaluates a permission the same way the api does. With a resource such as \"collections/{id}\", the row-level policies for the action in the permission are checked against that record as well.",
+			Tags:        []string{"Authentication"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewQueryParameter("permission").
+						WithRequired(true).
+						WithSchema(openapi3.NewStringSchema()),
+				},
+				{
+					Value: openapi3.NewQueryParameter("resource").
+						WithSchema(openapi3.NewStringSchema()),
+				},
+				{
+					Value: openapi3.NewQueryParameter("businessId").
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/authentication/can",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var queryParams CanQueryParams
+
+			if err := c.QueryParser(&queryParams); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			queryParams.Permission = strings.TrimSpace(queryParams.Permission)
+
+			if queryParams.Permission == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The permission is required.",
+				})
+			}
+
+			user := currentUser
+
+			if queryParams.BusinessId != nil {
+				businessRoles := []models.Role{}
+				member := currentUser.MemberOf(*queryParams.BusinessId)
+
+				if member {
+					if err := r.storage.Database().
+						Joins("JOIN businesses_users_roles ON businesses_users_roles.role_id = roles.id").
+						Where("businesses_users_roles.business_id = ? AND businesses_users_roles.user_id = ?", *queryParams.BusinessId, currentUser.Id).
+						Find(&businessRoles).Error; err != nil {
+						log.Errorf("🔥 Error retrieving business roles: %s", err.Error())
+
+						return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+							"error":   "Internal Server Error",
+							"message": "An error occurred while processing your request.",
+						})
+					}
+				}
+
+				businessUser := *currentUser
+				businessUser.ActiveBusinessId = nil
+				businessUser.BusinessRoles = businessRoles
+
+				if member {
+					businessUser.ActiveBusinessId = queryParams.BusinessId
+				}
+
+				user = &businessUser
+			}
+
+			if !permissions.Allows(permissions.Effective(user), queryParams.Permission) {
+				return c.Status(fiber.StatusOK).JSON(fiber.Map{
+					"allowed": false,
+					"reason":  fmt.Sprintf("You don't have the %s permission.", queryParams.Permission),
+				})
+			}
+
+			if queryParams.Resource == "" {
+				return c.Status(fiber.StatusOK).JSON(fiber.Map{
+					"allowed": true,
+					"reason":  fmt.Sprintf("You have the %s permission.", queryParams.Permission),
+				})
+			}
+
+			entity, resourceId, ok := strings.Cut(queryParams.Resource, "/")
+
+			id, err := uuid.Parse(resourceId)
+
+			if !ok || err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The resource must look like \"collections/{id}\".",
+				})
+			}
+
+			var model any
+
+			switch models.PolicyType(entity) {
+			case models.CollectionsPolicy:
+				model = &models.Collection{}
+			case models.TransactionsPolicy:
+				model = &models.Transaction{}
+			default:
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The resource type doesn't have row-level policies.",
+				})
+			}
+
+			decision := policies.Evaluate(policies.Registry, user, models.PolicyType(entity), action(queryParams.Permission))
+
+			if !decision.Allowed {
+				return c.Status(fiber.StatusOK).JSON(fiber.Map{
+					"allowed": false,
+					"reason":  decision.Reason,
+					"policy":  decision,
+				})
+			}
+
+			query := r.storage.Database().Model(model)
+
+			if expression := decision.Expression(); expression != nil {
+				query = query.Clauses(expression)
+			}
+
+			if err := query.Where("id = ?", id).First(model).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusOK).JSON(fiber.Map{
+						"allowed": false,
+						"reason":  "The resource doesn't exist or isn't covered by your policies.",
+						"policy":  decision,
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving resource: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"allowed": true,
+				"reason":  decision.Reason,
+				"policy":  decision,
+			})
+		},
+	}
+}
+
+// action picks the policy action named by a permission such as
+// "collections.update" or "collections.status.dispute", falling back to
+// viewing.
+func action(permission string) policies.Action {
+	segments := strings.Split(permission, ".")
+
+	if len(segments) >= 2 && segments[len(segments)-2] == "status" {
+		return policies.TransitionAction(segments[len(segments)-1])
+	}
+
+	for index := len(segments) - 1; index >= 0; index-- {
+		if slices.Contains(policies.Actions, policies.Action(segments[index])) {
+			return policies.Action(segments[index])
+		}
+	}
+
+	return policies.ViewAction
+}
+
+func distinct(values []string) []string {
+	unique := []string{}
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+
+		if value != "" && !slices.Contains(unique, value) {
+			unique = append(unique, value)
+		}
+	}
+
+	slices.Sort(unique)
+
+	return unique
+}
@@ -82,6 +82,7 @@ func (r *MfaRouter) EnableRoute() routing.Route {
 		Path:   "/authentication/mfa/enable",
 		Middlewares: []fiber.Handler{
 			r.middleware.Authenticated(),
+			r.middleware.NotImpersonating(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			currentUser := c.Locals("user").(*models.User)
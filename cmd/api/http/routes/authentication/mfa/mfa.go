@@ -2,6 +2,7 @@ package mfa
 
 import (
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
+	"github.com/connor-davis/threereco-nextgen/internal/lockouts"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
 	"github.com/gofiber/fiber/v2/middleware/session"
@@ -11,13 +12,15 @@ type MfaRouter struct {
 	storage    storage.Storage
 	middleware middleware.Middleware
 	session    *session.Store
+	lockouts   lockouts.Lockouts
 }
 
-func NewMfaRouter(storage storage.Storage, middleware middleware.Middleware, session *session.Store) Router {
+func NewMfaRouter(storage storage.Storage, middleware middleware.Middleware, session *session.Store, lockouts lockouts.Lockouts) Router {
 	return &MfaRouter{
 		storage:    storage,
 		middleware: middleware,
 		session:    session,
+		lockouts:   lockouts,
 	}
 }
 
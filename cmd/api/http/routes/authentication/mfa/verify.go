@@ -1,6 +1,7 @@
 package mfa
 
 import (
+	"github.com/connor-davis/threereco-nextgen/internal/lockouts"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
@@ -52,6 +53,16 @@ func (r *MfaRouter) VerifyRoute() routing.Route {
 			}),
 	})
 
+	responses.Set("429", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Too Many Requests").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
 	responses.Set("500", &openapi3.ResponseRef{
 		Value: openapi3.NewResponse().
 			WithJSONSchemaRef(schemas.ErrorSchema).
@@ -77,6 +88,7 @@ func (r *MfaRouter) VerifyRoute() routing.Route {
 		Path:   "/authentication/mfa/verify",
 		Middlewares: []fiber.Handler{
 			r.middleware.Authenticated(),
+			r.middleware.NotImpersonating(),
 		},
 		Handler: func(c *fiber.Ctx) error {
 			currentUser := c.Locals("user").(*models.User)
@@ -110,7 +122,50 @@ func (r *MfaRouter) VerifyRoute() routing.Route {
 				})
 			}
 
+			accountKey := lockouts.Account(models.MfaAccountLockout, currentUser.Id.String())
+			ipKey := lockouts.Ip(models.MfaIpLockout, c.IP())
+
+			retryAfter, err := r.lockouts.Check(accountKey, ipKey)
+
+			if err != nil {
+				log.Errorf("🔥 Error checking lockouts: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if retryAfter > 0 {
+				c.Set(fiber.HeaderRetryAfter, lockouts.RetryAfterSeconds(retryAfter))
+
+				return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+					"error":   "Too Many Requests",
+					"message": "Too many failed Multi-Factor Authentication attempts. Please try again later.",
+				})
+			}
+
 			if !totp.Validate(payload.Code, string(currentUser.MfaSecret)) {
+				retryAfter, err := r.lockouts.Fail(accountKey, ipKey)
+
+				if err != nil {
+					log.Errorf("🔥 Error recording failed MFA attempt: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
+					})
+				}
+
+				if retryAfter > 0 {
+					c.Set(fiber.HeaderRetryAfter, lockouts.RetryAfterSeconds(retryAfter))
+
+					return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+						"error":   "Too Many Requests",
+						"message": "Too many failed Multi-Factor Authentication attempts. Please try again later.",
+					})
+				}
+
 				return c.Status(fiber.StatusUnauthorized).
 					JSON(fiber.Map{
 						"error":   "Unauthorized",
@@ -118,6 +173,15 @@ func (r *MfaRouter) VerifyRoute() routing.Route {
 					})
 			}
 
+			if err := r.lockouts.Reset(accountKey); err != nil {
+				log.Errorf("🔥 Error resetting lockout: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
 			currentUser.MfaEnabled = true
 			currentUser.MfaVerified = true
 
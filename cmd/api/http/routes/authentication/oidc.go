@@ -0,0 +1,397 @@
+package authentication
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/connor-davis/threereco-nextgen/internal/sso"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type OidcParams struct {
+	Provider string `param:"provider"`
+}
+
+type OidcLoginQuery struct {
+	Redirect string `query:"redirect"`
+}
+
+type OidcCallbackQuery struct {
+	Code             string `query:"code"`
+	State            string `query:"state"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+}
+
+// safeRedirect only allows relative paths on the frontend so the login flow
+// can't be used as an open redirect.
+func safeRedirect(redirect string) string {
+	if !strings.HasPrefix(redirect, "/") || strings.HasPrefix(redirect, "//") || strings.Contains(redirect, "\\") {
+		return "/"
+	}
+
+	return redirect
+}
+
+func (r *AuthenticationRouter) OidcProvidersRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successfully retrieved identity providers.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "List Identity Providers",
+			Description: "Lists the OpenID Connect identity providers that can be used to sign in.",
+			Tags:        []string{"Authentication"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method:      routing.GET,
+		Path:        "/authentication/oidc/providers",
+		Middlewares: []fiber.Handler{},
+		Handler: func(c *fiber.Ctx) error {
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items": r.sso.Providers(),
+			})
+		},
+	}
+}
+
+func (r *AuthenticationRouter) OidcLoginRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Redirects to the identity provider.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Single Sign-On Login",
+			Description: "Starts an OpenID Connect authorization code flow with PKCE and redirects to the identity provider.",
+			Tags:        []string{"Authentication"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("provider").
+						WithRequired(true).
+						WithSchema(openapi3.NewStringSchema()),
+				},
+				{
+					Value: openapi3.NewQueryParameter("redirect").
+						WithDescription("Frontend path to return to after signing in.").
+						WithSchema(openapi3.NewStringSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method:      routing.GET,
+		Path:        "/authentication/oidc/{provider}/login",
+		Middlewares: []fiber.Handler{},
+		Handler: func(c *fiber.Ctx) error {
+			var params OidcParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var query OidcLoginQuery
+
+			if err := c.QueryParser(&query); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			flow, authUrl, err := r.sso.Begin(params.Provider, safeRedirect(query.Redirect))
+
+			if err != nil {
+				if errors.Is(err, sso.ErrUnknownProvider) {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The identity provider was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error starting single sign-on: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.sso.SaveFlow(c, flow); err != nil {
+				log.Errorf("🔥 Error saving single sign-on state: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Redirect(authUrl, fiber.StatusFound)
+		},
+	}
+}
+
+func (r *AuthenticationRouter) OidcCallbackRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Signs the user in and redirects to the frontend.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Single Sign-On Callback",
+			Description: "Completes the OpenID Connect flow, validates the ID token, provisions the user if needed and starts a session.",
+			Tags:        []string{"Authentication"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("provider").
+						WithRequired(true).
+						WithSchema(openapi3.NewStringSchema()),
+				},
+				{
+					Value: openapi3.NewQueryParameter("code").
+						WithSchema(openapi3.NewStringSchema()),
+				},
+				{
+					Value: openapi3.NewQueryParameter("state").
+						WithSchema(openapi3.NewStringSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method:      routing.GET,
+		Path:        "/authentication/oidc/{provider}/callback",
+		Middlewares: []fiber.Handler{},
+		Handler: func(c *fiber.Ctx) error {
+			var params OidcParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var query OidcCallbackQuery
+
+			if err := c.QueryParser(&query); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			flow, err := r.sso.TakeFlow(c, params.Provider, query.State)
+
+			if err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The single sign-on request is missing, expired or does not match.",
+				})
+			}
+
+			if query.Error != "" {
+				log.Warnf("⚠️ Identity provider %s returned an error: %s %s", params.Provider, query.Error, query.ErrorDescription)
+
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error":   "Unauthorized",
+					"message": "The identity provider did not authorize the sign in.",
+				})
+			}
+
+			claims, err := r.sso.Exchange(c.UserContext(), flow, query.Code)
+
+			if err != nil {
+				log.Warnf("⚠️ Single sign-on with %s failed: %s", params.Provider, err.Error())
+
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error":   "Unauthorized",
+					"message": "The identity provider response could not be verified.",
+				})
+			}
+
+			user, err := r.sso.Provision(params.Provider, claims)
+
+			if err != nil {
+				if errors.Is(err, sso.ErrNotProvisioned) || errors.Is(err, sso.ErrServiceAccount) {
+					return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+						"error":   "Forbidden",
+						"message": "No account is available for this identity.",
+					})
+				}
+
+				if errors.Is(err, sso.ErrUsernameTaken) {
+					return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+						"error":   "Conflict",
+						"message": "An account with this username already exists and is not linked to this identity.",
+					})
+				}
+
+				log.Errorf("🔥 Error provisioning single sign-on user: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if user.ApprovalStatus != models.ApprovedApproval {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "Your account has not been approved.",
+				})
+			}
+
+			currentSession, err := r.session.Get(c)
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.sessions.Start(c, currentSession, user.Id); err != nil {
+				log.Errorf("🔥 Error starting session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.storage.Database().
+				Model(user).
+				Update("mfa_verified", false).Error; err != nil {
+				log.Errorf("🔥 Error updating MFA status for user: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Redirect(fmt.Sprintf(
+				"%s%s",
+				strings.TrimSuffix(common.EnvString("APP_BASE_URL", "http://localhost:3000"), "/"),
+				flow.Redirect,
+			), fiber.StatusFound)
+		},
+	}
+}
@@ -0,0 +1,205 @@
+package authentication
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func (r *AuthenticationRouter) ChangePasswordRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Password changed successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Change Password",
+			Description: "Changes the password of the currently authenticated user and signs out every other session.",
+			Tags:        []string{"Authentication"},
+			Parameters:  nil,
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/ChangePasswordPayload",
+			},
+			Responses: responses,
+		},
+		Method: routing.POST,
+		Path:   "/authentication/password",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.NotImpersonating(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser, ok := c.Locals("user").(*models.User)
+
+			if !ok || currentUser == nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error":   "Unauthorized",
+					"message": "You must be logged in to access this resource.",
+				})
+			}
+
+			var payload models.ChangePasswordPayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			if err := bcrypt.CompareHashAndPassword(currentUser.Password, []byte(payload.CurrentPassword)); err != nil {
+				log.Warnf("⚠️ Invalid current password for user %s", currentUser.Id.String())
+
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error":   "Unauthorized",
+					"message": "The current password is incorrect.",
+				})
+			}
+
+			if violations := r.passwords.Validate(currentUser.Username, payload.NewPassword); len(violations) > 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":      "Bad Request",
+					"message":    "The password does not meet the password policy.",
+					"violations": violations,
+				})
+			}
+
+			if bcrypt.CompareHashAndPassword(currentUser.Password, []byte(payload.NewPassword)) == nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The password does not meet the password policy.",
+					"violations": []models.PasswordViolation{
+						{
+							Code:    "reused",
+							Message: "The new password must be different from your current password.",
+						},
+					},
+				})
+			}
+
+			reused, err := r.passwords.Reused(currentUser.Id, payload.NewPassword)
+
+			if err != nil {
+				log.Errorf("🔥 Error checking password history: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if reused {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The password does not meet the password policy.",
+					"violations": []models.PasswordViolation{
+						{
+							Code:    "reused",
+							Message: "The password has been used recently. Please choose a different password.",
+						},
+					},
+				})
+			}
+
+			hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.NewPassword), bcrypt.DefaultCost)
+
+			if err != nil {
+				log.Errorf("🔥 Error hashing password: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.storage.Database().
+				Model(&models.User{}).
+				Where("id = ?", currentUser.Id).
+				Updates(map[string]any{
+					"password":       hashedPassword,
+					"password_reset": false,
+				}).Error; err != nil {
+				log.Errorf("🔥 Error updating password: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.passwords.Remember(currentUser.Id, hashedPassword); err != nil {
+				log.Errorf("🔥 Error recording password history: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			currentSession, err := r.session.Get(c)
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.sessions.RevokeAll(currentUser.Id, currentSession.ID()); err != nil {
+				log.Errorf("🔥 Error revoking sessions: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
@@ -1,14 +1,13 @@
 package authentication
 
 import (
-	"fmt"
-	"time"
+	"errors"
 
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/notifications"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
 	"github.com/getkin/kin-openapi/openapi3"
-	"github.com/go-openapi/inflect"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/log"
 	"golang.org/x/crypto/bcrypt"
@@ -27,6 +26,15 @@ func (r *AuthenticationRouter) RegisterRoute() routing.Route {
 			}),
 	})
 
+	responses.Set("202", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Registered, but the account must be verified or approved before it can be used.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
 	responses.Set("400", &openapi3.ResponseRef{
 		Value: openapi3.NewResponse().
 			WithJSONSchemaRef(schemas.ErrorSchema).
@@ -70,7 +78,7 @@ func (r *AuthenticationRouter) RegisterRoute() routing.Route {
 	return routing.Route{
 		OpenAPIMetadata: routing.OpenAPIMetadata{
 			Summary:     "Register",
-			Description: "Registers a new user with email and password.",
+			Description: "Registers a new collector or business account. Accounts that still need to verify their username or wait for approval receive a 202 response describing the remaining steps; otherwise the user is signed in.",
 			Tags:        []string{"Authentication"},
 			Parameters:  nil,
 			RequestBody: &openapi3.RequestBodyRef{
@@ -102,10 +110,29 @@ func (r *AuthenticationRouter) RegisterRoute() routing.Route {
 				})
 			}
 
-			if payload.Type == models.SystemUser && user.Type != models.SystemUser {
-				return c.Status(fiber.StatusUnauthorized).JSON(&fiber.Map{
-					"error":   "Unauthorized",
-					"message": "You are not authorized to create a system user.",
+			if payload.Type == "" {
+				payload.Type = models.CollectorUser
+			}
+
+			if payload.Type != models.CollectorUser && payload.Type != models.BusinessUser {
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   "Bad Request",
+					"message": "Only collector and business accounts can be registered.",
+				})
+			}
+
+			if payload.Name == "" || payload.Username == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   "Bad Request",
+					"message": "A name and username are required.",
+				})
+			}
+
+			if violations := r.passwords.Validate(payload.Username, payload.Password); len(violations) > 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":      "Bad Request",
+					"message":    "The password does not meet the password policy.",
+					"violations": violations,
 				})
 			}
 
@@ -138,8 +165,15 @@ func (r *AuthenticationRouter) RegisterRoute() routing.Route {
 						Type:        payload.Type,
 					}
 
-					if err := r.storage.Database().Create(&newUser).Error; err != nil {
-						log.Errorf("🔥 Error creating user: %s", err.Error())
+					if err := r.registrations.Register(&newUser); err != nil {
+						if errors.Is(err, notifications.ErrUnsupportedRecipient) {
+							return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+								"error":   "Bad Request",
+								"message": "Registering with a phone number isn't available yet. Please register with an email address.",
+							})
+						}
+
+						log.Errorf("🔥 Error registering user: %s", err.Error())
 
 						return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
 							"error":   "Internal Server Error",
@@ -147,134 +181,20 @@ func (r *AuthenticationRouter) RegisterRoute() routing.Route {
 						})
 					}
 
-					if payload.Type == models.BusinessUser {
-						newBusinessName := fmt.Sprintf("%s Business", inflect.Pluralize(newUser.Name))
-
-						businessOwnerRoleName := "Business Owner"
-						businessOwnerRoleDescription := "Owner of the business with full access to business resources."
-
-						businessStaffRoleName := "Business Staff"
-						businessStaffRoleDescription := "Staff member of the business with limited access to business resources."
-
-						businessUserRoleName := "Business User"
-						businessUserRoleDescription := "User of the business with minimal access to business resources."
-
-						newBusinessOwnerRole := models.Role{
-							Name:        businessOwnerRoleName,
-							Description: &businessOwnerRoleDescription,
-							Permissions: []string{
-								"materials.view",
-								"collections.*",
-								"transactions.*",
-								"users.view.self",
-								"users.update.self",
-								"users.delete.self",
-								"businesses.view",
-								"businesses.update.self",
-								"businesses.delete.self",
-								"businesses.roles.assign",
-								"businesses.roles.unassign",
-								"businesses.roles.view",
-								"businesses.users.assign",
-								"businesses.users.unassign",
-								"businesses.users.view",
-							},
-							Default: false,
-						}
-
-						newBusinessStaffRole := models.Role{
-							Name:        businessStaffRoleName,
-							Description: &businessStaffRoleDescription,
-							Permissions: []string{
-								"materials.view",
-								"collections.view",
-								"collections.create",
-								"collections.update",
-								"transactions.view",
-								"transactions.create",
-								"transactions.update",
-								"users.view.self",
-								"users.update.self",
-								"users.delete.self",
-								"businesses.view",
-								"businesses.users.view",
-							},
-							Default: false,
-						}
-
-						newBusinessUserRole := models.Role{
-							Name:        businessUserRoleName,
-							Description: &businessUserRoleDescription,
-							Permissions: []string{
-								"materials.view",
-								"collections.view",
-								"transactions.view",
-								"users.view.self",
-								"users.update.self",
-								"users.delete.self",
-								"businesses.view",
-							},
-							Default: false,
-						}
-
-						if err := r.storage.Database().Where("name = ?", businessOwnerRoleName).FirstOrCreate(&newBusinessOwnerRole).Error; err != nil {
-							log.Errorf("🔥 Error creating business role: %s", err.Error())
+					if err := r.passwords.Remember(newUser.Id, hashedPassword); err != nil {
+						log.Errorf("🔥 Error recording password history: %s", err.Error())
 
-							return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
-								"error":   "Internal Server Error",
-								"message": "An error occurred while processing your request.",
-							})
-						}
-
-						if err := r.storage.Database().Where("name = ?", businessStaffRoleName).FirstOrCreate(&newBusinessStaffRole).Error; err != nil {
-							log.Errorf("🔥 Error creating business role: %s", err.Error())
-
-							return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
-								"error":   "Internal Server Error",
-								"message": "An error occurred while processing your request.",
-							})
-						}
-
-						if err := r.storage.Database().Where("name = ?", businessUserRoleName).FirstOrCreate(&newBusinessUserRole).Error; err != nil {
-							log.Errorf("🔥 Error creating business role: %s", err.Error())
-
-							return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
-								"error":   "Internal Server Error",
-								"message": "An error occurred while processing your request.",
-							})
-						}
-
-						newBusiness := models.Business{
-							Name: newBusinessName,
-							Users: []models.User{
-								newUser,
-							},
-							OwnerId: newUser.Id,
-						}
-
-						if err := r.storage.Database().Create(&newBusiness).Error; err != nil {
-							log.Errorf("🔥 Error creating business: %s", err.Error())
-
-							return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
-								"error":   "Internal Server Error",
-								"message": "An error occurred while processing your request.",
-							})
-						}
-
-						newUser.BusinessId = &newBusiness.Id
-						newUser.Roles = []models.Role{
-							newBusinessOwnerRole,
-						}
-
-						if err := r.storage.Database().
-							Save(&newUser).Error; err != nil {
-							log.Errorf("🔥 Error updating user with business ID: %s", err.Error())
+						return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+							"error":   "Internal Server Error",
+							"message": "An error occurred while processing your request.",
+						})
+					}
 
-							return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
-								"error":   "Internal Server Error",
-								"message": "An error occurred while processing your request.",
-							})
-						}
+					if err := r.registrations.Active(&newUser); err != nil {
+						return c.Status(fiber.StatusAccepted).JSON(&fiber.Map{
+							"verificationRequired": !newUser.Verified,
+							"approvalRequired":     newUser.ApprovalStatus == models.PendingApproval,
+						})
 					}
 
 					currentSession, err := r.session.Get(c)
@@ -288,11 +208,8 @@ func (r *AuthenticationRouter) RegisterRoute() routing.Route {
 						})
 					}
 
-					currentSession.Set("user_id", newUser.Id.String())
-					currentSession.SetExpiry(1 * time.Hour)
-
-					if err := currentSession.Save(); err != nil {
-						log.Errorf("🔥 Error saving session: %s", err.Error())
+					if err := r.sessions.Start(c, currentSession, newUser.Id); err != nil {
+						log.Errorf("🔥 Error starting session: %s", err.Error())
 
 						return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
 							"error":   "Internal Server Error",
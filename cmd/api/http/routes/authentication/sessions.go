@@ -0,0 +1,313 @@
+package authentication
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RevokeSessionParams struct {
+	Id uuid.UUID `param:"id"`
+}
+
+func (r *AuthenticationRouter) ListSessionsRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Successfully retrieved sessions.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "List Sessions",
+			Description: "Lists the active sessions of the currently authenticated user.",
+			Tags:        []string{"Authentication"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/authentication/sessions",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser, ok := c.Locals("user").(*models.User)
+
+			if !ok || currentUser == nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error":   "Unauthorized",
+					"message": "You must be logged in to access this resource.",
+				})
+			}
+
+			currentSession, err := r.session.Get(c)
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			userSessions, err := r.sessions.List(currentUser.Id)
+
+			if err != nil {
+				log.Errorf("🔥 Error listing sessions: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			for index := range userSessions {
+				userSessions[index].Current = userSessions[index].SessionId == currentSession.ID()
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items": userSessions,
+			})
+		},
+	}
+}
+
+func (r *AuthenticationRouter) RevokeSessionRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Session revoked successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Revoke Session",
+			Description: "Revokes one of the currently authenticated user's sessions, signing that device out.",
+			Tags:        []string{"Authentication"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("id").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.DELETE,
+		Path:   "/authentication/sessions/{id}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.NotImpersonating(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser, ok := c.Locals("user").(*models.User)
+
+			if !ok || currentUser == nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error":   "Unauthorized",
+					"message": "You must be logged in to access this resource.",
+				})
+			}
+
+			var params RevokeSessionParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if err := r.sessions.Revoke(currentUser.Id, params.Id); err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The session was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error revoking session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
+
+func (r *AuthenticationRouter) RevokeSessionsRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Sessions revoked successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Revoke All Sessions",
+			Description: "Revokes every session of the currently authenticated user, including the current one.",
+			Tags:        []string{"Authentication"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.DELETE,
+		Path:   "/authentication/sessions",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.NotImpersonating(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser, ok := c.Locals("user").(*models.User)
+
+			if !ok || currentUser == nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"error":   "Unauthorized",
+					"message": "You must be logged in to access this resource.",
+				})
+			}
+
+			if err := r.sessions.RevokeAll(currentUser.Id); err != nil {
+				log.Errorf("🔥 Error revoking sessions: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if _, ok := c.Locals("token").(*models.ApiToken); ok {
+				return c.SendStatus(fiber.StatusOK)
+			}
+
+			currentSession, err := r.session.Get(c)
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.sessions.End(c, currentSession); err != nil {
+				log.Errorf("🔥 Error ending session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
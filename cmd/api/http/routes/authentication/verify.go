@@ -0,0 +1,242 @@
+package authentication
+
+import (
+	"errors"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/notifications"
+	"github.com/connor-davis/threereco-nextgen/internal/registrations"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+func (r *AuthenticationRouter) VerifyRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Account verified successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("202", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Verified, but the account is waiting for approval.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Verify Account",
+			Description: "Verifies a self-registered account with the code that was sent to its username. The user is signed in when the account does not need approval.",
+			Tags:        []string{"Authentication"},
+			Parameters:  nil,
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/VerifyAccountPayload",
+			},
+			Responses: responses,
+		},
+		Method:      routing.POST,
+		Path:        "/authentication/verify",
+		Middlewares: []fiber.Handler{},
+		Handler: func(c *fiber.Ctx) error {
+			var payload models.VerifyAccountPayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			user, err := r.registrations.Verify(payload.Username, payload.Code)
+
+			if err != nil {
+				if errors.Is(err, registrations.ErrInvalidCode) || errors.Is(err, registrations.ErrAlreadyVerified) {
+					return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+						"error":   "Bad Request",
+						"message": "The verification code is invalid or has expired.",
+					})
+				}
+
+				log.Errorf("🔥 Error verifying account: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			user.Verified = true
+
+			if err := r.registrations.Active(user); err != nil {
+				return c.Status(fiber.StatusAccepted).JSON(&fiber.Map{
+					"verificationRequired": false,
+					"approvalRequired":     user.ApprovalStatus == models.PendingApproval,
+				})
+			}
+
+			currentSession, err := r.session.Get(c)
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.sessions.Start(c, currentSession, user.Id); err != nil {
+				log.Errorf("🔥 Error starting session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
+
+func (r *AuthenticationRouter) ResendVerificationRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Verification code sent if the account is awaiting verification.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("429", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Too Many Requests").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Resend Verification Code",
+			Description: "Sends a new verification code to an unverified account. The response does not reveal whether the account exists.",
+			Tags:        []string{"Authentication"},
+			Parameters:  nil,
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/ResendVerificationPayload",
+			},
+			Responses: responses,
+		},
+		Method:      routing.POST,
+		Path:        "/authentication/verify/resend",
+		Middlewares: []fiber.Handler{},
+		Handler: func(c *fiber.Ctx) error {
+			var payload models.ResendVerificationPayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			if err := r.registrations.ResendVerification(payload.Username); err != nil {
+				if errors.Is(err, registrations.ErrResendTooSoon) {
+					return c.Status(fiber.StatusTooManyRequests).JSON(&fiber.Map{
+						"error":   "Too Many Requests",
+						"message": "A verification code was sent recently. Please try again later.",
+					})
+				}
+
+				if errors.Is(err, notifications.ErrUnsupportedRecipient) {
+					return c.Status(fiber.StatusBadRequest).JSON(&fiber.Map{
+						"error":   "Bad Request",
+						"message": "Verification codes can only be sent to email addresses.",
+					})
+				}
+
+				if err != gorm.ErrRecordNotFound && !errors.Is(err, registrations.ErrAlreadyVerified) {
+					log.Errorf("🔥 Error resending verification code: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(&fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
+					})
+				}
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
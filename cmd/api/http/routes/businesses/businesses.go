@@ -3,20 +3,38 @@ package businesses
 import (
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
 	"github.com/connor-davis/threereco-nextgen/internal/api"
+	"github.com/connor-davis/threereco-nextgen/internal/inventory"
+	"github.com/connor-davis/threereco-nextgen/internal/invitations"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/payouts"
+	"github.com/connor-davis/threereco-nextgen/internal/pricing"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/connor-davis/threereco-nextgen/internal/tokens"
 )
 
 type Router struct {
-	storage    storage.Storage
-	middleware middleware.Middleware
+	storage     storage.Storage
+	middleware  middleware.Middleware
+	tokens      tokens.Tokens
+	invitations invitations.Invitations
+	pricing     pricing.Pricing
+	inventory   inventory.Inventory
+	payouts     payouts.Payouts
+	sessions    sessions.Manager
 }
 
-func NewBusinessesRouter(storage storage.Storage, middleware middleware.Middleware) IRouter {
+func NewBusinessesRouter(storage storage.Storage, middleware middleware.Middleware, tokens tokens.Tokens, invitations invitations.Invitations, pricing pricing.Pricing, inventory inventory.Inventory, payouts payouts.Payouts, sessions sessions.Manager) IRouter {
 	return &Router{
-		storage:    storage,
-		middleware: middleware,
+		storage:     storage,
+		middleware:  middleware,
+		tokens:      tokens,
+		invitations: invitations,
+		pricing:     pricing,
+		inventory:   inventory,
+		payouts:     payouts,
+		sessions:    sessions,
 	}
 }
 
@@ -36,6 +54,7 @@ func (r *Router) LoadRoutes() []routing.Route {
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("businesses.users.unassign"),
 	)
+	unassignUserRoute.Handler = r.removeMemberRoles(unassignUserRoute.Handler)
 	listUsersRoute := businessesUserAssignmentsApi.ListRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("businesses.users.view"),
@@ -69,6 +88,42 @@ func (r *Router) LoadRoutes() []routing.Route {
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("businesses.delete"),
 	)
+	listServiceAccountsRoute := r.ListServiceAccountsRoute()
+	createServiceAccountRoute := r.CreateServiceAccountRoute()
+	deleteServiceAccountRoute := r.DeleteServiceAccountRoute()
+	listServiceAccountTokensRoute := r.ListServiceAccountTokensRoute()
+	createServiceAccountTokenRoute := r.CreateServiceAccountTokenRoute()
+	revokeServiceAccountTokenRoute := r.RevokeServiceAccountTokenRoute()
+	listInvitationsRoute := r.ListInvitationsRoute()
+	createInvitationRoute := r.CreateInvitationRoute()
+	resendInvitationRoute := r.ResendInvitationRoute()
+	revokeInvitationRoute := r.RevokeInvitationRoute()
+	listBusinessRolesRoute := r.ListBusinessRolesRoute()
+	createBusinessRoleRoute := r.CreateBusinessRoleRoute()
+	updateBusinessRoleRoute := r.UpdateBusinessRoleRoute()
+	deleteBusinessRoleRoute := r.DeleteBusinessRoleRoute()
+	listMembersRoute := r.ListMembersRoute()
+	assignMemberRoleRoute := r.AssignMemberRoleRoute()
+	unassignMemberRoleRoute := r.UnassignMemberRoleRoute()
+	listPricesRoute := r.ListPricesRoute()
+	priceHistoryRoute := r.PriceHistoryRoute()
+	createPriceRoute := r.CreatePriceRoute()
+	retirePriceRoute := r.RetirePriceRoute()
+	listCollectorGradesRoute := r.ListCollectorGradesRoute()
+	setCollectorGradeRoute := r.SetCollectorGradeRoute()
+	removeCollectorGradeRoute := r.RemoveCollectorGradeRoute()
+	listStockRoute := r.ListStockRoute()
+	listStockMovementsRoute := r.ListStockMovementsRoute()
+	adjustStockRoute := r.AdjustStockRoute()
+	listPayoutRunsRoute := r.ListPayoutRunsRoute()
+	createPayoutRunRoute := r.CreatePayoutRunRoute()
+	getPayoutRunRoute := r.GetPayoutRunRoute()
+	exportPayoutRunRoute := r.ExportPayoutRunRoute()
+	confirmPayoutRunRoute := r.ConfirmPayoutRunRoute()
+	cancelPayoutRunRoute := r.CancelPayoutRunRoute()
+	reconcilePayoutRunRoute := r.ReconcilePayoutRunRoute()
+	getVatSettingsRoute := r.GetVatSettingsRoute()
+	updateVatSettingsRoute := r.UpdateVatSettingsRoute()
 
 	return []routing.Route{
 		assignUserRoute,
@@ -79,5 +134,41 @@ func (r *Router) LoadRoutes() []routing.Route {
 		createRoute,
 		updateRoute,
 		deleteRoute,
+		listServiceAccountsRoute,
+		createServiceAccountRoute,
+		deleteServiceAccountRoute,
+		listServiceAccountTokensRoute,
+		createServiceAccountTokenRoute,
+		revokeServiceAccountTokenRoute,
+		listInvitationsRoute,
+		createInvitationRoute,
+		resendInvitationRoute,
+		revokeInvitationRoute,
+		listBusinessRolesRoute,
+		createBusinessRoleRoute,
+		updateBusinessRoleRoute,
+		deleteBusinessRoleRoute,
+		listMembersRoute,
+		assignMemberRoleRoute,
+		unassignMemberRoleRoute,
+		listPricesRoute,
+		priceHistoryRoute,
+		createPriceRoute,
+		retirePriceRoute,
+		listCollectorGradesRoute,
+		setCollectorGradeRoute,
+		removeCollectorGradeRoute,
+		listStockRoute,
+		listStockMovementsRoute,
+		adjustStockRoute,
+		listPayoutRunsRoute,
+		createPayoutRunRoute,
+		getPayoutRunRoute,
+		exportPayoutRunRoute,
+		confirmPayoutRunRoute,
+		cancelPayoutRunRoute,
+		reconcilePayoutRunRoute,
+		getVatSettingsRoute,
+		updateVatSettingsRoute,
 	}
 }
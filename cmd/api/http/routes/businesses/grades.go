@@ -0,0 +1,443 @@
+package businesses
+
+import (
+	"strings"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CollectorGradesParams struct {
+	BusinessId uuid.UUID `param:"businessId"`
+}
+
+type CollectorGradeParams struct {
+	BusinessId  uuid.UUID `param:"businessId"`
+	CollectorId uuid.UUID `param:"collectorId"`
+}
+
+func (r *Router) ListCollectorGradesRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Collector grades retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Get Collector Grades",
+			Description: "Retrieves the grades the business has given the collectors it buys from.",
+			Tags:        []string{"Business Prices"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/businesses/{businessId}/collector-grades",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.prices.view"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params CollectorGradesParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			grades := []models.CollectorGrade{}
+
+			if err := r.storage.Database().
+				Where("business_id = ?", params.BusinessId).
+				Order("grade ASC, created_at ASC").
+				Find(&grades).Error; err != nil {
+				log.Errorf("🔥 Error retrieving collector grades: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items": grades,
+			})
+		},
+	}
+}
+
+func (r *Router) SetCollectorGradeRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Collector grade set successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Set Collector Grade",
+			Description: "Grades a collector for the business. Lines the collector sells to the business from then on are valued with the price tiers for that grade where the price list has them.",
+			Tags:        []string{"Business Prices"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("collectorId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/CollectorGradePayload",
+			},
+			Responses: responses,
+		},
+		Method: routing.PUT,
+		Path:   "/businesses/{businessId}/collector-grades/{collectorId}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.prices.create"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params CollectorGradeParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			var payload models.CollectorGradePayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			payload.Grade = strings.TrimSpace(payload.Grade)
+
+			if payload.Grade == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The grade is required.",
+				})
+			}
+
+			var collector models.User
+
+			if err := r.storage.Database().
+				Where("id = ? AND type = ?", params.CollectorId, models.CollectorUser).
+				First(&collector).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The collector was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving collector: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			grade := models.CollectorGrade{
+				BusinessId:  params.BusinessId,
+				CollectorId: collector.Id,
+				Grade:       payload.Grade,
+			}
+
+			if err := r.storage.Database().Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "business_id"}, {Name: "collector_id"}},
+				DoUpdates: clause.AssignmentColumns([]string{"grade", "updated_at"}),
+			}).Create(&grade).Error; err != nil {
+				log.Errorf("🔥 Error setting collector grade: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": grade,
+			})
+		},
+	}
+}
+
+func (r *Router) RemoveCollectorGradeRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Collector grade removed successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Remove Collector Grade",
+			Description: "Removes a collector's grade, so that only the business's ungraded price tiers apply to them.",
+			Tags:        []string{"Business Prices"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("collectorId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.DELETE,
+		Path:   "/businesses/{businessId}/collector-grades/{collectorId}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.prices.delete"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params CollectorGradeParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			result := r.storage.Database().
+				Where("business_id = ? AND collector_id = ?", params.BusinessId, params.CollectorId).
+				Delete(&models.CollectorGrade{})
+
+			if result.Error != nil {
+				log.Errorf("🔥 Error removing collector grade: %s", result.Error.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if result.RowsAffected == 0 {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error":   "Not Found",
+					"message": "The collector has no grade in this business.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
@@ -0,0 +1,632 @@
+package businesses
+
+import (
+	"errors"
+
+	"github.com/connor-davis/threereco-nextgen/internal/invitations"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/notifications"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type InvitationsParams struct {
+	BusinessId uuid.UUID `param:"businessId"`
+}
+
+type InvitationParams struct {
+	BusinessId   uuid.UUID `param:"businessId"`
+	InvitationId uuid.UUID `param:"invitationId"`
+}
+
+func (r *Router) findInvitation(businessId uuid.UUID, invitationId uuid.UUID) (*models.Invitation, error) {
+	var invitation models.Invitation
+
+	if err := r.storage.Database().
+		Preload("Business").
+		Preload("Role").
+		Where("id = ? AND business_id = ?", invitationId, businessId).
+		First(&invitation).Error; err != nil {
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (r *Router) ListInvitationsRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Invitations retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Get Invitations",
+			Description: "Retrieves the invitations that were sent for a business.",
+			Tags:        []string{"Business Invitations"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/businesses/{businessId}/invitations",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.invitations.view"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params InvitationsParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			var invitations []models.Invitation
+
+			if err := r.storage.Database().
+				Preload("Role").
+				Where("business_id = ?", params.BusinessId).
+				Order("created_at DESC").
+				Find(&invitations).Error; err != nil {
+				log.Errorf("🔥 Error retrieving invitations: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items": invitations,
+			})
+		},
+	}
+}
+
+func (r *Router) CreateInvitationRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Invitation sent successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Create Invitation",
+			Description: "Invites someone to join a business by email or phone number with a pre-selected role.",
+			Tags:        []string{"Business Invitations"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/CreateInvitationPayload",
+			},
+			Responses: responses,
+		},
+		Method: routing.POST,
+		Path:   "/businesses/{businessId}/invitations",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.invitations.create"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params InvitationsParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			var payload models.CreateInvitationPayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			var business models.Business
+
+			if err := r.storage.Database().Where("id = ?", params.BusinessId).First(&business).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The business was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving business: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			invitation, err := r.invitations.Invite(&business, currentUser, payload)
+
+			if err != nil {
+				switch {
+				case errors.Is(err, gorm.ErrRecordNotFound):
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The role was not found.",
+					})
+				case errors.Is(err, invitations.ErrInvalidRecipient),
+					errors.Is(err, notifications.ErrUnsupportedRecipient),
+					errors.Is(err, invitations.ErrInvalidUserType),
+					errors.Is(err, invitations.ErrRoleNotHeld):
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+
+				log.Errorf("🔥 Error creating invitation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": invitation,
+			})
+		},
+	}
+}
+
+func (r *Router) ResendInvitationRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Invitation resent successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Resend Invitation",
+			Description: "Sends a pending invitation again with a new link and expiry. Links sent before stop working.",
+			Tags:        []string{"Business Invitations"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("invitationId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.POST,
+		Path:   "/businesses/{businessId}/invitations/{invitationId}/resend",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.invitations.create"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params InvitationParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			invitation, err := r.findInvitation(params.BusinessId, params.InvitationId)
+
+			if err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The invitation was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving invitation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.invitations.Resend(invitation); err != nil {
+				if errors.Is(err, invitations.ErrNotPending) {
+					return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+						"error":   "Conflict",
+						"message": "The invitation is no longer pending.",
+					})
+				}
+
+				log.Errorf("🔥 Error resending invitation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
+
+func (r *Router) RevokeInvitationRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Invitation revoked successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Revoke Invitation",
+			Description: "Revokes a pending invitation so that it can no longer be accepted.",
+			Tags:        []string{"Business Invitations"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("invitationId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.DELETE,
+		Path:   "/businesses/{businessId}/invitations/{invitationId}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.invitations.revoke"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params InvitationParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			invitation, err := r.findInvitation(params.BusinessId, params.InvitationId)
+
+			if err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The invitation was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving invitation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.invitations.Revoke(invitation); err != nil {
+				if errors.Is(err, invitations.ErrNotPending) {
+					return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+						"error":   "Conflict",
+						"message": "The invitation is no longer pending.",
+					})
+				}
+
+				log.Errorf("🔥 Error revoking invitation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
@@ -0,0 +1,539 @@
+package businesses
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type MembersParams struct {
+	BusinessId uuid.UUID `param:"businessId"`
+}
+
+type MemberRoleParams struct {
+	BusinessId uuid.UUID `param:"businessId"`
+	UserId     uuid.UUID `param:"userId"`
+	RoleId     uuid.UUID `param:"roleId"`
+}
+
+func (r *Router) ListMembersRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Members retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Get Business Members",
+			Description: "Retrieves the members of a business together with the roles they hold inside it.",
+			Tags:        []string{"Business Members"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/businesses/{businessId}/members",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.users.view"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params MembersParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			var memberships []models.Membership
+
+			if err := r.storage.Database().
+				Where("business_id = ?", params.BusinessId).
+				Order("created_at ASC").
+				Find(&memberships).Error; err != nil {
+				log.Errorf("🔥 Error retrieving members: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			userIds := []uuid.UUID{}
+
+			for _, membership := range memberships {
+				userIds = append(userIds, membership.UserId)
+			}
+
+			var users []models.User
+			var membershipRoles []models.MembershipRole
+
+			if len(userIds) > 0 {
+				if err := r.storage.Database().Where("id IN ?", userIds).Find(&users).Error; err != nil {
+					log.Errorf("🔥 Error retrieving members: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
+					})
+				}
+
+				if err := r.storage.Database().
+					Preload("Role").
+					Where("business_id = ?", params.BusinessId).
+					Find(&membershipRoles).Error; err != nil {
+					log.Errorf("🔥 Error retrieving member roles: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
+					})
+				}
+			}
+
+			for index := range memberships {
+				memberships[index].Roles = []models.Role{}
+
+				for userIndex := range users {
+					if users[userIndex].Id == memberships[index].UserId {
+						memberships[index].User = &users[userIndex]
+					}
+				}
+
+				for _, membershipRole := range membershipRoles {
+					if membershipRole.UserId == memberships[index].UserId {
+						memberships[index].Roles = append(memberships[index].Roles, membershipRole.Role)
+					}
+				}
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items": memberships,
+			})
+		},
+	}
+}
+
+func (r *Router) AssignMemberRoleRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Role assigned successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Assign Member Role",
+			Description: "Grants a role to a member inside this business only.",
+			Tags:        []string{"Business Members"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("userId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("roleId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.POST,
+		Path:   "/businesses/{businessId}/members/{userId}/roles/{roleId}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.roles.assign"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params MemberRoleParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			var membership models.Membership
+
+			if err := r.storage.Database().
+				Where("business_id = ? AND user_id = ?", params.BusinessId, params.UserId).
+				First(&membership).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The user is not a member of this business.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving member: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			var role models.Role
+
+			if err := r.storage.Database().
+				Where("id = ? AND (business_id IS NULL OR business_id = ?)", params.RoleId, params.BusinessId).
+				First(&role).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The role was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving role: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if !holdsAll(currentUser, role.Permissions) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You can't assign a role that grants permissions you don't hold.",
+				})
+			}
+
+			membershipRole := models.MembershipRole{
+				BusinessId: membership.BusinessId,
+				UserId:     membership.UserId,
+				RoleId:     role.Id,
+			}
+
+			if err := r.storage.Database().Clauses(clause.OnConflict{DoNothing: true}).Create(&membershipRole).Error; err != nil {
+				log.Errorf("🔥 Error assigning member role: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
+
+func (r *Router) UnassignMemberRoleRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Role unassigned successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Unassign Member Role",
+			Description: "Removes a role from a member of this business and signs the member out of all of their sessions.",
+			Tags:        []string{"Business Members"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("userId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("roleId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.DELETE,
+		Path:   "/businesses/{businessId}/members/{userId}/roles/{roleId}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.roles.unassign"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params MemberRoleParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			result := r.storage.Database().
+				Where("business_id = ? AND user_id = ? AND role_id = ?", params.BusinessId, params.UserId, params.RoleId).
+				Delete(&models.MembershipRole{})
+
+			if result.Error != nil {
+				log.Errorf("🔥 Error unassigning member role: %s", result.Error.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if result.RowsAffected > 0 {
+				r.revokeSessions(params.UserId)
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
+
+// removeMemberRoles wraps the generic unassign handler so that a user who
+// leaves a business also loses the roles they held inside it.
+func (r *Router) removeMemberRoles(handler fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if err := handler(c); err != nil {
+			return err
+		}
+
+		if c.Response().StatusCode() != fiber.StatusOK {
+			return nil
+		}
+
+		businessId, err := uuid.Parse(c.Params("businessId"))
+
+		if err != nil {
+			return nil
+		}
+
+		userId, err := uuid.Parse(c.Params("userId"))
+
+		if err != nil {
+			return nil
+		}
+
+		result := r.storage.Database().
+			Where("business_id = ? AND user_id = ?", businessId, userId).
+			Delete(&models.MembershipRole{})
+
+		if result.Error != nil {
+			log.Errorf("🔥 Error removing member roles: %s", result.Error.Error())
+		}
+
+		if result.RowsAffected > 0 {
+			r.revokeSessions(userId)
+		}
+
+		return nil
+	}
+}
+
+// revokeSessions signs members who lost a business role out everywhere, like
+// removing a global role does, so that the removed permissions can't keep
+// being used from an existing session. Their cached principals are dropped by
+// the principals cache when the membership roles are deleted.
+func (r *Router) revokeSessions(userIds ...uuid.UUID) {
+	for _, userId := range userIds {
+		if err := r.sessions.RevokeAll(userId); err != nil {
+			log.Errorf("🔥 Error revoking sessions: %s", err.Error())
+		}
+	}
+}
@@ -0,0 +1,970 @@
+package businesses
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/connor-davis/threereco-nextgen/internal/lifecycle"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/payouts"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PayoutsParams struct {
+	BusinessId uuid.UUID `param:"businessId"`
+}
+
+type PayoutParams struct {
+	BusinessId uuid.UUID `param:"businessId"`
+	PayoutId   uuid.UUID `param:"payoutId"`
+}
+
+type PayoutExportQueryParams struct {
+	Format string `query:"format"`
+}
+
+func (r *Router) ListPayoutRunsRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Payout runs retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Get Business Payout Runs",
+			Description: "Retrieves the business's collector payout runs, newest first, without their statements.",
+			Tags:        []string{"Business Payouts"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/businesses/{businessId}/payouts",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.payouts.view"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params PayoutsParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			runs, err := r.payouts.Runs(params.BusinessId)
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving payout runs: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items": runs,
+			})
+		},
+	}
+}
+
+func (r *Router) CreatePayoutRunRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Payout run generated successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Generate Business Payout Run",
+			Description: "Generates a payout run for the approved collections the business recorded in the period that no other run holds, with one statement per collector. Collectors without valid bank details are skipped and listed in skipped, and their collections are left for a later run.",
+			Tags:        []string{"Business Payouts"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/CreatePayoutRunPayload",
+			},
+			Responses: responses,
+		},
+		Method: routing.POST,
+		Path:   "/businesses/{businessId}/payouts",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.payouts.create"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params PayoutsParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			var payload models.CreatePayoutRunPayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			run, skipped, err := r.payouts.Create(params.BusinessId, payload.From, payload.To, currentUser.Id)
+
+			if err != nil {
+				if errors.Is(err, payouts.ErrInvalidPeriod) || errors.Is(err, payouts.ErrNothingToPay) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": err.Error(),
+						"skipped": skipped,
+					})
+				}
+
+				log.Errorf("🔥 Error generating payout run: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item":    run,
+				"skipped": skipped,
+			})
+		},
+	}
+}
+
+func (r *Router) GetPayoutRunRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Payout run retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Get Business Payout Run",
+			Description: "Retrieves a payout run with its statements and the collections each statement pays.",
+			Tags:        []string{"Business Payouts"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("payoutId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/businesses/{businessId}/payouts/{payoutId}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.payouts.view"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params PayoutParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			run, err := r.payouts.Run(params.BusinessId, params.PayoutId)
+
+			if err != nil {
+				return payoutError(c, err, "retrieving payout run")
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": run,
+			})
+		},
+	}
+}
+
+func (r *Router) ExportPayoutRunRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Payout run exported successfully.").
+			WithContent(openapi3.Content{
+				"application/octet-stream": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Export Business Payout Run",
+			Description: "Downloads the run's pending and paid statements as a bank batch file, either CSV or the fixed-width EFT format, and marks a generated run exported. Cancelled runs can't be exported.",
+			Tags:        []string{"Business Payouts"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("payoutId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewQueryParameter("format").
+						WithSchema(openapi3.NewStringSchema().
+							WithEnum("csv", "eft").
+							WithDefault("csv")),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/businesses/{businessId}/payouts/{payoutId}/export",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.payouts.export"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params PayoutParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			var queryParams PayoutExportQueryParams
+
+			if err := c.QueryParser(&queryParams); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if queryParams.Format == "" {
+				queryParams.Format = string(payouts.CsvFormat)
+			}
+
+			file, name, err := r.payouts.Export(params.BusinessId, params.PayoutId, payouts.Format(queryParams.Format))
+
+			if err != nil {
+				return payoutError(c, err, "exporting payout run")
+			}
+
+			if queryParams.Format == string(payouts.CsvFormat) {
+				c.Set(fiber.HeaderContentType, "text/csv")
+			} else {
+				c.Set(fiber.HeaderContentType, fiber.MIMETextPlain)
+			}
+
+			c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name))
+
+			return c.Status(fiber.StatusOK).Send(file)
+		},
+	}
+}
+
+func (r *Router) ConfirmPayoutRunRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Payout run confirmed successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Confirm Business Payout Run",
+			Description: "Records that the bank paid the run: its statements are marked paid, apart from those listed in failedStatementIds, and their collections are moved to paid. The collections of failed statements are released for a later run.",
+			Tags:        []string{"Business Payouts"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("payoutId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/ConfirmPayoutRunPayload",
+			},
+			Responses: responses,
+		},
+		Method: routing.POST,
+		Path:   "/businesses/{businessId}/payouts/{payoutId}/confirm",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.payouts.confirm"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params PayoutParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			var payload models.ConfirmPayoutRunPayload
+
+			if len(c.Body()) > 0 {
+				if err := c.BodyParser(&payload); err != nil {
+					log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": "The request body is invalid.",
+					})
+				}
+			}
+
+			run, err := r.payouts.Confirm(params.BusinessId, params.PayoutId, payload.FailedStatementIds, currentUser.Id)
+
+			if err != nil {
+				return payoutError(c, err, "confirming payout run")
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": run,
+			})
+		},
+	}
+}
+
+func (r *Router) CancelPayoutRunRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Payout run cancelled successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Cancel Business Payout Run",
+			Description: "Cancels a payout run that hasn't been confirmed and releases its collections for a later run.",
+			Tags:        []string{"Business Payouts"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("payoutId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.POST,
+		Path:   "/businesses/{businessId}/payouts/{payoutId}/cancel",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.payouts.cancel"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params PayoutParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			run, err := r.payouts.Cancel(params.BusinessId, params.PayoutId)
+
+			if err != nil {
+				return payoutError(c, err, "cancelling payout run")
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": run,
+			})
+		},
+	}
+}
+
+func (r *Router) ReconcilePayoutRunRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Payout run reconciled successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Reconcile Business Payout Run",
+			Description: "Compares what the run was meant to pay with what its statements say was paid, failed or is pending, and lists discrepancies such as collections whose value changed, collections not marked paid after a paid statement, or collections paid by another run.",
+			Tags:        []string{"Business Payouts"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("businessId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewPathParameter("payoutId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/businesses/{businessId}/payouts/{payoutId}/reconciliation",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("businesses.payouts.view"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params PayoutParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if !currentUser.MemberOf(params.BusinessId) {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "You do not have permission to access this resource.",
+				})
+			}
+
+			reconciliation, err := r.payouts.Reconcile(params.BusinessId, params.PayoutId)
+
+			if err != nil {
+				return payoutError(c, err, "reconciling payout run")
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": reconciliation,
+			})
+		},
+	}
+}
+
+// payoutError maps the errors of the payouts service to responses.
+func payoutError(c *fiber.Ctx, err error, action string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error":   "Not Found",
+			"message": "The payout run was not found.",
+		})
+	case errors.Is(err, payouts.ErrRunClosed), errors.Is(err, lifecycle.ErrInvalidTransition):
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   "Conflict",
+			"message": err.Error(),
+		})
+	case errors.Is(err, payouts.ErrUnknownFormat), errors.Is(err, payouts.ErrUnknownStatement):
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	log.Errorf("🔥 Error %s: %s", action, err.Error())
+
+	return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		"error":   "Internal Server Error",
+		"message": "An error occurred while processing your request.",
+	})
+}
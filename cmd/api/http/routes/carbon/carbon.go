@@ -0,0 +1,146 @@
+package carbon
+
+import (
+	"slices"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
+	"github.com/connor-davis/threereco-nextgen/internal/carbon"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+type CarbonRouter struct {
+	storage    storage.Storage
+	middleware middleware.Middleware
+	carbon     carbon.Carbon
+}
+
+func NewCarbonRouter(storage storage.Storage, middleware middleware.Middleware, carbon carbon.Carbon) Router {
+	return &CarbonRouter{
+		storage:    storage,
+		middleware: middleware,
+		carbon:     carbon,
+	}
+}
+
+func (r *CarbonRouter) LoadRoutes() []routing.Route {
+	collectionsRoute := r.CollectionsRoute()
+	collectionRoute := r.CollectionRoute()
+	transactionsRoute := r.TransactionsRoute()
+	transactionRoute := r.TransactionRoute()
+
+	return []routing.Route{
+		collectionsRoute,
+		collectionRoute,
+		transactionsRoute,
+		transactionRoute,
+	}
+}
+
+type SavingsParams struct {
+	Id uuid.UUID `param:"id"`
+}
+
+type SavingsQueryParams struct {
+	CollectorId *uuid.UUID `query:"collectorId"`
+	BusinessId  *uuid.UUID `query:"businessId"`
+	From        string     `query:"from"`
+	To          string     `query:"to"`
+	Interval    string     `query:"interval"`
+}
+
+// periodParameters describes the query parameters shared by the savings
+// reports.
+func periodParameters() []*openapi3.ParameterRef {
+	return []*openapi3.ParameterRef{
+		{
+			Value: openapi3.NewQueryParameter("businessId").
+				WithSchema(openapi3.NewUUIDSchema()),
+		},
+		{
+			Value: openapi3.NewQueryParameter("from").
+				WithDescription("Start of the period (inclusive), as a date or RFC 3339 timestamp.").
+				WithSchema(openapi3.NewStringSchema()),
+		},
+		{
+			Value: openapi3.NewQueryParameter("to").
+				WithDescription("End of the period (exclusive), as a date or RFC 3339 timestamp.").
+				WithSchema(openapi3.NewStringSchema()),
+		},
+		{
+			Value: openapi3.NewQueryParameter("interval").
+				WithDescription("Breaks the savings down into periods of this length.").
+				WithSchema(openapi3.NewStringSchema().WithEnum(
+					string(carbon.DayInterval),
+					string(carbon.WeekInterval),
+					string(carbon.MonthInterval),
+					string(carbon.YearInterval),
+				)),
+		},
+	}
+}
+
+// parseFilter writes the error response itself when it returns false.
+func parseFilter(c *fiber.Ctx) (carbon.Filter, bool, error) {
+	var queryParams SavingsQueryParams
+
+	if err := c.QueryParser(&queryParams); err != nil {
+		return carbon.Filter{}, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": err.Error(),
+		})
+	}
+
+	filter := carbon.Filter{
+		CollectorId: queryParams.CollectorId,
+		BusinessId:  queryParams.BusinessId,
+		Interval:    carbon.Interval(queryParams.Interval),
+	}
+
+	if !slices.Contains([]carbon.Interval{carbon.NoInterval, carbon.DayInterval, carbon.WeekInterval, carbon.MonthInterval, carbon.YearInterval}, filter.Interval) {
+		return filter, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Bad Request",
+			"message": carbon.ErrInvalidInterval.Error(),
+		})
+	}
+
+	if queryParams.From != "" {
+		from, err := parseTime(queryParams.From)
+
+		if err != nil {
+			return filter, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": "The period must be given as dates (2006-01-02) or RFC 3339 timestamps.",
+			})
+		}
+
+		filter.From = &from
+	}
+
+	if queryParams.To != "" {
+		to, err := parseTime(queryParams.To)
+
+		if err != nil {
+			return filter, false, c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Bad Request",
+				"message": "The period must be given as dates (2006-01-02) or RFC 3339 timestamps.",
+			})
+		}
+
+		filter.To = &to
+	}
+
+	return filter, true, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+
+	return time.ParseInLocation(time.DateOnly, value, time.Local)
+}
@@ -1,9 +1,15 @@
 package collections
 
 import (
+	"fmt"
+
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
 	"github.com/connor-davis/threereco-nextgen/internal/api"
+	"github.com/connor-davis/threereco-nextgen/internal/documents"
+	"github.com/connor-davis/threereco-nextgen/internal/lifecycle"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/pricing"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
 )
@@ -11,35 +17,41 @@ import (
 type CollectionsRouter struct {
 	storage    storage.Storage
 	middleware middleware.Middleware
+	pricing    pricing.Pricing
+	lifecycle  lifecycle.Lifecycle
+	documents  documents.Documents
 }
 
-func NewCollectionsRouter(storage storage.Storage, middleware middleware.Middleware) Router {
+func NewCollectionsRouter(storage storage.Storage, middleware middleware.Middleware, pricing pricing.Pricing, lifecycle lifecycle.Lifecycle, documents documents.Documents) Router {
 	return &CollectionsRouter{
 		storage:    storage,
 		middleware: middleware,
+		pricing:    pricing,
+		lifecycle:  lifecycle,
+		documents:  documents,
 	}
 }
 
 func (r *CollectionsRouter) LoadRoutes() []routing.Route {
-	assignMaterialsApi := api.NewAssignmentApi[models.Collection, models.CollectionMaterial](
+	materialsApi := api.NewAssignmentApi[models.Collection, models.CollectionMaterial](
 		r.storage,
 		"/collections",
 		"Collection",
 		"Material",
 	)
 
-	assignMaterialRoute := assignMaterialsApi.AssignRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("collections.materials.assign"),
-	)
-	unassignMaterialRoute := assignMaterialsApi.UnassignRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("collections.materials.unassign"),
-	)
-	listMaterialsRoute := assignMaterialsApi.ListRoute(
+	listMaterialsRoute := materialsApi.ListRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("collections.materials.view"),
-		r.middleware.Policies(models.CollectionsPolicy, models.SystemPolicy),
+		r.middleware.Policies(models.CollectionsPolicy, policies.ViewAction),
+	)
+
+	lifecycleApi := api.NewLifecycleApi(
+		r.storage,
+		r.lifecycle,
+		models.CollectionsPolicy,
+		"/collections",
+		"Collection",
 	)
 
 	api := api.NewBaseApi[models.Collection](
@@ -53,34 +65,59 @@ func (r *CollectionsRouter) LoadRoutes() []routing.Route {
 	getAllRoute := api.GetAllRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("collections.view"),
-		r.middleware.Policies(models.CollectionsPolicy, models.SystemPolicy),
+		r.middleware.Policies(models.CollectionsPolicy, policies.ViewAction),
 	)
+	getAllRoute = lifecycleApi.StatusFilter(getAllRoute)
 	getOneRoute := api.GetOneRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("collections.view"),
-		r.middleware.Policies(models.CollectionsPolicy, models.SystemPolicy),
-	)
-	createRoute := api.CreateRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("collections.create"),
+		r.middleware.Policies(models.CollectionsPolicy, policies.ViewAction),
 	)
+	createRoute := r.CreateRoute()
 	updateRoute := api.UpdateRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("collections.update"),
+		r.middleware.Policies(models.CollectionsPolicy, policies.UpdateAction),
+		r.middleware.Unlocked(models.CollectionsPolicy, "id"),
 	)
 	deleteRoute := api.DeleteRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("collections.delete"),
+		r.middleware.Policies(models.CollectionsPolicy, policies.DeleteAction),
+		r.middleware.Unlocked(models.CollectionsPolicy, "id"),
+	)
+
+	createLineRoute := r.CreateLineRoute()
+	receiptRoute := r.ReceiptRoute()
+
+	historyRoute := lifecycleApi.HistoryRoute(
+		r.middleware.Authenticated(),
+		r.middleware.Authorized("collections.view"),
+		r.middleware.Policies(models.CollectionsPolicy, policies.ViewAction),
 	)
 
-	return []routing.Route{
-		assignMaterialRoute,
-		unassignMaterialRoute,
+	transitionRoutes := []routing.Route{}
+
+	for _, transition := range lifecycle.Transitions {
+		transitionRoutes = append(transitionRoutes, lifecycleApi.TransitionRoute(
+			transition,
+			r.middleware.Authenticated(),
+			r.middleware.Authorized(fmt.Sprintf("collections.status.%s", transition.Name)),
+			r.middleware.Policies(models.CollectionsPolicy, policies.TransitionAction(transition.Name)),
+		))
+	}
+
+	routes := []routing.Route{
+		createLineRoute,
 		listMaterialsRoute,
 		getAllRoute,
 		getOneRoute,
 		createRoute,
 		updateRoute,
 		deleteRoute,
+		historyRoute,
+		receiptRoute,
 	}
+
+	return append(routes, transitionRoutes...)
 }
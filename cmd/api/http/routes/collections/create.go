@@ -0,0 +1,273 @@
+package collections
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/pricing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/connor-davis/threereco-nextgen/internal/totals"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *CollectionsRouter) CreateRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Collection created successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Create Collection",
+			Description: "Creates a collection together with its lines in one step. A collection needs at least one line. Lines without a value are valued from the buyer's price list; the optional totals must match the sum of the lines.",
+			Tags:        []string{"Collections"},
+			Parameters:  nil,
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/CreateCollectionPayload",
+			},
+			Responses: responses,
+		},
+		Method: routing.POST,
+		Path:   "/collections",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("collections.create"),
+			r.middleware.Policies(models.CollectionsPolicy, policies.CreateAction),
+			r.middleware.DefaultBusiness("buyerId"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var payload models.CreateCollectionPayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			if len(payload.Materials) == 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "A collection needs at least one line.",
+				})
+			}
+
+			var sellers int64
+
+			if err := r.storage.Database().
+				Model(&models.User{}).
+				Where("id = ?", payload.SellerId).
+				Count(&sellers).Error; err != nil {
+				log.Errorf("🔥 Error retrieving seller: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if sellers == 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The seller was not found.",
+				})
+			}
+
+			var buyers int64
+
+			if err := r.storage.Database().
+				Model(&models.Business{}).
+				Where("id = ?", payload.BuyerId).
+				Count(&buyers).Error; err != nil {
+				log.Errorf("🔥 Error retrieving buyer: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if buyers == 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The buyer was not found.",
+				})
+			}
+
+			materialIds := []uuid.UUID{}
+
+			for _, line := range payload.Materials {
+				if !slices.Contains(materialIds, line.MaterialId) {
+					materialIds = append(materialIds, line.MaterialId)
+				}
+			}
+
+			var count int64
+
+			if err := r.storage.Database().
+				Model(&models.Material{}).
+				Where("id IN ?", materialIds).
+				Count(&count).Error; err != nil {
+				log.Errorf("🔥 Error retrieving materials: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if int(count) != len(materialIds) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error":   "Not Found",
+					"message": "One or more of the materials were not found.",
+				})
+			}
+
+			// The collection is valued at the prices of the moment it's
+			// recorded, so its creation time is set before the lines are valued.
+			collection := models.Collection{
+				Base: models.Base{
+					CreatedAt: time.Now(),
+				},
+				SellerId: payload.SellerId,
+				BuyerId:  payload.BuyerId,
+			}
+
+			lines := []totals.Line{}
+
+			for _, linePayload := range payload.Materials {
+				line := models.CollectionMaterial{
+					MaterialId: &linePayload.MaterialId,
+					Weight:     linePayload.Weight,
+				}
+
+				if linePayload.Value != nil {
+					line.Value = *linePayload.Value
+				}
+
+				if line.Weight > 0 {
+					if err := r.pricing.ValueLine(&collection, &line, linePayload.MaterialId, linePayload.Value, currentUser.Id); err != nil {
+						if errors.Is(err, pricing.ErrNoPrice) {
+							return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+								"error":   "Bad Request",
+								"message": "The buyer has no price for one or more of the materials, so those lines need a value.",
+							})
+						}
+
+						log.Errorf("🔥 Error valuing collection line: %s", err.Error())
+
+						return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+							"error":   "Internal Server Error",
+							"message": "An error occurred while processing your request.",
+						})
+					}
+				}
+
+				collection.Materials = append(collection.Materials, line)
+				lines = append(lines, totals.Line{Weight: line.Weight, Value: line.Value})
+			}
+
+			if _, err := totals.Validate(lines, payload.TotalWeight, payload.TotalValue); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			scope, _ := c.Locals("policies").(clause.Expression)
+
+			if err := r.storage.Database().Transaction(func(tx *gorm.DB) error {
+				if err := tx.Create(&collection).Error; err != nil {
+					return err
+				}
+
+				return policies.InScope(tx, &models.Collection{}, collection.Id, scope)
+			}); err != nil {
+				if errors.Is(err, policies.ErrOutOfScope) {
+					return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+						"error":   "Forbidden",
+						"message": "You can only create collections bought by your active business.",
+					})
+				}
+
+				log.Errorf("🔥 Error creating collection: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).SendString(collection.Id.String())
+		},
+	}
+}
@@ -0,0 +1,612 @@
+package collections
+
+import (
+	"errors"
+	"math"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/pricing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/connor-davis/threereco-nextgen/internal/totals"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CollectionLinesParams struct {
+	CollectionId uuid.UUID `param:"collectionId"`
+}
+
+func (r *CollectionsRouter) CreateLineRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Collection line created successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Create Collection Line",
+			Description: "Adds a line for a catalogue material to a collection. The line's value is the weight times the buyer's price for the material, picking the tier for the collector's grade and the line's weight, as the price list stood when the collection was recorded. A value sent with the line overrides the computed value and is recorded as a manual override.",
+			Tags:        []string{"Collections"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("collectionId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/CreateCollectionLinePayload",
+			},
+			Responses: responses,
+		},
+		Method: routing.POST,
+		Path:   "/collections/{collectionId}/materials",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("collections.materials.create"),
+			r.middleware.Policies(models.CollectionsPolicy, policies.UpdateAction),
+			r.middleware.Unlocked(models.CollectionsPolicy, "collectionId"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params CollectionLinesParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var payload models.CreateCollectionLinePayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			if math.IsNaN(payload.Weight) || payload.Weight <= 0 || (payload.Value != nil && (payload.Value.IsNegative() || !payload.Value.Equal(models.Cents(*payload.Value)))) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The weight must be more than zero and the value must be an amount of at least zero, to the cent.",
+				})
+			}
+
+			query := r.storage.Database().Model(&models.Collection{})
+
+			if scope, ok := c.Locals("policies").(clause.Expression); ok && scope != nil {
+				query = query.Clauses(scope)
+			}
+
+			var collection models.Collection
+
+			if err := query.Where("id = ?", params.CollectionId).First(&collection).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The collection was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving collection: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			var material models.Material
+
+			if err := r.storage.Database().Where("id = ?", payload.MaterialId).First(&material).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The material was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving material: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			line := models.CollectionMaterial{
+				CollectionId: collection.Id,
+				MaterialId:   &material.Id,
+				Weight:       payload.Weight,
+			}
+
+			if err := r.pricing.ValueLine(&collection, &line, material.Id, payload.Value, currentUser.Id); err != nil {
+				if errors.Is(err, pricing.ErrNoPrice) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": "The buyer has no price for this material, so the line needs a value.",
+					})
+				}
+
+				log.Errorf("🔥 Error valuing collection line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.storage.Database().Create(&line).Error; err != nil {
+				log.Errorf("🔥 Error creating collection line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": line,
+			})
+		},
+	}
+}
+
+type CollectionLineParams struct {
+	Id uuid.UUID `param:"id"`
+}
+
+func (r *CollectionMaterialsRouter) UpdateLineRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Collection line updated successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Update Collection Material",
+			Description: "Replaces the material, weight and value of a line of a collection the user may update. The line is revalued as when it was added: from the buyer's price list, or with the value given recorded as a manual override.",
+			Tags:        []string{"Collection Materials"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("id").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().
+					WithRequired(true).
+					WithJSONSchemaRef(&openapi3.SchemaRef{
+						Ref: "#/components/schemas/UpdateCollectionMaterial",
+					}).
+					WithDescription("Payload to update an existing collection material."),
+			},
+			Responses: responses,
+		},
+		Method: routing.PUT,
+		Path:   "/collections/materials/{id}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("collections.materials.update"),
+			r.middleware.LinePolicies(models.CollectionsPolicy, policies.UpdateAction),
+			r.middleware.LineUnlocked(models.CollectionsPolicy, "id"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params CollectionLineParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var payload models.UpdateCollectionLinePayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			if math.IsNaN(payload.Weight) || payload.Weight <= 0 || (payload.Value != nil && (payload.Value.IsNegative() || !payload.Value.Equal(models.Cents(*payload.Value)))) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The weight must be more than zero and the value must be an amount of at least zero, to the cent.",
+				})
+			}
+
+			query := r.storage.Database().Model(&models.CollectionMaterial{})
+
+			if scope, ok := c.Locals("policies").(clause.Expression); ok && scope != nil {
+				query = query.Clauses(scope)
+			}
+
+			var line models.CollectionMaterial
+
+			if err := query.Where("id = ?", params.Id).First(&line).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The collection material was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving collection line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			var collection models.Collection
+
+			if err := r.storage.Database().Where("id = ?", line.CollectionId).First(&collection).Error; err != nil {
+				log.Errorf("🔥 Error retrieving collection: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			line.MaterialId = &payload.MaterialId
+
+			if err := line.SnapshotMaterial(r.storage.Database()); err != nil {
+				if errors.Is(err, models.ErrUnknownMaterial) {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The material was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving material: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			// The line is valued afresh, so nothing of its old valuation is
+			// kept.
+			line.Weight = payload.Weight
+			line.PriceId = nil
+			line.PricePerKg = nil
+			line.ComputedValue = nil
+			line.ValueOverridden = false
+			line.OverriddenById = nil
+
+			if err := r.pricing.ValueLine(&collection, &line, payload.MaterialId, payload.Value, currentUser.Id); err != nil {
+				if errors.Is(err, pricing.ErrNoPrice) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": "The buyer has no price for this material, so the line needs a value.",
+					})
+				}
+
+				log.Errorf("🔥 Error valuing collection line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.storage.Database().Omit(clause.Associations).Save(&line).Error; err != nil {
+				log.Errorf("🔥 Error updating collection line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": line,
+			})
+		},
+	}
+}
+
+func (r *CollectionMaterialsRouter) DeleteLineRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Collection line deleted successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Delete Collection Line",
+			Description: "Deletes a line from a collection. A collection needs at least one line, so its last line can't be deleted.",
+			Tags:        []string{"Collections"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("id").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.DELETE,
+		Path:   "/collections/materials/{id}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("collections.materials.delete"),
+			r.middleware.LinePolicies(models.CollectionsPolicy, policies.UpdateAction),
+			r.middleware.LineUnlocked(models.CollectionsPolicy, "id"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			var params CollectionLineParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			query := r.storage.Database().Model(&models.CollectionMaterial{})
+
+			if scope, ok := c.Locals("policies").(clause.Expression); ok && scope != nil {
+				query = query.Clauses(scope)
+			}
+
+			var line models.CollectionMaterial
+
+			if err := query.Where("id = ?", params.Id).First(&line).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The collection material was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving collection line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.storage.Database().Transaction(func(tx *gorm.DB) error {
+				// The collection is locked so two deletes can't both remove
+				// what they each think is the second to last line.
+				if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+					Where("id = ?", line.CollectionId).
+					First(&models.Collection{}).Error; err != nil {
+					return err
+				}
+
+				var lines int64
+
+				if err := tx.Model(&models.CollectionMaterial{}).
+					Where("collection_id = ?", line.CollectionId).
+					Count(&lines).Error; err != nil {
+					return err
+				}
+
+				if lines <= 1 {
+					return totals.ErrNoLines
+				}
+
+				return tx.Delete(&line).Error
+			}); err != nil {
+				if errors.Is(err, totals.ErrNoLines) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": "A collection needs at least one line, so its last line can't be deleted.",
+					})
+				}
+
+				log.Errorf("🔥 Error deleting collection line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).SendString("OK")
+		},
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
 	"github.com/connor-davis/threereco-nextgen/internal/api"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/pricing"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
 )
@@ -11,12 +13,14 @@ import (
 type CollectionMaterialsRouter struct {
 	storage    storage.Storage
 	middleware middleware.Middleware
+	pricing    pricing.Pricing
 }
 
-func NewCollectionMaterialsRouter(storage storage.Storage, middleware middleware.Middleware) Router {
+func NewCollectionMaterialsRouter(storage storage.Storage, middleware middleware.Middleware, pricing pricing.Pricing) Router {
 	return &CollectionMaterialsRouter{
 		storage:    storage,
 		middleware: middleware,
+		pricing:    pricing,
 	}
 }
 
@@ -32,28 +36,23 @@ func (r *CollectionMaterialsRouter) LoadRoutes() []routing.Route {
 	getAllRoute := api.GetAllRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("collections.materials.view"),
+		r.middleware.LinePolicies(models.CollectionsPolicy, policies.ViewAction),
 	)
 	getOneRoute := api.GetOneRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("collections.materials.view"),
+		r.middleware.LinePolicies(models.CollectionsPolicy, policies.ViewAction),
 	)
-	createRoute := api.CreateRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("collections.materials.create"),
-	)
-	updateRoute := api.UpdateRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("collections.materials.update"),
-	)
-	deleteRoute := api.DeleteRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("collections.materials.delete"),
-	)
+	updateRoute := r.UpdateLineRoute()
+	deleteRoute := r.DeleteLineRoute()
+
+	// The unmatched route goes first so that its path isn't taken for an id.
+	unmatchedRoute := r.UnmatchedRoute()
 
 	return []routing.Route{
+		unmatchedRoute,
 		getAllRoute,
 		getOneRoute,
-		createRoute,
 		updateRoute,
 		deleteRoute,
 	}
@@ -0,0 +1,101 @@
+package collections
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm/clause"
+)
+
+func (r *CollectionMaterialsRouter) UnmatchedRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Unmatched collection lines retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Get Unmatched Collection Lines",
+			Description: "Retrieves the collection lines that aren't linked to a catalogue material because their GW code matched no material when lines were linked to the catalogue. Link them by updating their materialId.",
+			Tags:        []string{"Collection Materials"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/collections/materials/unmatched",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("collections.materials.view"),
+			r.middleware.Policies(models.CollectionsPolicy, policies.ViewAction),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			query := r.storage.Database().
+				Table("collection_materials").
+				Select("collection_materials.id, collection_materials.collection_id AS owner_id, collection_materials.name, collection_materials.gw_code, collection_materials.weight, collection_materials.value, collection_materials.created_at").
+				Joins("JOIN collections ON collections.id = collection_materials.collection_id").
+				Where("collection_materials.material_id IS NULL")
+
+			if scope, ok := c.Locals("policies").(clause.Expression); ok && scope != nil {
+				query = query.Clauses(scope)
+			}
+
+			lines := []models.UnmatchedLine{}
+
+			if err := query.
+				Order("collection_materials.gw_code ASC, collection_materials.created_at ASC").
+				Scan(&lines).Error; err != nil {
+				log.Errorf("🔥 Error retrieving unmatched collection lines: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items": lines,
+			})
+		},
+	}
+}
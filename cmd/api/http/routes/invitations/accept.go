@@ -0,0 +1,277 @@
+package invitations
+
+import (
+	"errors"
+
+	"github.com/connor-davis/threereco-nextgen/internal/invitations"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+func (r *InvitationsRouter) AcceptRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Invitation accepted successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Accept Invitation",
+			Description: "Accepts an invitation. Existing accounts are linked to the business and must be logged in to accept; otherwise a name and password are required to create the account, and the new user is signed in.",
+			Tags:        []string{"Invitations"},
+			Parameters:  nil,
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/AcceptInvitationPayload",
+			},
+			Responses: responses,
+		},
+		Method:      routing.POST,
+		Path:        "/invitations/accept",
+		Middlewares: []fiber.Handler{},
+		Handler: func(c *fiber.Ctx) error {
+			var payload models.AcceptInvitationPayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			invitation, err := r.invitations.Resolve(payload.Token)
+
+			if err != nil {
+				if errors.Is(err, invitations.ErrInvalidInvitation) {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The invitation is invalid, expired, revoked or already accepted.",
+					})
+				}
+
+				log.Errorf("🔥 Error resolving invitation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			var user models.User
+
+			err = r.storage.Database().Where("username = ?", invitation.Recipient).First(&user).Error
+
+			if err != nil && err != gorm.ErrRecordNotFound {
+				log.Errorf("🔥 Error retrieving user: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			created := err == gorm.ErrRecordNotFound
+
+			if !created && user.Type == models.ServiceUser {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+					"error":   "Forbidden",
+					"message": "Service accounts can't accept invitations.",
+				})
+			}
+
+			if created {
+				if payload.Name == "" {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": "A name is required to create your account.",
+					})
+				}
+
+				if violations := r.passwords.Validate(invitation.Recipient, payload.Password); len(violations) > 0 {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":      "Bad Request",
+						"message":    "The password does not meet the password policy.",
+						"violations": violations,
+					})
+				}
+
+				hashedPassword, err := bcrypt.GenerateFromPassword([]byte(payload.Password), bcrypt.DefaultCost)
+
+				if err != nil {
+					log.Errorf("🔥 Error hashing password: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
+					})
+				}
+
+				user = models.User{
+					Name:     payload.Name,
+					Username: invitation.Recipient,
+					Password: hashedPassword,
+					Type:     invitation.UserType,
+				}
+			} else {
+				// Linking an existing account needs that account's own
+				// session, so a leaked invitation can't add someone to a
+				// business without them.
+				currentSession, err := r.session.Get(c)
+
+				if err != nil {
+					log.Errorf("🔥 Error retrieving session: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
+					})
+				}
+
+				if currentUserId, _ := currentSession.Get("user_id").(string); currentUserId != user.Id.String() {
+					return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+						"error":   "Unauthorized",
+						"message": "Log in as the invited account to accept this invitation.",
+					})
+				}
+
+				if err := r.sessions.Refresh(c, currentSession); err != nil {
+					if errors.Is(err, sessions.ErrSessionExpired) {
+						return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+							"error":   "Unauthorized",
+							"message": "Your session has expired. Please log in again.",
+						})
+					}
+
+					log.Errorf("🔥 Error refreshing session: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
+					})
+				}
+
+				if !r.sessions.VerifyCsrf(c, currentSession) {
+					return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+						"error":   "Forbidden",
+						"message": "The CSRF token is missing or invalid.",
+					})
+				}
+			}
+
+			if err := r.invitations.Accept(invitation, &user); err != nil {
+				if errors.Is(err, invitations.ErrInvalidInvitation) {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The invitation is invalid, expired, revoked or already accepted.",
+					})
+				}
+
+				log.Errorf("🔥 Error accepting invitation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if !created {
+				return c.SendStatus(fiber.StatusOK)
+			}
+
+			if err := r.passwords.Remember(user.Id, user.Password); err != nil {
+				log.Errorf("🔥 Error recording password history: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			currentSession, err := r.session.Get(c)
+
+			if err != nil {
+				log.Errorf("🔥 Error retrieving session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.sessions.Start(c, currentSession, user.Id); err != nil {
+				log.Errorf("🔥 Error starting session: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
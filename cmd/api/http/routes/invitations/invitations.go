@@ -0,0 +1,41 @@
+package invitations
+
+import (
+	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
+	"github.com/connor-davis/threereco-nextgen/internal/invitations"
+	"github.com/connor-davis/threereco-nextgen/internal/passwords"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/gofiber/fiber/v2/middleware/session"
+)
+
+type InvitationsRouter struct {
+	storage     storage.Storage
+	middleware  middleware.Middleware
+	session     *session.Store
+	sessions    sessions.Manager
+	passwords   passwords.Passwords
+	invitations invitations.Invitations
+}
+
+func NewInvitationsRouter(storage storage.Storage, middleware middleware.Middleware, session *session.Store, sessions sessions.Manager, passwords passwords.Passwords, invitations invitations.Invitations) Router {
+	return &InvitationsRouter{
+		storage:     storage,
+		middleware:  middleware,
+		session:     session,
+		sessions:    sessions,
+		passwords:   passwords,
+		invitations: invitations,
+	}
+}
+
+func (r *InvitationsRouter) LoadRoutes() []routing.Route {
+	previewRoute := r.PreviewRoute()
+	acceptRoute := r.AcceptRoute()
+
+	return []routing.Route{
+		previewRoute,
+		acceptRoute,
+	}
+}
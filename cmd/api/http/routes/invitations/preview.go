@@ -0,0 +1,132 @@
+package invitations
+
+import (
+	"errors"
+
+	"github.com/connor-davis/threereco-nextgen/internal/invitations"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type PreviewQuery struct {
+	Token string `query:"token"`
+}
+
+func (r *InvitationsRouter) PreviewRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Invitation retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Preview Invitation",
+			Description: "Shows which business and role an invitation link is for, and whether accepting it needs a new account.",
+			Tags:        []string{"Invitations"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewQueryParameter("token").
+						WithRequired(true).
+						WithSchema(openapi3.NewStringSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method:      routing.GET,
+		Path:        "/invitations/preview",
+		Middlewares: []fiber.Handler{},
+		Handler: func(c *fiber.Ctx) error {
+			var query PreviewQuery
+
+			if err := c.QueryParser(&query); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			invitation, err := r.invitations.Resolve(query.Token)
+
+			if err != nil {
+				if errors.Is(err, invitations.ErrInvalidInvitation) {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The invitation is invalid, expired, revoked or already accepted.",
+					})
+				}
+
+				log.Errorf("🔥 Error resolving invitation: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			var existingUsers int64
+
+			if err := r.storage.Database().
+				Model(&models.User{}).
+				Where("username = ?", invitation.Recipient).
+				Count(&existingUsers).Error; err != nil {
+				log.Errorf("🔥 Error checking for existing user: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": fiber.Map{
+					"business":      invitation.Business.Name,
+					"role":          invitation.Role.Name,
+					"recipient":     invitation.Recipient,
+					"expiresAt":     invitation.ExpiresAt,
+					"accountExists": existingUsers > 0,
+				},
+			})
+		},
+	}
+}
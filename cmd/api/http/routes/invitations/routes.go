@@ -0,0 +1,7 @@
+package invitations
+
+import "github.com/connor-davis/threereco-nextgen/internal/routing"
+
+type Router interface {
+	LoadRoutes() []routing.Route
+}
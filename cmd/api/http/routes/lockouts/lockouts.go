@@ -0,0 +1,55 @@
+package lockouts
+
+import (
+	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
+	"github.com/connor-davis/threereco-nextgen/internal/api"
+	"github.com/connor-davis/threereco-nextgen/internal/lockouts"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+)
+
+type LockoutsRouter struct {
+	storage    storage.Storage
+	middleware middleware.Middleware
+	lockouts   lockouts.Lockouts
+}
+
+func NewLockoutsRouter(storage storage.Storage, middleware middleware.Middleware, lockouts lockouts.Lockouts) Router {
+	return &LockoutsRouter{
+		storage:    storage,
+		middleware: middleware,
+		lockouts:   lockouts,
+	}
+}
+
+func (r *LockoutsRouter) LoadRoutes() []routing.Route {
+	api := api.NewBaseApi[models.Lockout](
+		r.storage,
+		"/lockouts",
+		"Lockout",
+		"",
+		"",
+	)
+
+	getAllRoute := api.GetAllRoute(
+		r.middleware.Authenticated(),
+		r.middleware.Authorized("lockouts.view"),
+	)
+	getOneRoute := api.GetOneRoute(
+		r.middleware.Authenticated(),
+		r.middleware.Authorized("lockouts.view"),
+	)
+	deleteRoute := api.DeleteRoute(
+		r.middleware.Authenticated(),
+		r.middleware.Authorized("lockouts.unlock"),
+	)
+	unlockUserRoute := r.UnlockUserRoute()
+
+	return []routing.Route{
+		getAllRoute,
+		getOneRoute,
+		deleteRoute,
+		unlockUserRoute,
+	}
+}
@@ -0,0 +1,171 @@
+package materials
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/carbon"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+func (r *MaterialsRouter) CreateRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Material created successfully").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Create Material",
+			Description: "This endpoint creates a new material. The carbon factor is in kgCO2e/kg and becomes the first version of the material's factor history.",
+			Tags:        []string{"Materials"},
+			Parameters:  nil,
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().
+					WithRequired(true).
+					WithJSONSchemaRef(&openapi3.SchemaRef{
+						Ref: "#/components/schemas/CreateMaterial",
+					}).
+					WithDescription("Payload to create a new material."),
+			},
+			Responses: responses,
+		},
+		Method: routing.POST,
+		Path:   "/materials",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("materials.create"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var payload models.CreateMaterialPayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			payload.Name = strings.TrimSpace(payload.Name)
+			payload.GWCode = strings.TrimSpace(payload.GWCode)
+
+			if payload.Name == "" || payload.GWCode == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The material name and GW code are required.",
+				})
+			}
+
+			if err := r.carbon.Validate(payload.CarbonFactor, payload.CarbonFactorUnit); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if ok, err := r.unique(c, payload.Name, payload.GWCode, nil); !ok {
+				return err
+			}
+
+			material := models.Material{
+				Name:             payload.Name,
+				GWCode:           payload.GWCode,
+				CarbonFactor:     payload.CarbonFactor,
+				CarbonFactorUnit: models.KgCO2ePerKg,
+			}
+
+			if err := r.storage.Database().Transaction(func(tx *gorm.DB) error {
+				if err := tx.Create(&material).Error; err != nil {
+					return err
+				}
+
+				_, err := r.carbon.SetFactor(tx, material.Id, payload.CarbonFactor, payload.CarbonFactorUnit, time.Now(), &currentUser.Id)
+
+				return err
+			}); err != nil {
+				if errors.Is(err, carbon.ErrInvalidFactor) || errors.Is(err, carbon.ErrInvalidUnit) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+
+				log.Errorf("🔥 Error creating material: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).SendString(material.Id.String())
+		},
+	}
+}
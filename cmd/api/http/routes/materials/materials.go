@@ -3,6 +3,7 @@ package materials
 import (
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
 	"github.com/connor-davis/threereco-nextgen/internal/api"
+	"github.com/connor-davis/threereco-nextgen/internal/carbon"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
@@ -11,12 +12,14 @@ import (
 type MaterialsRouter struct {
 	storage    storage.Storage
 	middleware middleware.Middleware
+	carbon     carbon.Carbon
 }
 
-func NewMaterialsRouter(storage storage.Storage, middleware middleware.Middleware) Router {
+func NewMaterialsRouter(storage storage.Storage, middleware middleware.Middleware, carbon carbon.Carbon) Router {
 	return &MaterialsRouter{
 		storage:    storage,
 		middleware: middleware,
+		carbon:     carbon,
 	}
 }
 
@@ -37,24 +40,23 @@ func (r *MaterialsRouter) LoadRoutes() []routing.Route {
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("materials.view"),
 	)
-	createRoute := api.CreateRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("materials.create"),
-	)
-	updateRoute := api.UpdateRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("materials.update"),
-	)
+	createRoute := r.CreateRoute()
+	updateRoute := r.UpdateRoute()
 	deleteRoute := api.DeleteRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("materials.delete"),
 	)
 
+	listCarbonFactorsRoute := r.ListCarbonFactorsRoute()
+	createCarbonFactorRoute := r.CreateCarbonFactorRoute()
+
 	return []routing.Route{
 		getAllRoute,
 		getOneRoute,
 		createRoute,
 		updateRoute,
 		deleteRoute,
+		listCarbonFactorsRoute,
+		createCarbonFactorRoute,
 	}
 }
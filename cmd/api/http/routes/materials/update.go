@@ -0,0 +1,282 @@
+package materials
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/carbon"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type MaterialParams struct {
+	Id uuid.UUID `param:"id"`
+}
+
+func (r *MaterialsRouter) UpdateRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Material updated successfully").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Update Material",
+			Description: "This endpoint updates an existing material. Changing the carbon factor adds a new version to the factor history that applies from now on; collections and transactions recorded earlier keep the factor that applied at the time.",
+			Tags:        []string{"Materials"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("id").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().
+					WithRequired(true).
+					WithJSONSchemaRef(&openapi3.SchemaRef{
+						Ref: "#/components/schemas/UpdateMaterial",
+					}).
+					WithDescription("Payload to update an existing material."),
+			},
+			Responses: responses,
+		},
+		Method: routing.PUT,
+		Path:   "/materials/{id}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("materials.update"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var params MaterialParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var payload models.UpdateMaterialPayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			material, ok, err := r.findMaterial(c, params.Id)
+
+			if !ok {
+				return err
+			}
+
+			if payload.Name != nil {
+				material.Name = strings.TrimSpace(*payload.Name)
+			}
+
+			if payload.GWCode != nil {
+				material.GWCode = strings.TrimSpace(*payload.GWCode)
+			}
+
+			if material.Name == "" || material.GWCode == "" {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The material name and GW code are required.",
+				})
+			}
+
+			factor := material.CarbonFactor
+			unit := material.CarbonFactorUnit
+
+			if payload.CarbonFactor != nil {
+				factor = *payload.CarbonFactor
+			}
+
+			if payload.CarbonFactorUnit != nil {
+				unit = *payload.CarbonFactorUnit
+			}
+
+			if err := r.carbon.Validate(factor, unit); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if unit == "" {
+				unit = models.KgCO2ePerKg
+			}
+
+			if ok, err := r.unique(c, material.Name, material.GWCode, &material.Id); !ok {
+				return err
+			}
+
+			if err := r.storage.Database().Transaction(func(tx *gorm.DB) error {
+				if err := tx.Model(&material).Updates(map[string]any{
+					"name":    material.Name,
+					"gw_code": material.GWCode,
+				}).Error; err != nil {
+					return err
+				}
+
+				if factor.Equal(material.CarbonFactor) && unit == material.CarbonFactorUnit {
+					return nil
+				}
+
+				_, err := r.carbon.SetFactor(tx, material.Id, factor, unit, time.Now(), &currentUser.Id)
+
+				return err
+			}); err != nil {
+				if errors.Is(err, carbon.ErrInvalidFactor) || errors.Is(err, carbon.ErrInvalidUnit) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				}
+
+				log.Errorf("🔥 Error updating material: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).SendString("OK")
+		},
+	}
+}
+
+// findMaterial writes the error response itself when it returns false.
+func (r *MaterialsRouter) findMaterial(c *fiber.Ctx, materialId uuid.UUID) (models.Material, bool, error) {
+	var material models.Material
+
+	if err := r.storage.Database().Where("id = ?", materialId).First(&material).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return material, false, c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error":   "Not Found",
+				"message": "The material was not found.",
+			})
+		}
+
+		log.Errorf("🔥 Error retrieving material: %s", err.Error())
+
+		return material, false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": "An error occurred while processing your request.",
+		})
+	}
+
+	return material, true, nil
+}
+
+// unique rejects a name or GW code that another material already uses. It
+// writes the error response itself when it returns false.
+func (r *MaterialsRouter) unique(c *fiber.Ctx, name string, gwCode string, materialId *uuid.UUID) (bool, error) {
+	query := r.storage.Database().
+		Model(&models.Material{}).
+		Where("(name = ? OR gw_code = ?)", name, gwCode)
+
+	if materialId != nil {
+		query = query.Where("id <> ?", *materialId)
+	}
+
+	var existing int64
+
+	if err := query.Count(&existing).Error; err != nil {
+		log.Errorf("🔥 Error retrieving materials: %s", err.Error())
+
+		return false, c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Internal Server Error",
+			"message": "An error occurred while processing your request.",
+		})
+	}
+
+	if existing > 0 {
+		return false, c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":   "Conflict",
+			"message": "A material with this name or GW code already exists.",
+		})
+	}
+
+	return true, nil
+}
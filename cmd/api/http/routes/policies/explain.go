@@ -0,0 +1,207 @@
+package policies
+
+import (
+	"slices"
+
+	"github.com/connor-davis/threereco-nextgen/internal/lifecycle"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ExplainQueryParams struct {
+	Entity     models.PolicyType `query:"entity"`
+	Action     policies.Action   `query:"action"`
+	UserId     *uuid.UUID        `query:"userId"`
+	BusinessId *uuid.UUID        `query:"businessId"`
+}
+
+func (r *PoliciesRouter) ExplainRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Policy decision explained successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Explain Policy Decision",
+			Description: "Explains whether a user may perform an action on an entity, which policies matched and the row filter that would be applied. Defaults to yourself in your active business.",
+			Tags:        []string{"Policies"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewQueryParameter("entity").
+						WithRequired(true).
+						WithSchema(openapi3.NewStringSchema().WithEnum(
+							string(models.CollectionsPolicy),
+							string(models.TransactionsPolicy),
+						)),
+				},
+				{
+					Value: openapi3.NewQueryParameter("action").
+						WithRequired(true).
+						WithSchema(openapi3.NewStringSchema().WithEnum(actions()...)),
+				},
+				{
+					Value: openapi3.NewQueryParameter("userId").
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+				{
+					Value: openapi3.NewQueryParameter("businessId").
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/policies/explain",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("policies.explain"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			currentUser := c.Locals("user").(*models.User)
+
+			var queryParams ExplainQueryParams
+
+			if err := c.QueryParser(&queryParams); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			if queryParams.Entity == "" || !slices.Contains(actions(), any(string(queryParams.Action))) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "A valid entity and action are required.",
+				})
+			}
+
+			user := currentUser
+
+			if queryParams.UserId != nil && *queryParams.UserId != currentUser.Id {
+				var target models.User
+
+				if err := r.storage.Database().
+					Where("id = ?", *queryParams.UserId).
+					Preload("Businesses").
+					First(&target).Error; err != nil {
+					if err == gorm.ErrRecordNotFound {
+						return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+							"error":   "Not Found",
+							"message": "The user was not found.",
+						})
+					}
+
+					log.Errorf("🔥 Error retrieving user: %s", err.Error())
+
+					return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+						"error":   "Internal Server Error",
+						"message": "An error occurred while processing your request.",
+					})
+				}
+
+				target.ActiveBusinessId = target.BusinessId
+				user = &target
+			}
+
+			if queryParams.BusinessId != nil {
+				if !user.MemberOf(*queryParams.BusinessId) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": "The user is not a member of this business.",
+					})
+				}
+
+				explained := *user
+				explained.ActiveBusinessId = queryParams.BusinessId
+				user = &explained
+			}
+
+			decision := policies.Evaluate(policies.Registry, user, queryParams.Entity, queryParams.Action)
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": decision,
+			})
+		},
+	}
+}
+
+// actions lists the actions that can be explained: the record actions and a
+// status.<transition> action per lifecycle transition.
+func actions() []any {
+	names := []any{}
+
+	for _, action := range policies.Actions {
+		names = append(names, string(action))
+	}
+
+	for _, transition := range lifecycle.Transitions {
+		names = append(names, string(policies.TransitionAction(transition.Name)))
+	}
+
+	return names
+}
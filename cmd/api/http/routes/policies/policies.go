@@ -0,0 +1,29 @@
+package policies
+
+import (
+	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+)
+
+type PoliciesRouter struct {
+	storage    storage.Storage
+	middleware middleware.Middleware
+}
+
+func NewPoliciesRouter(storage storage.Storage, middleware middleware.Middleware) Router {
+	return &PoliciesRouter{
+		storage:    storage,
+		middleware: middleware,
+	}
+}
+
+func (r *PoliciesRouter) LoadRoutes() []routing.Route {
+	listRoute := r.ListRoute()
+	explainRoute := r.ExplainRoute()
+
+	return []routing.Route{
+		listRoute,
+		explainRoute,
+	}
+}
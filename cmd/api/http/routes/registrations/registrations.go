@@ -0,0 +1,34 @@
+package registrations
+
+import (
+	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
+	"github.com/connor-davis/threereco-nextgen/internal/registrations"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+)
+
+type RegistrationsRouter struct {
+	storage       storage.Storage
+	middleware    middleware.Middleware
+	registrations registrations.Registrations
+}
+
+func NewRegistrationsRouter(storage storage.Storage, middleware middleware.Middleware, registrations registrations.Registrations) Router {
+	return &RegistrationsRouter{
+		storage:       storage,
+		middleware:    middleware,
+		registrations: registrations,
+	}
+}
+
+func (r *RegistrationsRouter) LoadRoutes() []routing.Route {
+	listRoute := r.ListRoute()
+	approveRoute := r.ApproveRoute()
+	rejectRoute := r.RejectRoute()
+
+	return []routing.Route{
+		listRoute,
+		approveRoute,
+		rejectRoute,
+	}
+}
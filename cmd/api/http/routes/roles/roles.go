@@ -41,10 +41,12 @@ func (r *RolesRouter) LoadRoutes() []routing.Route {
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("roles.create"),
 	)
+	createRoute.Handler = r.validatePermissions(createRoute.Handler)
 	updateRoute := api.UpdateRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("roles.update"),
 	)
+	updateRoute.Handler = r.validatePermissions(updateRoute.Handler)
 	deleteRoute := api.DeleteRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("roles.delete"),
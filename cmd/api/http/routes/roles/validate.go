@@ -0,0 +1,32 @@
+package roles
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/permissions"
+	"github.com/gofiber/fiber/v2"
+)
+
+type rolePermissionsPayload struct {
+	Permissions []string `json:"permissions"`
+}
+
+// validatePermissions rejects role payloads that reference permissions which
+// don't exist in the registry before handing over to the generic handler.
+func (r *RolesRouter) validatePermissions(handler fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		var payload rolePermissionsPayload
+
+		if err := c.BodyParser(&payload); err != nil {
+			return handler(c)
+		}
+
+		if unknown := permissions.Unknown(payload.Permissions); len(unknown) > 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":       "Bad Request",
+				"message":     "The role contains permissions that don't exist.",
+				"permissions": unknown,
+			})
+		}
+
+		return handler(c)
+	}
+}
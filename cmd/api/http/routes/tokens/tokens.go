@@ -0,0 +1,34 @@
+package tokens
+
+import (
+	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/connor-davis/threereco-nextgen/internal/tokens"
+)
+
+type TokensRouter struct {
+	storage    storage.Storage
+	middleware middleware.Middleware
+	tokens     tokens.Tokens
+}
+
+func NewTokensRouter(storage storage.Storage, middleware middleware.Middleware, tokens tokens.Tokens) Router {
+	return &TokensRouter{
+		storage:    storage,
+		middleware: middleware,
+		tokens:     tokens,
+	}
+}
+
+func (r *TokensRouter) LoadRoutes() []routing.Route {
+	listRoute := r.ListRoute()
+	createRoute := r.CreateRoute()
+	revokeRoute := r.RevokeRoute()
+
+	return []routing.Route{
+		listRoute,
+		createRoute,
+		revokeRoute,
+	}
+}
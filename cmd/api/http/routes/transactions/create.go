@@ -0,0 +1,232 @@
+package transactions
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/connor-davis/threereco-nextgen/internal/totals"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *TransactionsRouter) CreateRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Transaction created successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Create Transaction",
+			Description: "Creates a transaction together with its lines in one step. A transaction needs at least one line; the optional totals must match the sum of the lines.",
+			Tags:        []string{"Transactions"},
+			Parameters:  nil,
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/CreateTransactionPayload",
+			},
+			Responses: responses,
+		},
+		Method: routing.POST,
+		Path:   "/transactions",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("transactions.create"),
+			r.middleware.Policies(models.TransactionsPolicy, policies.CreateAction),
+			r.middleware.DefaultBusiness("buyerId"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			var payload models.CreateTransactionPayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			var sellers int64
+
+			if err := r.storage.Database().
+				Model(&models.Business{}).
+				Where("id = ?", payload.SellerId).
+				Count(&sellers).Error; err != nil {
+				log.Errorf("🔥 Error retrieving seller: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if sellers == 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The seller was not found.",
+				})
+			}
+
+			var buyers int64
+
+			if err := r.storage.Database().
+				Model(&models.Business{}).
+				Where("id = ?", payload.BuyerId).
+				Count(&buyers).Error; err != nil {
+				log.Errorf("🔥 Error retrieving buyer: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if buyers == 0 {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The buyer was not found.",
+				})
+			}
+
+			transaction := models.Transaction{
+				SellerId: payload.SellerId,
+				BuyerId:  payload.BuyerId,
+			}
+
+			materialIds := []uuid.UUID{}
+			lines := []totals.Line{}
+
+			for _, linePayload := range payload.Materials {
+				if !slices.Contains(materialIds, linePayload.MaterialId) {
+					materialIds = append(materialIds, linePayload.MaterialId)
+				}
+
+				transaction.Materials = append(transaction.Materials, models.TransactionMaterial{
+					MaterialId: &linePayload.MaterialId,
+					Weight:     linePayload.Weight,
+					Value:      linePayload.Value,
+				})
+
+				lines = append(lines, totals.Line{Weight: linePayload.Weight, Value: linePayload.Value})
+			}
+
+			if _, err := totals.Validate(lines, payload.TotalWeight, payload.TotalValue); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var count int64
+
+			if err := r.storage.Database().
+				Model(&models.Material{}).
+				Where("id IN ?", materialIds).
+				Count(&count).Error; err != nil {
+				log.Errorf("🔥 Error retrieving materials: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if int(count) != len(materialIds) {
+				return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error":   "Not Found",
+					"message": "One or more of the materials were not found.",
+				})
+			}
+
+			scope, _ := c.Locals("policies").(clause.Expression)
+
+			if err := r.storage.Database().Transaction(func(tx *gorm.DB) error {
+				if err := tx.Create(&transaction).Error; err != nil {
+					return err
+				}
+
+				return policies.InScope(tx, &models.Transaction{}, transaction.Id, scope)
+			}); err != nil {
+				if errors.Is(err, policies.ErrOutOfScope) {
+					return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+						"error":   "Forbidden",
+						"message": "You can only create transactions your active business sold or bought.",
+					})
+				}
+
+				log.Errorf("🔥 Error creating transaction: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).SendString(transaction.Id.String())
+		},
+	}
+}
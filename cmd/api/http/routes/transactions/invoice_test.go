@@ -0,0 +1,178 @@
+package transactions
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/connor-davis/threereco-nextgen/internal/carbon"
+	"github.com/connor-davis/threereco-nextgen/internal/documents"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/testdb"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+// stubMiddleware signs every request in as user and allows every permission,
+// while still applying the policy registry.
+type stubMiddleware struct {
+	user *models.User
+}
+
+func (m *stubMiddleware) Authenticated() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals("user", m.user)
+
+		return c.Next()
+	}
+}
+
+func (m *stubMiddleware) Authorized(permissions ...string) fiber.Handler {
+	return next
+}
+
+func (m *stubMiddleware) Policies(entity models.PolicyType, action policies.Action) fiber.Handler {
+	return m.policies(entity, action, false)
+}
+
+func (m *stubMiddleware) LinePolicies(entity models.PolicyType, action policies.Action) fiber.Handler {
+	return m.policies(entity, action, true)
+}
+
+func (m *stubMiddleware) policies(entity models.PolicyType, action policies.Action, lines bool) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		decision := policies.Evaluate(policies.Registry, m.user, entity, action)
+
+		if !decision.Allowed {
+			return c.SendStatus(fiber.StatusForbidden)
+		}
+
+		expression := decision.Expression()
+
+		if lines {
+			expression = policies.Lines(entity, expression)
+		}
+
+		if expression != nil {
+			c.Locals("policies", expression)
+		}
+
+		return c.Next()
+	}
+}
+
+func (m *stubMiddleware) NotImpersonating() fiber.Handler {
+	return next
+}
+
+func (m *stubMiddleware) DefaultBusiness(field string) fiber.Handler {
+	return next
+}
+
+func (m *stubMiddleware) Unlocked(entity models.PolicyType, param string) fiber.Handler {
+	return next
+}
+
+func (m *stubMiddleware) LineUnlocked(entity models.PolicyType, param string) fiber.Handler {
+	return next
+}
+
+func next(c *fiber.Ctx) error {
+	return c.Next()
+}
+
+func TestInvoiceIssuing(t *testing.T) {
+	store := testdb.Open(t)
+
+	owner := testdb.User(t, store, models.BusinessUser)
+	seller := testdb.Business(t, store, owner)
+	buyer := testdb.Business(t, store, owner)
+	material := testdb.Material(t, store)
+
+	transaction := models.Transaction{
+		SellerId: seller.Id,
+		BuyerId:  buyer.Id,
+		Materials: []models.TransactionMaterial{
+			{MaterialId: &material.Id, Weight: 10, Value: decimal.NewFromInt(100)},
+		},
+	}
+
+	if err := store.Database().Create(&transaction).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Database().Table("transactions").Where("id = ?", transaction.Id).Update("status", models.ApprovedStatus).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	middleware := &stubMiddleware{}
+	router := &TransactionsRouter{
+		storage:    store,
+		middleware: middleware,
+		documents:  documents.New(store, carbon.New(store)),
+	}
+
+	app := fiber.New()
+
+	issueRoute := router.IssueInvoiceRoute()
+	invoiceRoute := router.InvoiceRoute()
+
+	app.Post("/transactions/:id/invoice", append(issueRoute.Middlewares, issueRoute.Handler)...)
+	app.Get("/transactions/:id/invoice", append(invoiceRoute.Middlewares, invoiceRoute.Handler)...)
+
+	sellerUser := &models.User{Base: models.Base{Id: owner.Id}, Type: models.BusinessUser, ActiveBusinessId: &seller.Id}
+	buyerUser := &models.User{Base: models.Base{Id: owner.Id}, Type: models.BusinessUser, ActiveBusinessId: &buyer.Id}
+
+	last := func(t *testing.T) int {
+		t.Helper()
+
+		numbers := []int{}
+
+		if err := store.Database().Model(&models.InvoiceSequence{}).Where("business_id = ?", seller.Id).Pluck("last", &numbers).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		if len(numbers) == 0 {
+			return 0
+		}
+
+		return numbers[0]
+	}
+
+	steps := []struct {
+		name   string
+		user   *models.User
+		method string
+		status int
+		last   int
+	}{
+		{name: "downloading before it is issued", user: sellerUser, method: fiber.MethodGet, status: fiber.StatusNotFound},
+		{name: "the buyer issuing", user: buyerUser, method: fiber.MethodPost, status: fiber.StatusForbidden},
+		{name: "the seller issuing", user: sellerUser, method: fiber.MethodPost, status: fiber.StatusOK, last: 1},
+		{name: "the buyer downloading", user: buyerUser, method: fiber.MethodGet, status: fiber.StatusOK, last: 1},
+		{name: "the seller downloading", user: sellerUser, method: fiber.MethodGet, status: fiber.StatusOK, last: 1},
+		{name: "the seller downloading again", user: sellerUser, method: fiber.MethodGet, status: fiber.StatusOK, last: 1},
+		{name: "the seller issuing again", user: sellerUser, method: fiber.MethodPost, status: fiber.StatusOK, last: 1},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			middleware.user = step.user
+
+			response, err := app.Test(httptest.NewRequest(step.method, fmt.Sprintf("/transactions/%s/invoice", transaction.Id), nil))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if response.StatusCode != step.status {
+				t.Errorf("got status %d, want %d", response.StatusCode, step.status)
+			}
+
+			if got := last(t); got != step.last {
+				t.Errorf("got invoice number %d, want %d", got, step.last)
+			}
+		})
+	}
+}
@@ -0,0 +1,550 @@
+package transactions
+
+import (
+	"errors"
+	"math"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/connor-davis/threereco-nextgen/internal/totals"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TransactionLinesParams struct {
+	TransactionId uuid.UUID `param:"transactionId"`
+}
+
+func (r *TransactionsRouter) CreateLineRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Transaction line created successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Create Transaction Line",
+			Description: "Adds a line for a catalogue material to a transaction. The line's name, GW code and carbon factor are copied from the material, and the transaction's VAT and totals are refreshed.",
+			Tags:        []string{"Transactions"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("transactionId").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Ref: "#/components/requestBodies/CreateTransactionLinePayload",
+			},
+			Responses: responses,
+		},
+		Method: routing.POST,
+		Path:   "/transactions/{transactionId}/materials",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("transactions.materials.create"),
+			r.middleware.Policies(models.TransactionsPolicy, policies.UpdateAction),
+			r.middleware.Unlocked(models.TransactionsPolicy, "transactionId"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			var params TransactionLinesParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var payload models.CreateTransactionLinePayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			if math.IsNaN(payload.Weight) || payload.Weight <= 0 || payload.Value.IsNegative() || !payload.Value.Equal(models.Cents(payload.Value)) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The weight must be more than zero and the value must be an amount of at least zero, to the cent.",
+				})
+			}
+
+			query := r.storage.Database().Model(&models.Transaction{})
+
+			if scope, ok := c.Locals("policies").(clause.Expression); ok && scope != nil {
+				query = query.Clauses(scope)
+			}
+
+			var transaction models.Transaction
+
+			if err := query.Where("id = ?", params.TransactionId).First(&transaction).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The transaction was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving transaction: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			line := models.TransactionMaterial{
+				TransactionId: transaction.Id,
+				MaterialId:    &payload.MaterialId,
+				Weight:        payload.Weight,
+				Value:         payload.Value,
+			}
+
+			// Creating the line snapshots its material and refreshes the
+			// transaction's VAT and totals.
+			if err := r.storage.Database().Create(&line).Error; err != nil {
+				if errors.Is(err, models.ErrUnknownMaterial) {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The material was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error creating transaction line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": line,
+			})
+		},
+	}
+}
+
+type TransactionLineParams struct {
+	Id uuid.UUID `param:"id"`
+}
+
+func (r *TransactionMaterialsRouter) UpdateLineRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Transaction line updated successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Update Transaction Material",
+			Description: "Replaces the material, weight and value of a line of a transaction the user may update, and refreshes the transaction's VAT and totals.",
+			Tags:        []string{"Transaction Materials"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("id").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: &openapi3.RequestBodyRef{
+				Value: openapi3.NewRequestBody().
+					WithRequired(true).
+					WithJSONSchemaRef(&openapi3.SchemaRef{
+						Ref: "#/components/schemas/UpdateTransactionMaterial",
+					}).
+					WithDescription("Payload to update an existing transaction material."),
+			},
+			Responses: responses,
+		},
+		Method: routing.PUT,
+		Path:   "/transactions/materials/{id}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("transactions.materials.update"),
+			r.middleware.LinePolicies(models.TransactionsPolicy, policies.UpdateAction),
+			r.middleware.LineUnlocked(models.TransactionsPolicy, "id"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			var params TransactionLineParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var payload models.UpdateTransactionLinePayload
+
+			if err := c.BodyParser(&payload); err != nil {
+				log.Errorf("🔥 Error parsing request body: %s", err.Error())
+
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The request body is invalid.",
+				})
+			}
+
+			if math.IsNaN(payload.Weight) || payload.Weight <= 0 || payload.Value.IsNegative() || !payload.Value.Equal(models.Cents(payload.Value)) {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": "The weight must be more than zero and the value must be an amount of at least zero, to the cent.",
+				})
+			}
+
+			query := r.storage.Database().Model(&models.TransactionMaterial{})
+
+			if scope, ok := c.Locals("policies").(clause.Expression); ok && scope != nil {
+				query = query.Clauses(scope)
+			}
+
+			var line models.TransactionMaterial
+
+			if err := query.Where("id = ?", params.Id).First(&line).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The transaction material was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving transaction line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			line.MaterialId = &payload.MaterialId
+			line.Weight = payload.Weight
+			line.Value = payload.Value
+
+			if err := line.SnapshotMaterial(r.storage.Database()); err != nil {
+				if errors.Is(err, models.ErrUnknownMaterial) {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The material was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving material: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			// Saving the line refreshes the transaction's VAT and totals.
+			if err := r.storage.Database().Omit(clause.Associations).Save(&line).Error; err != nil {
+				log.Errorf("🔥 Error updating transaction line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": line,
+			})
+		},
+	}
+}
+
+func (r *TransactionMaterialsRouter) DeleteLineRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Transaction line deleted successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Delete Transaction Line",
+			Description: "Deletes a line from a transaction. A transaction needs at least one line, so its last line can't be deleted.",
+			Tags:        []string{"Transactions"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("id").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.DELETE,
+		Path:   "/transactions/materials/{id}",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("transactions.materials.delete"),
+			r.middleware.LinePolicies(models.TransactionsPolicy, policies.UpdateAction),
+			r.middleware.LineUnlocked(models.TransactionsPolicy, "id"),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			var params TransactionLineParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			query := r.storage.Database().Model(&models.TransactionMaterial{})
+
+			if scope, ok := c.Locals("policies").(clause.Expression); ok && scope != nil {
+				query = query.Clauses(scope)
+			}
+
+			var line models.TransactionMaterial
+
+			if err := query.Where("id = ?", params.Id).First(&line).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The transaction material was not found.",
+					})
+				}
+
+				log.Errorf("🔥 Error retrieving transaction line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			if err := r.storage.Database().Transaction(func(tx *gorm.DB) error {
+				// The transaction is locked so two deletes can't both remove
+				// what they each think is the second to last line.
+				if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+					Where("id = ?", line.TransactionId).
+					First(&models.Transaction{}).Error; err != nil {
+					return err
+				}
+
+				var lines int64
+
+				if err := tx.Model(&models.TransactionMaterial{}).
+					Where("transaction_id = ?", line.TransactionId).
+					Count(&lines).Error; err != nil {
+					return err
+				}
+
+				if lines <= 1 {
+					return totals.ErrNoLines
+				}
+
+				return tx.Delete(&line).Error
+			}); err != nil {
+				if errors.Is(err, totals.ErrNoLines) {
+					return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": "A transaction needs at least one line, so its last line can't be deleted.",
+					})
+				}
+
+				log.Errorf("🔥 Error deleting transaction line: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).SendString("OK")
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package transactions
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/testdb"
+	"github.com/gofiber/fiber/v2"
+	"github.com/shopspring/decimal"
+)
+
+func TestDeleteLastLine(t *testing.T) {
+	store := testdb.Open(t)
+
+	owner := testdb.User(t, store, models.BusinessUser)
+	seller := testdb.Business(t, store, owner)
+	buyer := testdb.Business(t, store, owner)
+	material := testdb.Material(t, store)
+
+	transaction := models.Transaction{
+		SellerId: seller.Id,
+		BuyerId:  buyer.Id,
+		Materials: []models.TransactionMaterial{
+			{MaterialId: &material.Id, Weight: 10, Value: decimal.NewFromInt(100)},
+			{MaterialId: &material.Id, Weight: 5, Value: decimal.NewFromInt(50)},
+		},
+	}
+
+	if err := store.Database().Create(&transaction).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	router := &TransactionMaterialsRouter{
+		storage:    store,
+		middleware: &stubMiddleware{user: &models.User{Base: models.Base{Id: owner.Id}, Type: models.BusinessUser, ActiveBusinessId: &seller.Id}},
+	}
+
+	app := fiber.New()
+
+	deleteRoute := router.DeleteLineRoute()
+
+	app.Delete("/transactions/materials/:id", append(deleteRoute.Middlewares, deleteRoute.Handler)...)
+
+	steps := []struct {
+		name   string
+		line   models.TransactionMaterial
+		status int
+	}{
+		{name: "deleting one of two lines", line: transaction.Materials[0], status: fiber.StatusOK},
+		{name: "deleting the last line", line: transaction.Materials[1], status: fiber.StatusBadRequest},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			response, err := app.Test(httptest.NewRequest(fiber.MethodDelete, fmt.Sprintf("/transactions/materials/%s", step.line.Id), nil))
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if response.StatusCode != step.status {
+				t.Errorf("got status %d, want %d", response.StatusCode, step.status)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
 	"github.com/connor-davis/threereco-nextgen/internal/api"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
 )
@@ -32,28 +33,23 @@ func (r *TransactionMaterialsRouter) LoadRoutes() []routing.Route {
 	getAllRoute := api.GetAllRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("transactions.materials.view"),
+		r.middleware.LinePolicies(models.TransactionsPolicy, policies.ViewAction),
 	)
 	getOneRoute := api.GetOneRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("transactions.materials.view"),
+		r.middleware.LinePolicies(models.TransactionsPolicy, policies.ViewAction),
 	)
-	createRoute := api.CreateRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("transactions.materials.create"),
-	)
-	updateRoute := api.UpdateRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("transactions.materials.update"),
-	)
-	deleteRoute := api.DeleteRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("transactions.materials.delete"),
-	)
+	updateRoute := r.UpdateLineRoute()
+	deleteRoute := r.DeleteLineRoute()
+
+	// The unmatched route goes first so that its path isn't taken for an id.
+	unmatchedRoute := r.UnmatchedRoute()
 
 	return []routing.Route{
+		unmatchedRoute,
 		getAllRoute,
 		getOneRoute,
-		createRoute,
 		updateRoute,
 		deleteRoute,
 	}
@@ -1,9 +1,16 @@
 package transactions
 
 import (
+	"fmt"
+
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
 	"github.com/connor-davis/threereco-nextgen/internal/api"
+	"github.com/connor-davis/threereco-nextgen/internal/custody"
+	"github.com/connor-davis/threereco-nextgen/internal/documents"
+	"github.com/connor-davis/threereco-nextgen/internal/inventory"
+	"github.com/connor-davis/threereco-nextgen/internal/lifecycle"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
 )
@@ -11,35 +18,43 @@ import (
 type TransactionsRouter struct {
 	storage    storage.Storage
 	middleware middleware.Middleware
+	lifecycle  lifecycle.Lifecycle
+	inventory  inventory.Inventory
+	custody    custody.Custody
+	documents  documents.Documents
 }
 
-func NewTransactionsRouter(storage storage.Storage, middleware middleware.Middleware) Router {
+func NewTransactionsRouter(storage storage.Storage, middleware middleware.Middleware, lifecycle lifecycle.Lifecycle, inventory inventory.Inventory, custody custody.Custody, documents documents.Documents) Router {
 	return &TransactionsRouter{
 		storage:    storage,
 		middleware: middleware,
+		lifecycle:  lifecycle,
+		inventory:  inventory,
+		custody:    custody,
+		documents:  documents,
 	}
 }
 
 func (r *TransactionsRouter) LoadRoutes() []routing.Route {
-	assignMaterialsApi := api.NewAssignmentApi[models.Transaction, models.TransactionMaterial](
+	materialsApi := api.NewAssignmentApi[models.Transaction, models.TransactionMaterial](
 		r.storage,
 		"/transactions",
 		"Transaction",
 		"Material",
 	)
 
-	assignMaterialRoute := assignMaterialsApi.AssignRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("transactions.materials.assign"),
-	)
-	unassignMaterialRoute := assignMaterialsApi.UnassignRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("transactions.materials.unassign"),
-	)
-	listMaterialsRoute := assignMaterialsApi.ListRoute(
+	listMaterialsRoute := materialsApi.ListRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("transactions.materials.view"),
-		r.middleware.Policies(models.TransactionsPolicy, models.SystemPolicy),
+		r.middleware.Policies(models.TransactionsPolicy, policies.ViewAction),
+	)
+
+	lifecycleApi := api.NewLifecycleApi(
+		r.storage,
+		r.lifecycle,
+		models.TransactionsPolicy,
+		"/transactions",
+		"Transaction",
 	)
 
 	api := api.NewBaseApi[models.Transaction](
@@ -53,34 +68,65 @@ func (r *TransactionsRouter) LoadRoutes() []routing.Route {
 	getAllRoute := api.GetAllRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("transactions.view"),
-		r.middleware.Policies(models.TransactionsPolicy, models.SystemPolicy),
+		r.middleware.Policies(models.TransactionsPolicy, policies.ViewAction),
 	)
+	getAllRoute = lifecycleApi.StatusFilter(getAllRoute)
 	getOneRoute := api.GetOneRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("transactions.view"),
-		r.middleware.Policies(models.TransactionsPolicy, models.SystemPolicy),
-	)
-	createRoute := api.CreateRoute(
-		r.middleware.Authenticated(),
-		r.middleware.Authorized("transactions.create"),
+		r.middleware.Policies(models.TransactionsPolicy, policies.ViewAction),
 	)
+	createRoute := r.CreateRoute()
+	createLineRoute := r.CreateLineRoute()
 	updateRoute := api.UpdateRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("transactions.update"),
+		r.middleware.Policies(models.TransactionsPolicy, policies.UpdateAction),
+		r.middleware.Unlocked(models.TransactionsPolicy, "id"),
 	)
 	deleteRoute := api.DeleteRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("transactions.delete"),
+		r.middleware.Policies(models.TransactionsPolicy, policies.DeleteAction),
+		r.middleware.Unlocked(models.TransactionsPolicy, "id"),
+	)
+
+	historyRoute := lifecycleApi.HistoryRoute(
+		r.middleware.Authenticated(),
+		r.middleware.Authorized("transactions.view"),
+		r.middleware.Policies(models.TransactionsPolicy, policies.ViewAction),
 	)
 
-	return []routing.Route{
-		assignMaterialRoute,
-		unassignMaterialRoute,
+	stockCheckRoute := r.StockCheckRoute()
+	lineageRoute := r.LineageRoute()
+	issueInvoiceRoute := r.IssueInvoiceRoute()
+	invoiceRoute := r.InvoiceRoute()
+
+	transitionRoutes := []routing.Route{}
+
+	for _, transition := range lifecycle.Transitions {
+		transitionRoutes = append(transitionRoutes, lifecycleApi.TransitionRoute(
+			transition,
+			r.middleware.Authenticated(),
+			r.middleware.Authorized(fmt.Sprintf("transactions.status.%s", transition.Name)),
+			r.middleware.Policies(models.TransactionsPolicy, policies.TransitionAction(transition.Name)),
+		))
+	}
+
+	routes := []routing.Route{
 		listMaterialsRoute,
 		getAllRoute,
 		getOneRoute,
 		createRoute,
+		createLineRoute,
 		updateRoute,
 		deleteRoute,
+		historyRoute,
+		stockCheckRoute,
+		lineageRoute,
+		issueInvoiceRoute,
+		invoiceRoute,
 	}
+
+	return append(routes, transitionRoutes...)
 }
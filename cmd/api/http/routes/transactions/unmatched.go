@@ -0,0 +1,101 @@
+package transactions
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm/clause"
+)
+
+func (r *TransactionMaterialsRouter) UnmatchedRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Unmatched transaction lines retrieved successfully.").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Get Unmatched Transaction Lines",
+			Description: "Retrieves the transaction lines that aren't linked to a catalogue material because their GW code matched no material when lines were linked to the catalogue. Link them by updating their materialId.",
+			Tags:        []string{"Transaction Materials"},
+			Parameters:  nil,
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.GET,
+		Path:   "/transactions/materials/unmatched",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("transactions.materials.view"),
+			r.middleware.Policies(models.TransactionsPolicy, policies.ViewAction),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			query := r.storage.Database().
+				Table("transaction_materials").
+				Select("transaction_materials.id, transaction_materials.transaction_id AS owner_id, transaction_materials.name, transaction_materials.gw_code, transaction_materials.weight, transaction_materials.value, transaction_materials.created_at").
+				Joins("JOIN transactions ON transactions.id = transaction_materials.transaction_id").
+				Where("transaction_materials.material_id IS NULL")
+
+			if scope, ok := c.Locals("policies").(clause.Expression); ok && scope != nil {
+				query = query.Clauses(scope)
+			}
+
+			lines := []models.UnmatchedLine{}
+
+			if err := query.
+				Order("transaction_materials.gw_code ASC, transaction_materials.created_at ASC").
+				Scan(&lines).Error; err != nil {
+				log.Errorf("🔥 Error retrieving unmatched transaction lines: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items": lines,
+			})
+		},
+	}
+}
@@ -0,0 +1,209 @@
+package users
+
+import (
+	"slices"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RevokeSessionsParams struct {
+	Id uuid.UUID `param:"id"`
+}
+
+func (r *UsersRouter) RevokeSessionsRoute() routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription("Sessions revoked successfully.").
+			WithContent(openapi3.Content{
+				"text/plain": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     "Revoke User Sessions",
+			Description: "Signs a user out of every session they have open.",
+			Tags:        []string{"Users"},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("id").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method: routing.DELETE,
+		Path:   "/users/{id}/sessions",
+		Middlewares: []fiber.Handler{
+			r.middleware.Authenticated(),
+			r.middleware.Authorized("users.sessions.revoke"),
+			r.middleware.NotImpersonating(),
+		},
+		Handler: func(c *fiber.Ctx) error {
+			var params RevokeSessionsParams
+
+			if err := c.ParamsParser(&params); err != nil {
+				return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var user models.User
+
+			if err := r.storage.Database().Where("id = ?", params.Id).First(&user).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": "The user was not found.",
+					})
+				}
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			if err := r.sessions.RevokeAll(user.Id); err != nil {
+				log.Errorf("🔥 Error revoking sessions: %s", err.Error())
+
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": "An error occurred while processing your request.",
+				})
+			}
+
+			return c.SendStatus(fiber.StatusOK)
+		},
+	}
+}
+
+// revokeSessionsOnRoleRemoval wraps a handler that may change a user's roles
+// and signs the user out everywhere when any role was taken away, so that the
+// removed permissions can't keep being used from an existing session.
+func (r *UsersRouter) revokeSessionsOnRoleRemoval(handler fiber.Handler, param string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		userId, err := uuid.Parse(c.Params(param))
+
+		if err != nil {
+			return handler(c)
+		}
+
+		before, err := r.roleIds(userId)
+
+		if err != nil {
+			log.Errorf("🔥 Error retrieving user roles: %s", err.Error())
+
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error":   "Internal Server Error",
+				"message": "An error occurred while processing your request.",
+			})
+		}
+
+		if err := handler(c); err != nil {
+			return err
+		}
+
+		if c.Response().StatusCode() != fiber.StatusOK {
+			return nil
+		}
+
+		after, err := r.roleIds(userId)
+
+		if err != nil {
+			log.Errorf("🔥 Error retrieving user roles: %s", err.Error())
+
+			return nil
+		}
+
+		for _, roleId := range before {
+			if slices.Contains(after, roleId) {
+				continue
+			}
+
+			if err := r.sessions.RevokeAll(userId); err != nil {
+				log.Errorf("🔥 Error revoking sessions: %s", err.Error())
+			}
+
+			break
+		}
+
+		return nil
+	}
+}
+
+func (r *UsersRouter) roleIds(userId uuid.UUID) ([]uuid.UUID, error) {
+	var roleIds []uuid.UUID
+
+	if err := r.storage.Database().
+		Table("users_roles").
+		Where("user_id = ?", userId).
+		Pluck("role_id", &roleIds).Error; err != nil {
+		return nil, err
+	}
+
+	return roleIds, nil
+}
@@ -3,20 +3,35 @@ package users
 import (
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
 	"github.com/connor-davis/threereco-nextgen/internal/api"
+	"github.com/connor-davis/threereco-nextgen/internal/audit"
+	"github.com/connor-davis/threereco-nextgen/internal/impersonation"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/passwords"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/gofiber/fiber/v2/middleware/session"
 )
 
 type UsersRouter struct {
-	storage    storage.Storage
-	middleware middleware.Middleware
+	storage       storage.Storage
+	middleware    middleware.Middleware
+	passwords     passwords.Passwords
+	sessions      sessions.Manager
+	session       *session.Store
+	impersonation impersonation.Impersonation
+	audit         audit.Audit
 }
 
-func NewUsersRouter(storage storage.Storage, middleware middleware.Middleware) Router {
+func NewUsersRouter(storage storage.Storage, middleware middleware.Middleware, passwords passwords.Passwords, sessions sessions.Manager, session *session.Store, impersonation impersonation.Impersonation, audit audit.Audit) Router {
 	return &UsersRouter{
-		storage:    storage,
-		middleware: middleware,
+		storage:       storage,
+		middleware:    middleware,
+		passwords:     passwords,
+		sessions:      sessions,
+		session:       session,
+		impersonation: impersonation,
+		audit:         audit,
 	}
 }
 
@@ -36,6 +51,7 @@ func (r *UsersRouter) LoadRoutes() []routing.Route {
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("users.roles.unassign"),
 	)
+	unassignRoleRoute.Handler = r.revokeSessionsOnRoleRemoval(unassignRoleRoute.Handler, "userId")
 	listRolesRoute := assignRoleApi.ListRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("users.roles.view"),
@@ -65,10 +81,15 @@ func (r *UsersRouter) LoadRoutes() []routing.Route {
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("users.update.any", "users.update.self"),
 	)
+	updateRoute.Handler = r.revokeSessionsOnRoleRemoval(updateRoute.Handler, "id")
 	deleteRoute := api.DeleteRoute(
 		r.middleware.Authenticated(),
 		r.middleware.Authorized("users.delete.any", "users.delete.self"),
+		r.middleware.NotImpersonating(),
 	)
+	setPasswordRoute := r.SetPasswordRoute()
+	revokeSessionsRoute := r.RevokeSessionsRoute()
+	impersonateRoute := r.ImpersonateRoute()
 
 	return []routing.Route{
 		assignRoleRoute,
@@ -79,5 +100,8 @@ func (r *UsersRouter) LoadRoutes() []routing.Route {
 		createRoute,
 		updateRoute,
 		deleteRoute,
+		setPasswordRoute,
+		revokeSessionsRoute,
+		impersonateRoute,
 	}
 }
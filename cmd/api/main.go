@@ -9,8 +9,25 @@ import (
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http"
 	"github.com/connor-davis/threereco-nextgen/cmd/api/http/middleware"
 	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/audit"
+	"github.com/connor-davis/threereco-nextgen/internal/carbon"
+	"github.com/connor-davis/threereco-nextgen/internal/custody"
+	"github.com/connor-davis/threereco-nextgen/internal/documents"
+	"github.com/connor-davis/threereco-nextgen/internal/impersonation"
+	"github.com/connor-davis/threereco-nextgen/internal/inventory"
+	"github.com/connor-davis/threereco-nextgen/internal/invitations"
+	"github.com/connor-davis/threereco-nextgen/internal/lifecycle"
+	"github.com/connor-davis/threereco-nextgen/internal/lockouts"
+	"github.com/connor-davis/threereco-nextgen/internal/notifications"
+	"github.com/connor-davis/threereco-nextgen/internal/passwords"
+	"github.com/connor-davis/threereco-nextgen/internal/payouts"
+	"github.com/connor-davis/threereco-nextgen/internal/pricing"
+	"github.com/connor-davis/threereco-nextgen/internal/principals"
+	"github.com/connor-davis/threereco-nextgen/internal/registrations"
 	"github.com/connor-davis/threereco-nextgen/internal/sessions"
+	"github.com/connor-davis/threereco-nextgen/internal/sso"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/connor-davis/threereco-nextgen/internal/tokens"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
@@ -37,8 +54,37 @@ func main() {
 		log.Fatalf("🔥 Failed to seed default business: %v", err)
 	}
 
-	session := sessions.New()
-	middleware := middleware.New(storage, session)
+	sessionConfig := sessions.NewConfig()
+	session := sessions.New(sessionConfig)
+	tokens := tokens.New(storage)
+	sessionManager := sessions.NewManager(storage, session, sessionConfig)
+	impersonation := impersonation.New(storage)
+	audit := audit.New(storage)
+	principals := principals.NewMemory(storage)
+	inventory := inventory.New(storage)
+	lifecycle := lifecycle.New(storage, inventory)
+	middleware := middleware.New(storage, session, tokens, sessionManager, impersonation, audit, principals, lifecycle)
+	passwords, err := passwords.New(storage)
+
+	if err != nil {
+		log.Fatalf("🔥 Failed to set up passwords: %v", err)
+	}
+
+	lockouts := lockouts.New(storage)
+	sso := sso.New(storage, sessionConfig)
+	notifications := notifications.New()
+	invitations := invitations.New(storage, notifications)
+	registrations, err := registrations.New(storage, notifications)
+
+	if err != nil {
+		log.Fatalf("🔥 Failed to set up registrations: %v", err)
+	}
+
+	carbon := carbon.New(storage)
+	pricing := pricing.New(storage)
+	custody := custody.New(storage)
+	payouts := payouts.New(storage, lifecycle)
+	documents := documents.New(storage, carbon)
 
 	app := fiber.New(fiber.Config{
 		AppName:       common.EnvString("APP_NAME", "Dynamic CRUD API"),
@@ -57,6 +103,7 @@ func main() {
 		),
 		AllowMethods:     "GET,POST,PUT,PATCH,DELETE,OPTIONS",
 		AllowCredentials: true,
+		ExposeHeaders:    "X-Impersonated-By,X-Impersonation-Id",
 	}))
 
 	app.Use(logger.New(logger.Config{
@@ -67,7 +114,7 @@ func main() {
 
 	api := app.Group("/api")
 
-	httpRouter := http.NewHttpRouter(storage, middleware, session)
+	httpRouter := http.NewHttpRouter(storage, middleware, session, passwords, lockouts, tokens, sessionManager, sso, invitations, registrations, impersonation, audit, carbon, pricing, lifecycle, inventory, custody, payouts, documents)
 	httpRouter.InitializeRoutes(api)
 
 	openapi := httpRouter.InitializeOpenAPI()
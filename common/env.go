@@ -2,6 +2,8 @@ package common
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	_ "github.com/joho/godotenv/autoload"
 )
@@ -13,3 +15,45 @@ func EnvString(key, fallback string) string {
 
 	return fallback
 }
+
+func EnvInt(key string, fallback int) int {
+	if value, exists := os.LookupEnv(key); exists {
+		parsed, err := strconv.Atoi(value)
+
+		if err != nil {
+			return fallback
+		}
+
+		return parsed
+	}
+
+	return fallback
+}
+
+func EnvBool(key string, fallback bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		parsed, err := strconv.ParseBool(value)
+
+		if err != nil {
+			return fallback
+		}
+
+		return parsed
+	}
+
+	return fallback
+}
+
+func EnvDuration(key string, fallback time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		parsed, err := time.ParseDuration(value)
+
+		if err != nil {
+			return fallback
+		}
+
+		return parsed
+	}
+
+	return fallback
+}
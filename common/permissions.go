@@ -94,7 +94,7 @@ var Permissions = []models.PermissionGroup{
 					{
 						Label:       "Create Collection Material",
 						Value:       "collections.materials.create",
-						Description: "Allows the user to create new collection materials.",
+						Description: "Allows the user to add lines to collections.",
 					},
 					{
 						Label:       "Update Collection Material",
@@ -106,15 +106,65 @@ var Permissions = []models.PermissionGroup{
 						Value:       "collections.materials.delete",
 						Description: "Allows the user to delete existing collection materials.",
 					},
+				},
+			},
+			{
+				Name: "Collection Status",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Collection Statuses",
+						Value:       "collections.status.*",
+						Description: "Allows the user to take any status transition on collections.",
+					},
+					{
+						Label:       "Submit Collection",
+						Value:       "collections.status.submit",
+						Description: "Allows the user to submit draft collections for weighing.",
+					},
+					{
+						Label:       "Weigh Collection",
+						Value:       "collections.status.weigh",
+						Description: "Allows the user to confirm the weights of submitted collections.",
+					},
+					{
+						Label:       "Approve Collection",
+						Value:       "collections.status.approve",
+						Description: "Allows the user to approve weighed collections, locking their lines.",
+					},
+					{
+						Label:       "Pay Collection",
+						Value:       "collections.status.pay",
+						Description: "Allows the user to mark approved collections as paid.",
+					},
+					{
+						Label:       "Dispute Collection",
+						Value:       "collections.status.dispute",
+						Description: "Allows the user to dispute collections with a reason.",
+					},
 					{
-						Label:       "Assign Materials to Collection",
-						Value:       "collections.materials.assign",
-						Description: "Allows the user to assign materials to collections.",
+						Label:       "Reopen Collection",
+						Value:       "collections.status.reopen",
+						Description: "Allows the user to send disputed collections back to be weighed again.",
 					},
 					{
-						Label:       "Unassign Materials from Collection",
-						Value:       "collections.materials.unassign",
-						Description: "Allows the user to unassign materials from collections.",
+						Label:       "Void Collection",
+						Value:       "collections.status.void",
+						Description: "Allows the user to void unpaid collections with a reason.",
+					},
+				},
+			},
+			{
+				Name: "Collection Receipts",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Collection Receipts",
+						Value:       "collections.receipts.*",
+						Description: "Allows the user to perform any action on collection receipts.",
+					},
+					{
+						Label:       "View Collection Receipts",
+						Value:       "collections.receipts.view",
+						Description: "Allows the user to download PDF receipts for collections.",
 					},
 				},
 			},
@@ -188,20 +238,110 @@ var Permissions = []models.PermissionGroup{
 						Value:       "transactions.materials.delete",
 						Description: "Allows the user to delete existing transaction materials.",
 					},
+				},
+			},
+			{
+				Name: "Transaction Status",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Transaction Statuses",
+						Value:       "transactions.status.*",
+						Description: "Allows the user to take any status transition on transactions.",
+					},
+					{
+						Label:       "Submit Transaction",
+						Value:       "transactions.status.submit",
+						Description: "Allows the user to submit draft transactions for weighing.",
+					},
+					{
+						Label:       "Weigh Transaction",
+						Value:       "transactions.status.weigh",
+						Description: "Allows the user to confirm the weights of submitted transactions.",
+					},
+					{
+						Label:       "Approve Transaction",
+						Value:       "transactions.status.approve",
+						Description: "Allows the user to approve weighed transactions, locking their lines.",
+					},
+					{
+						Label:       "Pay Transaction",
+						Value:       "transactions.status.pay",
+						Description: "Allows the user to mark approved transactions as paid.",
+					},
+					{
+						Label:       "Dispute Transaction",
+						Value:       "transactions.status.dispute",
+						Description: "Allows the user to dispute transactions with a reason.",
+					},
 					{
-						Label:       "Assign Materials to Transaction",
-						Value:       "transactions.materials.assign",
-						Description: "Allows the user to assign materials to transactions.",
+						Label:       "Reopen Transaction",
+						Value:       "transactions.status.reopen",
+						Description: "Allows the user to send disputed transactions back to be weighed again.",
 					},
 					{
-						Label:       "Unassign Materials from Transaction",
-						Value:       "transactions.materials.unassign",
-						Description: "Allows the user to unassign materials from transactions.",
+						Label:       "Void Transaction",
+						Value:       "transactions.status.void",
+						Description: "Allows the user to void unpaid transactions with a reason.",
+					},
+				},
+			},
+			{
+				Name: "Transaction Lineage",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Transaction Lineage",
+						Value:       "transactions.lineage.*",
+						Description: "Allows the user to perform any action on transaction lineage.",
+					},
+					{
+						Label:       "View Transaction Lineage",
+						Value:       "transactions.lineage.view",
+						Description: "Allows the user to trace the material of transactions back to the collections and collectors it came from.",
+					},
+				},
+			},
+			{
+				Name: "Transaction Invoices",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Transaction Invoices",
+						Value:       "transactions.invoices.*",
+						Description: "Allows the user to perform any action on transaction invoices.",
+					},
+					{
+						Label:       "View Transaction Invoices",
+						Value:       "transactions.invoices.view",
+						Description: "Allows the user to download the PDF invoices issued for transactions.",
+					},
+					{
+						Label:       "Issue Transaction Invoices",
+						Value:       "transactions.invoices.issue",
+						Description: "Allows the user to issue invoices, with the next invoice number, for the transactions their business sold.",
 					},
 				},
 			},
 		},
 	},
+	{
+		Name: "Carbon",
+		Permissions: []models.Permission{
+			{
+				Label:       "All Carbon",
+				Value:       "carbon.*",
+				Description: "Allows the user to perform any action on carbon savings.",
+			},
+			{
+				Label:       "Access Carbon",
+				Value:       "carbon.access",
+				Description: "Allows the user to access the carbon module.",
+			},
+			{
+				Label:       "View Carbon Savings",
+				Value:       "carbon.view",
+				Description: "Allows the user to view the CO2e avoided by the collections and transactions they can see.",
+			},
+		},
+	},
 	{
 		Name: "Users",
 		Permissions: []models.Permission{
@@ -220,6 +360,11 @@ var Permissions = []models.PermissionGroup{
 				Value:       "users.view",
 				Description: "Allows the user to view a user.",
 			},
+			{
+				Label:       "View Self",
+				Value:       "users.view.self",
+				Description: "Allows the user to view their own user details.",
+			},
 			{
 				Label:       "Create User",
 				Value:       "users.create",
@@ -250,6 +395,43 @@ var Permissions = []models.PermissionGroup{
 				Value:       "users.mfa.manage",
 				Description: "Allows the user to manage multi-factor authentication settings.",
 			},
+			{
+				Label:       "Revoke User Sessions",
+				Value:       "users.sessions.revoke",
+				Description: "Allows the user to sign another user out of all of their sessions.",
+			},
+			{
+				Label:       "Impersonate Users",
+				Value:       "users.impersonate",
+				Description: "Allows the user to sign in as collector and business users to troubleshoot their accounts.",
+			},
+		},
+		SubGroups: []models.PermissionGroup{
+			{
+				Name: "User Roles",
+				Permissions: []models.Permission{
+					{
+						Label:       "All User Roles",
+						Value:       "users.roles.*",
+						Description: "Allows the user to perform any action on the global roles of users.",
+					},
+					{
+						Label:       "View User Roles",
+						Value:       "users.roles.view",
+						Description: "Allows the user to view the global roles assigned to a user.",
+					},
+					{
+						Label:       "Assign User Role",
+						Value:       "users.roles.assign",
+						Description: "Allows the user to assign global roles to users.",
+					},
+					{
+						Label:       "Unassign User Role",
+						Value:       "users.roles.unassign",
+						Description: "Allows the user to remove global roles from users.",
+					},
+				},
+			},
 		},
 	},
 	{
@@ -280,11 +462,21 @@ var Permissions = []models.PermissionGroup{
 				Value:       "businesses.update",
 				Description: "Allows the user to update a business.",
 			},
+			{
+				Label:       "Update Own Business",
+				Value:       "businesses.update.self",
+				Description: "Allows the user to update the business they own.",
+			},
 			{
 				Label:       "Delete Business",
 				Value:       "businesses.delete",
 				Description: "Allows the user to delete a business.",
 			},
+			{
+				Label:       "Delete Own Business",
+				Value:       "businesses.delete.self",
+				Description: "Allows the user to delete the business they own.",
+			},
 		},
 		SubGroups: []models.PermissionGroup{
 			{
@@ -317,6 +509,71 @@ var Permissions = []models.PermissionGroup{
 					},
 				},
 			},
+			{
+				Name: "Business Service Accounts",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Business Service Accounts",
+						Value:       "businesses.serviceaccounts.*",
+						Description: "Allows the user to perform any action on business service accounts.",
+					},
+					{
+						Label:       "Access Business Service Accounts",
+						Value:       "businesses.serviceaccounts.access",
+						Description: "Allows the user to access the business service accounts module.",
+					},
+					{
+						Label:       "View Business Service Accounts",
+						Value:       "businesses.serviceaccounts.view",
+						Description: "Allows the user to view business service accounts and their API tokens.",
+					},
+					{
+						Label:       "Create Business Service Account",
+						Value:       "businesses.serviceaccounts.create",
+						Description: "Allows the user to create business service accounts.",
+					},
+					{
+						Label:       "Delete Business Service Account",
+						Value:       "businesses.serviceaccounts.delete",
+						Description: "Allows the user to delete business service accounts.",
+					},
+					{
+						Label:       "Manage Business Service Account Tokens",
+						Value:       "businesses.serviceaccounts.tokens",
+						Description: "Allows the user to issue and revoke API tokens for business service accounts.",
+					},
+				},
+			},
+			{
+				Name: "Business Invitations",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Business Invitations",
+						Value:       "businesses.invitations.*",
+						Description: "Allows the user to perform any action on business invitations.",
+					},
+					{
+						Label:       "Access Business Invitations",
+						Value:       "businesses.invitations.access",
+						Description: "Allows the user to access the business invitations module.",
+					},
+					{
+						Label:       "View Business Invitations",
+						Value:       "businesses.invitations.view",
+						Description: "Allows the user to view the invitations sent for a business.",
+					},
+					{
+						Label:       "Create Business Invitation",
+						Value:       "businesses.invitations.create",
+						Description: "Allows the user to invite people to a business and resend pending invitations.",
+					},
+					{
+						Label:       "Revoke Business Invitation",
+						Value:       "businesses.invitations.revoke",
+						Description: "Allows the user to revoke pending business invitations.",
+					},
+				},
+			},
 			{
 				Name: "Business Roles",
 				Permissions: []models.Permission{
@@ -335,15 +592,150 @@ var Permissions = []models.PermissionGroup{
 						Value:       "businesses.roles.view",
 						Description: "Allows the user to view business roles.",
 					},
+					{
+						Label:       "Create Business Role",
+						Value:       "businesses.roles.create",
+						Description: "Allows the user to create roles that only exist inside a business.",
+					},
+					{
+						Label:       "Update Business Role",
+						Value:       "businesses.roles.update",
+						Description: "Allows the user to update roles that only exist inside a business.",
+					},
+					{
+						Label:       "Delete Business Role",
+						Value:       "businesses.roles.delete",
+						Description: "Allows the user to delete roles that only exist inside a business.",
+					},
 					{
 						Label:       "Assign Business Role",
 						Value:       "businesses.roles.assign",
-						Description: "Allows the user to assign roles to businesses.",
+						Description: "Allows the user to assign roles to members of a business.",
 					},
 					{
 						Label:       "Unassign Business Role",
 						Value:       "businesses.roles.unassign",
-						Description: "Allows the user to unassign roles from businesses.",
+						Description: "Allows the user to unassign roles from members of a business.",
+					},
+				},
+			},
+			{
+				Name: "Business Prices",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Business Prices",
+						Value:       "businesses.prices.*",
+						Description: "Allows the user to perform any action on business price lists.",
+					},
+					{
+						Label:       "Access Business Prices",
+						Value:       "businesses.prices.access",
+						Description: "Allows the user to access the business prices module.",
+					},
+					{
+						Label:       "View Business Prices",
+						Value:       "businesses.prices.view",
+						Description: "Allows the user to view a business's price list, its history and collector grades.",
+					},
+					{
+						Label:       "Create Business Price",
+						Value:       "businesses.prices.create",
+						Description: "Allows the user to publish price versions and grade collectors.",
+					},
+					{
+						Label:       "Delete Business Price",
+						Value:       "businesses.prices.delete",
+						Description: "Allows the user to retire price tiers and remove collector grades.",
+					},
+				},
+			},
+			{
+				Name: "Business Stock",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Business Stock",
+						Value:       "businesses.stock.*",
+						Description: "Allows the user to perform any action on business stock.",
+					},
+					{
+						Label:       "Access Business Stock",
+						Value:       "businesses.stock.access",
+						Description: "Allows the user to access the business stock module.",
+					},
+					{
+						Label:       "View Business Stock",
+						Value:       "businesses.stock.view",
+						Description: "Allows the user to view a business's stock and its movements.",
+					},
+					{
+						Label:       "Adjust Business Stock",
+						Value:       "businesses.stock.adjust",
+						Description: "Allows the user to record manual stock adjustments.",
+					},
+				},
+			},
+			{
+				Name: "Business Payouts",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Business Payouts",
+						Value:       "businesses.payouts.*",
+						Description: "Allows the user to perform any action on business payouts.",
+					},
+					{
+						Label:       "Access Business Payouts",
+						Value:       "businesses.payouts.access",
+						Description: "Allows the user to access the business payouts module.",
+					},
+					{
+						Label:       "View Business Payouts",
+						Value:       "businesses.payouts.view",
+						Description: "Allows the user to view a business's payout runs, statements and reconciliations.",
+					},
+					{
+						Label:       "Create Business Payouts",
+						Value:       "businesses.payouts.create",
+						Description: "Allows the user to generate payout runs.",
+					},
+					{
+						Label:       "Export Business Payouts",
+						Value:       "businesses.payouts.export",
+						Description: "Allows the user to export payout runs as bank batch files.",
+					},
+					{
+						Label:       "Confirm Business Payouts",
+						Value:       "businesses.payouts.confirm",
+						Description: "Allows the user to confirm that the bank paid a payout run.",
+					},
+					{
+						Label:       "Cancel Business Payouts",
+						Value:       "businesses.payouts.cancel",
+						Description: "Allows the user to cancel payout runs.",
+					},
+				},
+			},
+			{
+				Name: "Business VAT",
+				Permissions: []models.Permission{
+					{
+						Label:       "All Business VAT",
+						Value:       "businesses.vat.*",
+						Description: "Allows the user to perform any action on business VAT settings.",
+					},
+					{
+						Label:       "Access Business VAT",
+						Value:       "businesses.vat.access",
+						Description: "Allows the user to access the business VAT module.",
+					},
+					{
+						Label:       "View Business VAT",
+						Value:       "businesses.vat.view",
+						Description: "Allows the user to view a business's currency and VAT settings.",
+					},
+					{
+						Label:       "Update Business VAT",
+						Value:       "businesses.vat.update",
+						Description: "Allows the user to change a business's currency and VAT registration.",
 					},
 				},
 			},
@@ -384,6 +776,136 @@ var Permissions = []models.PermissionGroup{
 			},
 		},
 	},
+	{
+		Name: "API Tokens",
+		Permissions: []models.Permission{
+			{
+				Label:       "All API Tokens",
+				Value:       "tokens.*",
+				Description: "Allows the user to perform any action on their own API tokens.",
+			},
+			{
+				Label:       "Access API Tokens",
+				Value:       "tokens.access",
+				Description: "Allows the user to access the API tokens module.",
+			},
+			{
+				Label:       "View API Tokens",
+				Value:       "tokens.view",
+				Description: "Allows the user to view their own API tokens.",
+			},
+			{
+				Label:       "Create API Token",
+				Value:       "tokens.create",
+				Description: "Allows the user to issue API tokens for themselves.",
+			},
+			{
+				Label:       "Revoke API Token",
+				Value:       "tokens.revoke",
+				Description: "Allows the user to revoke their own API tokens.",
+			},
+		},
+	},
+	{
+		Name: "Lockouts",
+		Permissions: []models.Permission{
+			{
+				Label:       "All Lockouts",
+				Value:       "lockouts.*",
+				Description: "Allows the user to perform any action on login lockouts.",
+			},
+			{
+				Label:       "Access Lockouts",
+				Value:       "lockouts.access",
+				Description: "Allows the user to access the lockouts module.",
+			},
+			{
+				Label:       "View Lockouts",
+				Value:       "lockouts.view",
+				Description: "Allows the user to view failed login attempts and active lockouts.",
+			},
+			{
+				Label:       "Unlock Accounts",
+				Value:       "lockouts.unlock",
+				Description: "Allows the user to clear lockouts for accounts and IP addresses.",
+			},
+		},
+	},
+	{
+		Name: "Registrations",
+		Permissions: []models.Permission{
+			{
+				Label:       "All Registrations",
+				Value:       "registrations.*",
+				Description: "Allows the user to perform any action on self-registrations.",
+			},
+			{
+				Label:       "Access Registrations",
+				Value:       "registrations.access",
+				Description: "Allows the user to access the registrations module.",
+			},
+			{
+				Label:       "View Registrations",
+				Value:       "registrations.view",
+				Description: "Allows the user to view self-registered accounts and their approval status.",
+			},
+			{
+				Label:       "Approve Registration",
+				Value:       "registrations.approve",
+				Description: "Allows the user to approve pending registrations.",
+			},
+			{
+				Label:       "Reject Registration",
+				Value:       "registrations.reject",
+				Description: "Allows the user to reject pending registrations.",
+			},
+		},
+	},
+	{
+		Name: "Audit Logs",
+		Permissions: []models.Permission{
+			{
+				Label:       "All Audit Logs",
+				Value:       "audit.*",
+				Description: "Allows the user to perform any action on audit logs.",
+			},
+			{
+				Label:       "Access Audit Logs",
+				Value:       "audit.access",
+				Description: "Allows the user to access the audit logs module.",
+			},
+			{
+				Label:       "View Audit Logs",
+				Value:       "audit.view",
+				Description: "Allows the user to view audit logs, including impersonation activity.",
+			},
+		},
+	},
+	{
+		Name: "Policies",
+		Permissions: []models.Permission{
+			{
+				Label:       "All Policies",
+				Value:       "policies.*",
+				Description: "Allows the user to perform any action on data policies.",
+			},
+			{
+				Label:       "Access Policies",
+				Value:       "policies.access",
+				Description: "Allows the user to access the policies module.",
+			},
+			{
+				Label:       "View Policies",
+				Value:       "policies.view",
+				Description: "Allows the user to view the data policy registry.",
+			},
+			{
+				Label:       "Explain Policies",
+				Value:       "policies.explain",
+				Description: "Allows the user to see why a policy allows or denies a user an action.",
+			},
+		},
+	},
 	{
 		Name: "Permissions",
 		Permissions: []models.Permission{
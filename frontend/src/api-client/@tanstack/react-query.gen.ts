@@ -48,15 +48,11 @@ import {
   postApiBusinessesAssignUserByBusinessIdByUserId,
   postApiBusinessesUnassignUserByBusinessIdByUserId,
   postApiCollections,
-  postApiCollectionsAssignMaterialByCollectionIdByMaterialId,
-  postApiCollectionsMaterials,
-  postApiCollectionsUnassignMaterialByCollectionIdByMaterialId,
+  postApiCollectionsByCollectionIdMaterials,
   postApiMaterials,
   postApiRoles,
   postApiTransactions,
-  postApiTransactionsAssignMaterialByTransactionIdByMaterialId,
-  postApiTransactionsMaterials,
-  postApiTransactionsUnassignMaterialByTransactionIdByMaterialId,
+  postApiTransactionsByTransactionIdMaterials,
   postApiUsers,
   postApiUsersAssignRoleByUserIdByRoleId,
   postApiUsersUnassignRoleByUserIdByRoleId,
@@ -162,36 +158,24 @@ import type {
   PostApiBusinessesUnassignUserByBusinessIdByUserIdData,
   PostApiBusinessesUnassignUserByBusinessIdByUserIdError,
   PostApiBusinessesUnassignUserByBusinessIdByUserIdResponse,
-  PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdData,
-  PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdError,
-  PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdResponse,
+  PostApiCollectionsByCollectionIdMaterialsData,
+  PostApiCollectionsByCollectionIdMaterialsError,
+  PostApiCollectionsByCollectionIdMaterialsResponse,
   PostApiCollectionsData,
   PostApiCollectionsError,
-  PostApiCollectionsMaterialsData,
-  PostApiCollectionsMaterialsError,
-  PostApiCollectionsMaterialsResponse,
   PostApiCollectionsResponse,
-  PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdData,
-  PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdError,
-  PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdResponse,
   PostApiMaterialsData,
   PostApiMaterialsError,
   PostApiMaterialsResponse,
   PostApiRolesData,
   PostApiRolesError,
   PostApiRolesResponse,
-  PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdData,
-  PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdError,
-  PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdResponse,
+  PostApiTransactionsByTransactionIdMaterialsData,
+  PostApiTransactionsByTransactionIdMaterialsError,
+  PostApiTransactionsByTransactionIdMaterialsResponse,
   PostApiTransactionsData,
   PostApiTransactionsError,
-  PostApiTransactionsMaterialsData,
-  PostApiTransactionsMaterialsError,
-  PostApiTransactionsMaterialsResponse,
   PostApiTransactionsResponse,
-  PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdData,
-  PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdError,
-  PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdResponse,
   PostApiUsersAssignRoleByUserIdByRoleIdData,
   PostApiUsersAssignRoleByUserIdByRoleIdError,
   PostApiUsersAssignRoleByUserIdByRoleIdResponse,
@@ -1116,73 +1100,6 @@ export const postApiCollectionsMutation = (
   return mutationOptions;
 };
 
-export const postApiCollectionsAssignMaterialByCollectionIdByMaterialIdQueryKey =
-  (
-    options: Options<PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdData>
-  ) =>
-    createQueryKey(
-      'postApiCollectionsAssignMaterialByCollectionIdByMaterialId',
-      options
-    );
-
-/**
- * Assign Material
- * This endpoint assigns a material to a collection
- */
-export const postApiCollectionsAssignMaterialByCollectionIdByMaterialIdOptions =
-  (
-    options: Options<PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdData>
-  ) => {
-    return queryOptions({
-      queryFn: async ({ queryKey, signal }) => {
-        const { data } =
-          await postApiCollectionsAssignMaterialByCollectionIdByMaterialId({
-            ...options,
-            ...queryKey[0],
-            signal,
-            throwOnError: true,
-          });
-        return data;
-      },
-      queryKey:
-        postApiCollectionsAssignMaterialByCollectionIdByMaterialIdQueryKey(
-          options
-        ),
-    });
-  };
-
-/**
- * Assign Material
- * This endpoint assigns a material to a collection
- */
-export const postApiCollectionsAssignMaterialByCollectionIdByMaterialIdMutation =
-  (
-    options?: Partial<
-      Options<PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdData>
-    >
-  ): UseMutationOptions<
-    PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdResponse,
-    PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdError,
-    Options<PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdData>
-  > => {
-    const mutationOptions: UseMutationOptions<
-      PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdResponse,
-      PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdError,
-      Options<PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdData>
-    > = {
-      mutationFn: async (localOptions) => {
-        const { data } =
-          await postApiCollectionsAssignMaterialByCollectionIdByMaterialId({
-            ...options,
-            ...localOptions,
-            throwOnError: true,
-          });
-        return data;
-      },
-    };
-    return mutationOptions;
-  };
-
 export const getApiCollectionsListMaterialsByCollectionIdQueryKey = (
   options: Options<GetApiCollectionsListMaterialsByCollectionIdData>
 ) => createQueryKey('getApiCollectionsListMaterialsByCollectionId', options);
@@ -1340,20 +1257,20 @@ export const getApiCollectionsMaterialsInfiniteOptions = (
   );
 };
 
-export const postApiCollectionsMaterialsQueryKey = (
-  options: Options<PostApiCollectionsMaterialsData>
-) => createQueryKey('postApiCollectionsMaterials', options);
+export const postApiCollectionsByCollectionIdMaterialsQueryKey = (
+  options: Options<PostApiCollectionsByCollectionIdMaterialsData>
+) => createQueryKey('postApiCollectionsByCollectionIdMaterials', options);
 
 /**
- * Create Collection Material
- * This endpoint creates a new collection material.
+ * Create Collection Line
+ * Adds a line for a catalogue material to a collection. The line's value is the weight times the buyer's price for the material, picking the tier for the collector's grade and the line's weight, as the price list stood when the collection was recorded. A value sent with the line overrides the computed value and is recorded as a manual override.
  */
-export const postApiCollectionsMaterialsOptions = (
-  options: Options<PostApiCollectionsMaterialsData>
+export const postApiCollectionsByCollectionIdMaterialsOptions = (
+  options: Options<PostApiCollectionsByCollectionIdMaterialsData>
 ) => {
   return queryOptions({
     queryFn: async ({ queryKey, signal }) => {
-      const { data } = await postApiCollectionsMaterials({
+      const { data } = await postApiCollectionsByCollectionIdMaterials({
         ...options,
         ...queryKey[0],
         signal,
@@ -1361,28 +1278,28 @@ export const postApiCollectionsMaterialsOptions = (
       });
       return data;
     },
-    queryKey: postApiCollectionsMaterialsQueryKey(options),
+    queryKey: postApiCollectionsByCollectionIdMaterialsQueryKey(options),
   });
 };
 
 /**
- * Create Collection Material
- * This endpoint creates a new collection material.
+ * Create Collection Line
+ * Adds a line for a catalogue material to a collection. The line's value is the weight times the buyer's price for the material, picking the tier for the collector's grade and the line's weight, as the price list stood when the collection was recorded. A value sent with the line overrides the computed value and is recorded as a manual override.
  */
-export const postApiCollectionsMaterialsMutation = (
-  options?: Partial<Options<PostApiCollectionsMaterialsData>>
+export const postApiCollectionsByCollectionIdMaterialsMutation = (
+  options?: Partial<Options<PostApiCollectionsByCollectionIdMaterialsData>>
 ): UseMutationOptions<
-  PostApiCollectionsMaterialsResponse,
-  PostApiCollectionsMaterialsError,
-  Options<PostApiCollectionsMaterialsData>
+  PostApiCollectionsByCollectionIdMaterialsResponse,
+  PostApiCollectionsByCollectionIdMaterialsError,
+  Options<PostApiCollectionsByCollectionIdMaterialsData>
 > => {
   const mutationOptions: UseMutationOptions<
-    PostApiCollectionsMaterialsResponse,
-    PostApiCollectionsMaterialsError,
-    Options<PostApiCollectionsMaterialsData>
+    PostApiCollectionsByCollectionIdMaterialsResponse,
+    PostApiCollectionsByCollectionIdMaterialsError,
+    Options<PostApiCollectionsByCollectionIdMaterialsData>
   > = {
     mutationFn: async (localOptions) => {
-      const { data } = await postApiCollectionsMaterials({
+      const { data } = await postApiCollectionsByCollectionIdMaterials({
         ...options,
         ...localOptions,
         throwOnError: true,
@@ -1474,73 +1391,6 @@ export const putApiCollectionsMaterialsByIdMutation = (
   return mutationOptions;
 };
 
-export const postApiCollectionsUnassignMaterialByCollectionIdByMaterialIdQueryKey =
-  (
-    options: Options<PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdData>
-  ) =>
-    createQueryKey(
-      'postApiCollectionsUnassignMaterialByCollectionIdByMaterialId',
-      options
-    );
-
-/**
- * Unassign Material
- * This endpoint unassigns a material from a collection
- */
-export const postApiCollectionsUnassignMaterialByCollectionIdByMaterialIdOptions =
-  (
-    options: Options<PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdData>
-  ) => {
-    return queryOptions({
-      queryFn: async ({ queryKey, signal }) => {
-        const { data } =
-          await postApiCollectionsUnassignMaterialByCollectionIdByMaterialId({
-            ...options,
-            ...queryKey[0],
-            signal,
-            throwOnError: true,
-          });
-        return data;
-      },
-      queryKey:
-        postApiCollectionsUnassignMaterialByCollectionIdByMaterialIdQueryKey(
-          options
-        ),
-    });
-  };
-
-/**
- * Unassign Material
- * This endpoint unassigns a material from a collection
- */
-export const postApiCollectionsUnassignMaterialByCollectionIdByMaterialIdMutation =
-  (
-    options?: Partial<
-      Options<PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdData>
-    >
-  ): UseMutationOptions<
-    PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdResponse,
-    PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdError,
-    Options<PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdData>
-  > => {
-    const mutationOptions: UseMutationOptions<
-      PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdResponse,
-      PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdError,
-      Options<PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdData>
-    > = {
-      mutationFn: async (localOptions) => {
-        const { data } =
-          await postApiCollectionsUnassignMaterialByCollectionIdByMaterialId({
-            ...options,
-            ...localOptions,
-            throwOnError: true,
-          });
-        return data;
-      },
-    };
-    return mutationOptions;
-  };
-
 /**
  * Delete Collection
  * This endpoint deletes an existing collection.
@@ -2168,73 +2018,6 @@ export const postApiTransactionsMutation = (
   return mutationOptions;
 };
 
-export const postApiTransactionsAssignMaterialByTransactionIdByMaterialIdQueryKey =
-  (
-    options: Options<PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdData>
-  ) =>
-    createQueryKey(
-      'postApiTransactionsAssignMaterialByTransactionIdByMaterialId',
-      options
-    );
-
-/**
- * Assign Material
- * This endpoint assigns a material to a transaction
- */
-export const postApiTransactionsAssignMaterialByTransactionIdByMaterialIdOptions =
-  (
-    options: Options<PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdData>
-  ) => {
-    return queryOptions({
-      queryFn: async ({ queryKey, signal }) => {
-        const { data } =
-          await postApiTransactionsAssignMaterialByTransactionIdByMaterialId({
-            ...options,
-            ...queryKey[0],
-            signal,
-            throwOnError: true,
-          });
-        return data;
-      },
-      queryKey:
-        postApiTransactionsAssignMaterialByTransactionIdByMaterialIdQueryKey(
-          options
-        ),
-    });
-  };
-
-/**
- * Assign Material
- * This endpoint assigns a material to a transaction
- */
-export const postApiTransactionsAssignMaterialByTransactionIdByMaterialIdMutation =
-  (
-    options?: Partial<
-      Options<PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdData>
-    >
-  ): UseMutationOptions<
-    PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdResponse,
-    PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdError,
-    Options<PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdData>
-  > => {
-    const mutationOptions: UseMutationOptions<
-      PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdResponse,
-      PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdError,
-      Options<PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdData>
-    > = {
-      mutationFn: async (localOptions) => {
-        const { data } =
-          await postApiTransactionsAssignMaterialByTransactionIdByMaterialId({
-            ...options,
-            ...localOptions,
-            throwOnError: true,
-          });
-        return data;
-      },
-    };
-    return mutationOptions;
-  };
-
 export const getApiTransactionsListMaterialsByTransactionIdQueryKey = (
   options: Options<GetApiTransactionsListMaterialsByTransactionIdData>
 ) => createQueryKey('getApiTransactionsListMaterialsByTransactionId', options);
@@ -2398,20 +2181,20 @@ export const getApiTransactionsMaterialsInfiniteOptions = (
   );
 };
 
-export const postApiTransactionsMaterialsQueryKey = (
-  options: Options<PostApiTransactionsMaterialsData>
-) => createQueryKey('postApiTransactionsMaterials', options);
+export const postApiTransactionsByTransactionIdMaterialsQueryKey = (
+  options: Options<PostApiTransactionsByTransactionIdMaterialsData>
+) => createQueryKey('postApiTransactionsByTransactionIdMaterials', options);
 
 /**
- * Create Transaction Material
- * This endpoint creates a new transaction material.
+ * Create Transaction Line
+ * Adds a line for a catalogue material to a transaction. The line's name, GW code and carbon factor are copied from the material, and the transaction's VAT and totals are refreshed.
  */
-export const postApiTransactionsMaterialsOptions = (
-  options: Options<PostApiTransactionsMaterialsData>
+export const postApiTransactionsByTransactionIdMaterialsOptions = (
+  options: Options<PostApiTransactionsByTransactionIdMaterialsData>
 ) => {
   return queryOptions({
     queryFn: async ({ queryKey, signal }) => {
-      const { data } = await postApiTransactionsMaterials({
+      const { data } = await postApiTransactionsByTransactionIdMaterials({
         ...options,
         ...queryKey[0],
         signal,
@@ -2419,28 +2202,28 @@ export const postApiTransactionsMaterialsOptions = (
       });
       return data;
     },
-    queryKey: postApiTransactionsMaterialsQueryKey(options),
+    queryKey: postApiTransactionsByTransactionIdMaterialsQueryKey(options),
   });
 };
 
 /**
- * Create Transaction Material
- * This endpoint creates a new transaction material.
+ * Create Transaction Line
+ * Adds a line for a catalogue material to a transaction. The line's name, GW code and carbon factor are copied from the material, and the transaction's VAT and totals are refreshed.
  */
-export const postApiTransactionsMaterialsMutation = (
-  options?: Partial<Options<PostApiTransactionsMaterialsData>>
+export const postApiTransactionsByTransactionIdMaterialsMutation = (
+  options?: Partial<Options<PostApiTransactionsByTransactionIdMaterialsData>>
 ): UseMutationOptions<
-  PostApiTransactionsMaterialsResponse,
-  PostApiTransactionsMaterialsError,
-  Options<PostApiTransactionsMaterialsData>
+  PostApiTransactionsByTransactionIdMaterialsResponse,
+  PostApiTransactionsByTransactionIdMaterialsError,
+  Options<PostApiTransactionsByTransactionIdMaterialsData>
 > => {
   const mutationOptions: UseMutationOptions<
-    PostApiTransactionsMaterialsResponse,
-    PostApiTransactionsMaterialsError,
-    Options<PostApiTransactionsMaterialsData>
+    PostApiTransactionsByTransactionIdMaterialsResponse,
+    PostApiTransactionsByTransactionIdMaterialsError,
+    Options<PostApiTransactionsByTransactionIdMaterialsData>
   > = {
     mutationFn: async (localOptions) => {
-      const { data } = await postApiTransactionsMaterials({
+      const { data } = await postApiTransactionsByTransactionIdMaterials({
         ...options,
         ...localOptions,
         throwOnError: true,
@@ -2532,73 +2315,6 @@ export const putApiTransactionsMaterialsByIdMutation = (
   return mutationOptions;
 };
 
-export const postApiTransactionsUnassignMaterialByTransactionIdByMaterialIdQueryKey =
-  (
-    options: Options<PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdData>
-  ) =>
-    createQueryKey(
-      'postApiTransactionsUnassignMaterialByTransactionIdByMaterialId',
-      options
-    );
-
-/**
- * Unassign Material
- * This endpoint unassigns a material from a transaction
- */
-export const postApiTransactionsUnassignMaterialByTransactionIdByMaterialIdOptions =
-  (
-    options: Options<PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdData>
-  ) => {
-    return queryOptions({
-      queryFn: async ({ queryKey, signal }) => {
-        const { data } =
-          await postApiTransactionsUnassignMaterialByTransactionIdByMaterialId({
-            ...options,
-            ...queryKey[0],
-            signal,
-            throwOnError: true,
-          });
-        return data;
-      },
-      queryKey:
-        postApiTransactionsUnassignMaterialByTransactionIdByMaterialIdQueryKey(
-          options
-        ),
-    });
-  };
-
-/**
- * Unassign Material
- * This endpoint unassigns a material from a transaction
- */
-export const postApiTransactionsUnassignMaterialByTransactionIdByMaterialIdMutation =
-  (
-    options?: Partial<
-      Options<PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdData>
-    >
-  ): UseMutationOptions<
-    PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdResponse,
-    PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdError,
-    Options<PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdData>
-  > => {
-    const mutationOptions: UseMutationOptions<
-      PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdResponse,
-      PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdError,
-      Options<PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdData>
-    > = {
-      mutationFn: async (localOptions) => {
-        const { data } =
-          await postApiTransactionsUnassignMaterialByTransactionIdByMaterialId({
-            ...options,
-            ...localOptions,
-            throwOnError: true,
-          });
-        return data;
-      },
-    };
-    return mutationOptions;
-  };
-
 /**
  * Delete Transaction
  * This endpoint deletes an existing transaction.
@@ -116,36 +116,24 @@ import type {
   PostApiBusinessesUnassignUserByBusinessIdByUserIdData,
   PostApiBusinessesUnassignUserByBusinessIdByUserIdErrors,
   PostApiBusinessesUnassignUserByBusinessIdByUserIdResponses,
-  PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdData,
-  PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdErrors,
-  PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdResponses,
+  PostApiCollectionsByCollectionIdMaterialsData,
+  PostApiCollectionsByCollectionIdMaterialsErrors,
+  PostApiCollectionsByCollectionIdMaterialsResponses,
   PostApiCollectionsData,
   PostApiCollectionsErrors,
-  PostApiCollectionsMaterialsData,
-  PostApiCollectionsMaterialsErrors,
-  PostApiCollectionsMaterialsResponses,
   PostApiCollectionsResponses,
-  PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdData,
-  PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdErrors,
-  PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdResponses,
   PostApiMaterialsData,
   PostApiMaterialsErrors,
   PostApiMaterialsResponses,
   PostApiRolesData,
   PostApiRolesErrors,
   PostApiRolesResponses,
-  PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdData,
-  PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdErrors,
-  PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdResponses,
+  PostApiTransactionsByTransactionIdMaterialsData,
+  PostApiTransactionsByTransactionIdMaterialsErrors,
+  PostApiTransactionsByTransactionIdMaterialsResponses,
   PostApiTransactionsData,
   PostApiTransactionsErrors,
-  PostApiTransactionsMaterialsData,
-  PostApiTransactionsMaterialsErrors,
-  PostApiTransactionsMaterialsResponses,
   PostApiTransactionsResponses,
-  PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdData,
-  PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdErrors,
-  PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdResponses,
   PostApiUsersAssignRoleByUserIdByRoleIdData,
   PostApiUsersAssignRoleByUserIdByRoleIdErrors,
   PostApiUsersAssignRoleByUserIdByRoleIdResponses,
@@ -535,28 +523,6 @@ export const postApiCollections = <ThrowOnError extends boolean = false>(
   });
 };
 
-/**
- * Assign Material
- * This endpoint assigns a material to a collection
- */
-export const postApiCollectionsAssignMaterialByCollectionIdByMaterialId = <
-  ThrowOnError extends boolean = false,
->(
-  options: Options<
-    PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdData,
-    ThrowOnError
-  >
-) => {
-  return (options.client ?? _heyApiClient).post<
-    PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdResponses,
-    PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdErrors,
-    ThrowOnError
-  >({
-    url: '/api/collections/assign-material/{collectionId}/{materialId}',
-    ...options,
-  });
-};
-
 /**
  * List Material
  * This endpoint retrieves a list of material assigned to a collection
@@ -599,20 +565,20 @@ export const getApiCollectionsMaterials = <
 };
 
 /**
- * Create Collection Material
- * This endpoint creates a new collection material.
+ * Create Collection Line
+ * Adds a line for a catalogue material to a collection. The line's value is the weight times the buyer's price for the material, picking the tier for the collector's grade and the line's weight, as the price list stood when the collection was recorded. A value sent with the line overrides the computed value and is recorded as a manual override.
  */
-export const postApiCollectionsMaterials = <
+export const postApiCollectionsByCollectionIdMaterials = <
   ThrowOnError extends boolean = false,
 >(
-  options: Options<PostApiCollectionsMaterialsData, ThrowOnError>
+  options: Options<PostApiCollectionsByCollectionIdMaterialsData, ThrowOnError>
 ) => {
   return (options.client ?? _heyApiClient).post<
-    PostApiCollectionsMaterialsResponses,
-    PostApiCollectionsMaterialsErrors,
+    PostApiCollectionsByCollectionIdMaterialsResponses,
+    PostApiCollectionsByCollectionIdMaterialsErrors,
     ThrowOnError
   >({
-    url: '/api/collections/materials',
+    url: '/api/collections/{collectionId}/materials',
     ...options,
     headers: {
       'Content-Type': 'application/json',
@@ -682,28 +648,6 @@ export const putApiCollectionsMaterialsById = <
   });
 };
 
-/**
- * Unassign Material
- * This endpoint unassigns a material from a collection
- */
-export const postApiCollectionsUnassignMaterialByCollectionIdByMaterialId = <
-  ThrowOnError extends boolean = false,
->(
-  options: Options<
-    PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdData,
-    ThrowOnError
-  >
-) => {
-  return (options.client ?? _heyApiClient).post<
-    PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdResponses,
-    PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdErrors,
-    ThrowOnError
-  >({
-    url: '/api/collections/unassign-material/{collectionId}/{materialId}',
-    ...options,
-  });
-};
-
 /**
  * Delete Collection
  * This endpoint deletes an existing collection.
@@ -983,28 +927,6 @@ export const postApiTransactions = <ThrowOnError extends boolean = false>(
   });
 };
 
-/**
- * Assign Material
- * This endpoint assigns a material to a transaction
- */
-export const postApiTransactionsAssignMaterialByTransactionIdByMaterialId = <
-  ThrowOnError extends boolean = false,
->(
-  options: Options<
-    PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdData,
-    ThrowOnError
-  >
-) => {
-  return (options.client ?? _heyApiClient).post<
-    PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdResponses,
-    PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdErrors,
-    ThrowOnError
-  >({
-    url: '/api/transactions/assign-material/{transactionId}/{materialId}',
-    ...options,
-  });
-};
-
 /**
  * List Material
  * This endpoint retrieves a list of material assigned to a transaction
@@ -1047,20 +969,23 @@ export const getApiTransactionsMaterials = <
 };
 
 /**
- * Create Transaction Material
- * This endpoint creates a new transaction material.
+ * Create Transaction Line
+ * Adds a line for a catalogue material to a transaction. The line's name, GW code and carbon factor are copied from the material, and the transaction's VAT and totals are refreshed.
  */
-export const postApiTransactionsMaterials = <
+export const postApiTransactionsByTransactionIdMaterials = <
   ThrowOnError extends boolean = false,
 >(
-  options: Options<PostApiTransactionsMaterialsData, ThrowOnError>
+  options: Options<
+    PostApiTransactionsByTransactionIdMaterialsData,
+    ThrowOnError
+  >
 ) => {
   return (options.client ?? _heyApiClient).post<
-    PostApiTransactionsMaterialsResponses,
-    PostApiTransactionsMaterialsErrors,
+    PostApiTransactionsByTransactionIdMaterialsResponses,
+    PostApiTransactionsByTransactionIdMaterialsErrors,
     ThrowOnError
   >({
-    url: '/api/transactions/materials',
+    url: '/api/transactions/{transactionId}/materials',
     ...options,
     headers: {
       'Content-Type': 'application/json',
@@ -1130,28 +1055,6 @@ export const putApiTransactionsMaterialsById = <
   });
 };
 
-/**
- * Unassign Material
- * This endpoint unassigns a material from a transaction
- */
-export const postApiTransactionsUnassignMaterialByTransactionIdByMaterialId = <
-  ThrowOnError extends boolean = false,
->(
-  options: Options<
-    PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdData,
-    ThrowOnError
-  >
-) => {
-  return (options.client ?? _heyApiClient).post<
-    PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdResponses,
-    PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdErrors,
-    ThrowOnError
-  >({
-    url: '/api/transactions/unassign-material/{transactionId}/{materialId}',
-    ...options,
-  });
-};
-
 /**
  * Delete Transaction
  * This endpoint deletes an existing transaction.
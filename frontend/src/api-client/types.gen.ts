@@ -19,7 +19,7 @@ export type AssignCollectionMaterial = {
   carbonFactor: string;
   gwCode: string;
   name: string;
-  value: number;
+  value: string;
   weight: number;
 };
 
@@ -42,7 +42,7 @@ export type AssignTransactionMaterial = {
   gwCode: string;
   id?: string;
   name: string;
-  value: number;
+  value: string;
   weight: number;
 };
 
@@ -89,9 +89,10 @@ export type CollectionMaterial = {
   createdAt: string;
   gwCode?: string;
   id: string;
+  materialId?: string | null;
   name?: string;
   updatedAt: string;
-  value: number;
+  value: string;
   weight: number;
 };
 
@@ -117,7 +118,7 @@ export type CreateCollectionMaterial = {
   carbonFactor: string;
   gwCode: string;
   name: string;
-  value: number;
+  value: string;
   weight: number;
 };
 
@@ -144,7 +145,7 @@ export type CreateTransactionMaterial = {
   carbonFactor: string;
   gwCode: string;
   name: string;
-  value: number;
+  value: string;
   weight: number;
 };
 
@@ -271,7 +272,7 @@ export type SuccessResponse = {
         id: string;
         name?: string;
         updatedAt: string;
-        value: number;
+        value: string;
         weight: number;
       }
     | {
@@ -289,7 +290,7 @@ export type SuccessResponse = {
         id: string;
         name?: string;
         updatedAt: string;
-        value: number;
+        value: string;
         weight: number;
       }
     | {
@@ -353,7 +354,7 @@ export type SuccessResponse = {
         id: string;
         name?: string;
         updatedAt: string;
-        value: number;
+        value: string;
         weight: number;
       }
     | {
@@ -371,7 +372,7 @@ export type SuccessResponse = {
         id: string;
         name?: string;
         updatedAt: string;
-        value: number;
+        value: string;
         weight: number;
       }
     | {
@@ -408,9 +409,10 @@ export type TransactionMaterial = {
   createdAt: string;
   gwCode?: string;
   id: string;
+  materialId?: string | null;
   name?: string;
   updatedAt: string;
-  value: number;
+  value: string;
   weight: number;
 };
 
@@ -432,11 +434,9 @@ export type UpdateCollection = {
 };
 
 export type UpdateCollectionMaterial = {
-  carbonFactor: string;
-  gwCode: string;
-  name: string;
-  value: number | null;
-  weight: number | null;
+  materialId: string;
+  value?: string | null;
+  weight: number;
 };
 
 export type UpdateMaterial = {
@@ -458,11 +458,9 @@ export type UpdateTransaction = {
 };
 
 export type UpdateTransactionMaterial = {
-  carbonFactor: string;
-  gwCode: string;
-  name: string;
-  value: number | null;
-  weight: number | null;
+  materialId: string;
+  value: string;
+  weight: number;
 };
 
 export type UpdateUser = {
@@ -498,6 +496,24 @@ export type User = {
 
 export type Users = Array<User>;
 
+/**
+ * Create collection line payload
+ */
+export type CreateCollectionLinePayload = {
+  materialId: string;
+  value?: string | null;
+  weight: number;
+};
+
+/**
+ * Create transaction line payload
+ */
+export type CreateTransactionLinePayload = {
+  materialId: string;
+  value: string;
+  weight: number;
+};
+
 /**
  * Login payload
  */
@@ -620,7 +636,7 @@ export type GetApiAuthenticationCheckResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -638,7 +654,7 @@ export type GetApiAuthenticationCheckResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -702,7 +718,7 @@ export type GetApiAuthenticationCheckResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -720,7 +736,7 @@ export type GetApiAuthenticationCheckResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -847,7 +863,7 @@ export type PostApiAuthenticationLoginResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -865,7 +881,7 @@ export type PostApiAuthenticationLoginResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -929,7 +945,7 @@ export type PostApiAuthenticationLoginResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -947,7 +963,7 @@ export type PostApiAuthenticationLoginResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1071,7 +1087,7 @@ export type PostApiAuthenticationLogoutResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1089,7 +1105,7 @@ export type PostApiAuthenticationLogoutResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1153,7 +1169,7 @@ export type PostApiAuthenticationLogoutResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1171,7 +1187,7 @@ export type PostApiAuthenticationLogoutResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1347,7 +1363,7 @@ export type PostApiAuthenticationMfaVerifyResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1365,7 +1381,7 @@ export type PostApiAuthenticationMfaVerifyResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1429,7 +1445,7 @@ export type PostApiAuthenticationMfaVerifyResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1447,7 +1463,7 @@ export type PostApiAuthenticationMfaVerifyResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1571,7 +1587,7 @@ export type GetApiAuthenticationPermissionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1589,7 +1605,7 @@ export type GetApiAuthenticationPermissionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1653,7 +1669,7 @@ export type GetApiAuthenticationPermissionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1671,7 +1687,7 @@ export type GetApiAuthenticationPermissionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1798,7 +1814,7 @@ export type PostApiAuthenticationRegisterResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1816,7 +1832,7 @@ export type PostApiAuthenticationRegisterResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1880,7 +1896,7 @@ export type PostApiAuthenticationRegisterResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -1898,7 +1914,7 @@ export type PostApiAuthenticationRegisterResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2028,7 +2044,7 @@ export type GetApiBusinessesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2046,7 +2062,7 @@ export type GetApiBusinessesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2110,7 +2126,7 @@ export type GetApiBusinessesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2128,7 +2144,7 @@ export type GetApiBusinessesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2255,7 +2271,7 @@ export type PostApiBusinessesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2273,7 +2289,7 @@ export type PostApiBusinessesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2337,7 +2353,7 @@ export type PostApiBusinessesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2355,7 +2371,7 @@ export type PostApiBusinessesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2489,7 +2505,7 @@ export type PostApiBusinessesAssignUserByBusinessIdByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2507,7 +2523,7 @@ export type PostApiBusinessesAssignUserByBusinessIdByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2571,7 +2587,7 @@ export type PostApiBusinessesAssignUserByBusinessIdByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2589,7 +2605,7 @@ export type PostApiBusinessesAssignUserByBusinessIdByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2728,7 +2744,7 @@ export type GetApiBusinessesListUsersByBusinessIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2746,7 +2762,7 @@ export type GetApiBusinessesListUsersByBusinessIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2810,7 +2826,7 @@ export type GetApiBusinessesListUsersByBusinessIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2828,7 +2844,7 @@ export type GetApiBusinessesListUsersByBusinessIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2962,7 +2978,7 @@ export type PostApiBusinessesUnassignUserByBusinessIdByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -2980,7 +2996,7 @@ export type PostApiBusinessesUnassignUserByBusinessIdByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3044,7 +3060,7 @@ export type PostApiBusinessesUnassignUserByBusinessIdByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3062,7 +3078,7 @@ export type PostApiBusinessesUnassignUserByBusinessIdByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3195,7 +3211,7 @@ export type DeleteApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3213,7 +3229,7 @@ export type DeleteApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3277,7 +3293,7 @@ export type DeleteApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3295,7 +3311,7 @@ export type DeleteApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3430,7 +3446,7 @@ export type GetApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3448,7 +3464,7 @@ export type GetApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3512,7 +3528,7 @@ export type GetApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3530,7 +3546,7 @@ export type GetApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3666,7 +3682,7 @@ export type PutApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3684,7 +3700,7 @@ export type PutApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3748,7 +3764,7 @@ export type PutApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3766,7 +3782,7 @@ export type PutApiBusinessesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3896,7 +3912,7 @@ export type GetApiCollectionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3914,7 +3930,7 @@ export type GetApiCollectionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3978,7 +3994,7 @@ export type GetApiCollectionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -3996,7 +4012,7 @@ export type GetApiCollectionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4123,7 +4139,7 @@ export type PostApiCollectionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4141,7 +4157,7 @@ export type PostApiCollectionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4205,7 +4221,7 @@ export type PostApiCollectionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4223,7 +4239,7 @@ export type PostApiCollectionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4250,241 +4266,6 @@ export type PostApiCollectionsResponses = {
 export type PostApiCollectionsResponse =
   PostApiCollectionsResponses[keyof PostApiCollectionsResponses];
 
-export type PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdData = {
-  body?: never;
-  path: {
-    collectionId: string;
-    materialId: string;
-  };
-  query?: never;
-  url: '/api/collections/assign-material/{collectionId}/{materialId}';
-};
-
-export type PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdErrors = {
-  /**
-   * Bad Request
-   */
-  400: {
-    error: string;
-    message: string;
-  };
-  /**
-   * Unauthorized
-   */
-  401: {
-    error: string;
-    message: string;
-  };
-  /**
-   * Forbidden
-   */
-  403: {
-    error: string;
-    message: string;
-  };
-  /**
-   * Not Found
-   */
-  404: {
-    error: string;
-    message: string;
-  };
-  /**
-   * Internal Server Error
-   */
-  500: {
-    error: string;
-    message: string;
-  };
-  default: unknown;
-};
-
-export type PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdError =
-  PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdErrors[keyof PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdErrors];
-
-export type PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdResponses =
-  {
-    /**
-     * Material assigned to Collection successfully.
-     */
-    200: {
-      item?:
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            businessId: string | null;
-            businesses: Array<Business>;
-            createdAt: string;
-            id: string;
-            idNumber: string | null;
-            mfaEnabled: boolean;
-            mfaVerified: boolean;
-            name: string;
-            permissions: Array<string>;
-            roles: Roles;
-            type: 'system' | 'collector' | 'business';
-            updatedAt: string;
-            username: string;
-          }
-        | {
-            createdAt: string;
-            default: boolean;
-            description: string;
-            id: string;
-            name: string;
-            permissions: Array<string>;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor: string;
-            createdAt: string;
-            gwCode: string;
-            id: string;
-            name: string;
-            updatedAt: string;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: CollectionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: TransactionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            createdAt: string;
-            id: string;
-            name: string;
-            ownerId: string;
-            roles: Roles;
-            updatedAt: string;
-            users: Array<User>;
-          };
-      items?: Array<
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            businessId: string | null;
-            businesses: Array<Business>;
-            createdAt: string;
-            id: string;
-            idNumber: string | null;
-            mfaEnabled: boolean;
-            mfaVerified: boolean;
-            name: string;
-            permissions: Array<string>;
-            roles: Roles;
-            type: 'system' | 'collector' | 'business';
-            updatedAt: string;
-            username: string;
-          }
-        | {
-            createdAt: string;
-            default: boolean;
-            description: string;
-            id: string;
-            name: string;
-            permissions: Array<string>;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor: string;
-            createdAt: string;
-            gwCode: string;
-            id: string;
-            name: string;
-            updatedAt: string;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: CollectionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: TransactionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            createdAt: string;
-            id: string;
-            name: string;
-            ownerId: string;
-            roles: Roles;
-            updatedAt: string;
-            users: Array<User>;
-          }
-        | {
-            name: string;
-            permissions: Permissions;
-            subGroups: Array<PermissionGroup>;
-          }
-      >;
-      pagination?: Pagination;
-    };
-  };
-
-export type PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdResponse =
-  PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdResponses[keyof PostApiCollectionsAssignMaterialByCollectionIdByMaterialIdResponses];
-
 export type GetApiCollectionsListMaterialsByCollectionIdData = {
   body?: never;
   path: {
@@ -4597,7 +4378,7 @@ export type GetApiCollectionsListMaterialsByCollectionIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4615,7 +4396,7 @@ export type GetApiCollectionsListMaterialsByCollectionIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4679,7 +4460,7 @@ export type GetApiCollectionsListMaterialsByCollectionIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4697,7 +4478,7 @@ export type GetApiCollectionsListMaterialsByCollectionIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4827,7 +4608,7 @@ export type GetApiCollectionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4845,7 +4626,7 @@ export type GetApiCollectionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4909,7 +4690,7 @@ export type GetApiCollectionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4927,7 +4708,7 @@ export type GetApiCollectionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -4954,17 +4735,19 @@ export type GetApiCollectionsMaterialsResponses = {
 export type GetApiCollectionsMaterialsResponse =
   GetApiCollectionsMaterialsResponses[keyof GetApiCollectionsMaterialsResponses];
 
-export type PostApiCollectionsMaterialsData = {
+export type PostApiCollectionsByCollectionIdMaterialsData = {
   /**
-   * Payload to create a new collection material.
+   * Create collection line payload
    */
-  body: CreateCollectionMaterial;
-  path?: never;
+  body: CreateCollectionLinePayload;
+  path: {
+    collectionId: string;
+  };
   query?: never;
-  url: '/api/collections/materials';
+  url: '/api/collections/{collectionId}/materials';
 };
 
-export type PostApiCollectionsMaterialsErrors = {
+export type PostApiCollectionsByCollectionIdMaterialsErrors = {
   /**
    * Bad Request
    */
@@ -4986,6 +4769,13 @@ export type PostApiCollectionsMaterialsErrors = {
     error: string;
     message: string;
   };
+  /**
+   * Not Found
+   */
+  404: {
+    error: string;
+    message: string;
+  };
   /**
    * Internal Server Error
    */
@@ -4996,10 +4786,10 @@ export type PostApiCollectionsMaterialsErrors = {
   default: unknown;
 };
 
-export type PostApiCollectionsMaterialsError =
-  PostApiCollectionsMaterialsErrors[keyof PostApiCollectionsMaterialsErrors];
+export type PostApiCollectionsByCollectionIdMaterialsError =
+  PostApiCollectionsByCollectionIdMaterialsErrors[keyof PostApiCollectionsByCollectionIdMaterialsErrors];
 
-export type PostApiCollectionsMaterialsResponses = {
+export type PostApiCollectionsByCollectionIdMaterialsResponses = {
   /**
    * Collection Material created successfully.
    */
@@ -5054,7 +4844,7 @@ export type PostApiCollectionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5072,7 +4862,7 @@ export type PostApiCollectionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5136,7 +4926,7 @@ export type PostApiCollectionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5154,7 +4944,7 @@ export type PostApiCollectionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5178,8 +4968,8 @@ export type PostApiCollectionsMaterialsResponses = {
   };
 };
 
-export type PostApiCollectionsMaterialsResponse =
-  PostApiCollectionsMaterialsResponses[keyof PostApiCollectionsMaterialsResponses];
+export type PostApiCollectionsByCollectionIdMaterialsResponse =
+  PostApiCollectionsByCollectionIdMaterialsResponses[keyof PostApiCollectionsByCollectionIdMaterialsResponses];
 
 export type DeleteApiCollectionsMaterialsByIdData = {
   body?: never;
@@ -5287,7 +5077,7 @@ export type DeleteApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5305,7 +5095,7 @@ export type DeleteApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5369,7 +5159,7 @@ export type DeleteApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5387,7 +5177,7 @@ export type DeleteApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5522,7 +5312,7 @@ export type GetApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5540,7 +5330,7 @@ export type GetApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5604,7 +5394,7 @@ export type GetApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5622,7 +5412,7 @@ export type GetApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5758,7 +5548,7 @@ export type PutApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5776,7 +5566,7 @@ export type PutApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5840,7 +5630,7 @@ export type PutApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5858,7 +5648,7 @@ export type PutApiCollectionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -5885,242 +5675,6 @@ export type PutApiCollectionsMaterialsByIdResponses = {
 export type PutApiCollectionsMaterialsByIdResponse =
   PutApiCollectionsMaterialsByIdResponses[keyof PutApiCollectionsMaterialsByIdResponses];
 
-export type PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdData = {
-  body?: never;
-  path: {
-    collectionId: string;
-    materialId: string;
-  };
-  query?: never;
-  url: '/api/collections/unassign-material/{collectionId}/{materialId}';
-};
-
-export type PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdErrors =
-  {
-    /**
-     * Bad Request
-     */
-    400: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Unauthorized
-     */
-    401: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Forbidden
-     */
-    403: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Not Found
-     */
-    404: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Internal Server Error
-     */
-    500: {
-      error: string;
-      message: string;
-    };
-    default: unknown;
-  };
-
-export type PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdError =
-  PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdErrors[keyof PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdErrors];
-
-export type PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdResponses =
-  {
-    /**
-     * Material unassigned from Collection successfully.
-     */
-    200: {
-      item?:
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            businessId: string | null;
-            businesses: Array<Business>;
-            createdAt: string;
-            id: string;
-            idNumber: string | null;
-            mfaEnabled: boolean;
-            mfaVerified: boolean;
-            name: string;
-            permissions: Array<string>;
-            roles: Roles;
-            type: 'system' | 'collector' | 'business';
-            updatedAt: string;
-            username: string;
-          }
-        | {
-            createdAt: string;
-            default: boolean;
-            description: string;
-            id: string;
-            name: string;
-            permissions: Array<string>;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor: string;
-            createdAt: string;
-            gwCode: string;
-            id: string;
-            name: string;
-            updatedAt: string;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: CollectionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: TransactionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            createdAt: string;
-            id: string;
-            name: string;
-            ownerId: string;
-            roles: Roles;
-            updatedAt: string;
-            users: Array<User>;
-          };
-      items?: Array<
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            businessId: string | null;
-            businesses: Array<Business>;
-            createdAt: string;
-            id: string;
-            idNumber: string | null;
-            mfaEnabled: boolean;
-            mfaVerified: boolean;
-            name: string;
-            permissions: Array<string>;
-            roles: Roles;
-            type: 'system' | 'collector' | 'business';
-            updatedAt: string;
-            username: string;
-          }
-        | {
-            createdAt: string;
-            default: boolean;
-            description: string;
-            id: string;
-            name: string;
-            permissions: Array<string>;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor: string;
-            createdAt: string;
-            gwCode: string;
-            id: string;
-            name: string;
-            updatedAt: string;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: CollectionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: TransactionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            createdAt: string;
-            id: string;
-            name: string;
-            ownerId: string;
-            roles: Roles;
-            updatedAt: string;
-            users: Array<User>;
-          }
-        | {
-            name: string;
-            permissions: Permissions;
-            subGroups: Array<PermissionGroup>;
-          }
-      >;
-      pagination?: Pagination;
-    };
-  };
-
-export type PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdResponse =
-  PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdResponses[keyof PostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdResponses];
-
 export type DeleteApiCollectionsByIdData = {
   body?: never;
   path: {
@@ -6227,7 +5781,7 @@ export type DeleteApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6245,7 +5799,7 @@ export type DeleteApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6309,7 +5863,7 @@ export type DeleteApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6327,7 +5881,7 @@ export type DeleteApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6462,7 +6016,7 @@ export type GetApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6480,7 +6034,7 @@ export type GetApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6544,7 +6098,7 @@ export type GetApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6562,7 +6116,7 @@ export type GetApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6698,7 +6252,7 @@ export type PutApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6716,7 +6270,7 @@ export type PutApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6780,7 +6334,7 @@ export type PutApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6798,7 +6352,7 @@ export type PutApiCollectionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6928,7 +6482,7 @@ export type GetApiMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -6946,7 +6500,7 @@ export type GetApiMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7010,7 +6564,7 @@ export type GetApiMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7028,7 +6582,7 @@ export type GetApiMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7155,7 +6709,7 @@ export type PostApiMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7173,7 +6727,7 @@ export type PostApiMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7237,7 +6791,7 @@ export type PostApiMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7255,7 +6809,7 @@ export type PostApiMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7388,7 +6942,7 @@ export type DeleteApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7406,7 +6960,7 @@ export type DeleteApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7470,7 +7024,7 @@ export type DeleteApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7488,7 +7042,7 @@ export type DeleteApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7623,7 +7177,7 @@ export type GetApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7641,7 +7195,7 @@ export type GetApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7705,7 +7259,7 @@ export type GetApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7723,7 +7277,7 @@ export type GetApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7859,7 +7413,7 @@ export type PutApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7877,7 +7431,7 @@ export type PutApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7941,7 +7495,7 @@ export type PutApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -7959,7 +7513,7 @@ export type PutApiMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8088,7 +7642,7 @@ export type GetApiRolesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8106,7 +7660,7 @@ export type GetApiRolesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8170,7 +7724,7 @@ export type GetApiRolesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8188,7 +7742,7 @@ export type GetApiRolesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8314,7 +7868,7 @@ export type PostApiRolesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8332,7 +7886,7 @@ export type PostApiRolesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8396,7 +7950,7 @@ export type PostApiRolesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8414,7 +7968,7 @@ export type PostApiRolesResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8547,7 +8101,7 @@ export type DeleteApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8565,7 +8119,7 @@ export type DeleteApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8629,7 +8183,7 @@ export type DeleteApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8647,7 +8201,7 @@ export type DeleteApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8782,7 +8336,7 @@ export type GetApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8800,7 +8354,7 @@ export type GetApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8864,7 +8418,7 @@ export type GetApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -8882,7 +8436,7 @@ export type GetApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9018,7 +8572,7 @@ export type PutApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9036,7 +8590,7 @@ export type PutApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9100,7 +8654,7 @@ export type PutApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9118,7 +8672,7 @@ export type PutApiRolesByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9248,7 +8802,7 @@ export type GetApiTransactionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9266,7 +8820,7 @@ export type GetApiTransactionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9330,7 +8884,7 @@ export type GetApiTransactionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9348,7 +8902,7 @@ export type GetApiTransactionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9475,7 +9029,7 @@ export type PostApiTransactionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9493,7 +9047,7 @@ export type PostApiTransactionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9557,7 +9111,7 @@ export type PostApiTransactionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9575,7 +9129,7 @@ export type PostApiTransactionsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9602,242 +9156,6 @@ export type PostApiTransactionsResponses = {
 export type PostApiTransactionsResponse =
   PostApiTransactionsResponses[keyof PostApiTransactionsResponses];
 
-export type PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdData = {
-  body?: never;
-  path: {
-    transactionId: string;
-    materialId: string;
-  };
-  query?: never;
-  url: '/api/transactions/assign-material/{transactionId}/{materialId}';
-};
-
-export type PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdErrors =
-  {
-    /**
-     * Bad Request
-     */
-    400: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Unauthorized
-     */
-    401: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Forbidden
-     */
-    403: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Not Found
-     */
-    404: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Internal Server Error
-     */
-    500: {
-      error: string;
-      message: string;
-    };
-    default: unknown;
-  };
-
-export type PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdError =
-  PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdErrors[keyof PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdErrors];
-
-export type PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdResponses =
-  {
-    /**
-     * Material assigned to Transaction successfully.
-     */
-    200: {
-      item?:
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            businessId: string | null;
-            businesses: Array<Business>;
-            createdAt: string;
-            id: string;
-            idNumber: string | null;
-            mfaEnabled: boolean;
-            mfaVerified: boolean;
-            name: string;
-            permissions: Array<string>;
-            roles: Roles;
-            type: 'system' | 'collector' | 'business';
-            updatedAt: string;
-            username: string;
-          }
-        | {
-            createdAt: string;
-            default: boolean;
-            description: string;
-            id: string;
-            name: string;
-            permissions: Array<string>;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor: string;
-            createdAt: string;
-            gwCode: string;
-            id: string;
-            name: string;
-            updatedAt: string;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: CollectionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: TransactionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            createdAt: string;
-            id: string;
-            name: string;
-            ownerId: string;
-            roles: Roles;
-            updatedAt: string;
-            users: Array<User>;
-          };
-      items?: Array<
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            businessId: string | null;
-            businesses: Array<Business>;
-            createdAt: string;
-            id: string;
-            idNumber: string | null;
-            mfaEnabled: boolean;
-            mfaVerified: boolean;
-            name: string;
-            permissions: Array<string>;
-            roles: Roles;
-            type: 'system' | 'collector' | 'business';
-            updatedAt: string;
-            username: string;
-          }
-        | {
-            createdAt: string;
-            default: boolean;
-            description: string;
-            id: string;
-            name: string;
-            permissions: Array<string>;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor: string;
-            createdAt: string;
-            gwCode: string;
-            id: string;
-            name: string;
-            updatedAt: string;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: CollectionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: TransactionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            createdAt: string;
-            id: string;
-            name: string;
-            ownerId: string;
-            roles: Roles;
-            updatedAt: string;
-            users: Array<User>;
-          }
-        | {
-            name: string;
-            permissions: Permissions;
-            subGroups: Array<PermissionGroup>;
-          }
-      >;
-      pagination?: Pagination;
-    };
-  };
-
-export type PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdResponse =
-  PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdResponses[keyof PostApiTransactionsAssignMaterialByTransactionIdByMaterialIdResponses];
-
 export type GetApiTransactionsListMaterialsByTransactionIdData = {
   body?: never;
   path: {
@@ -9950,7 +9268,7 @@ export type GetApiTransactionsListMaterialsByTransactionIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -9968,7 +9286,7 @@ export type GetApiTransactionsListMaterialsByTransactionIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10032,7 +9350,7 @@ export type GetApiTransactionsListMaterialsByTransactionIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10050,7 +9368,7 @@ export type GetApiTransactionsListMaterialsByTransactionIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10180,7 +9498,7 @@ export type GetApiTransactionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10198,7 +9516,7 @@ export type GetApiTransactionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10262,7 +9580,7 @@ export type GetApiTransactionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10280,7 +9598,7 @@ export type GetApiTransactionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10307,17 +9625,19 @@ export type GetApiTransactionsMaterialsResponses = {
 export type GetApiTransactionsMaterialsResponse =
   GetApiTransactionsMaterialsResponses[keyof GetApiTransactionsMaterialsResponses];
 
-export type PostApiTransactionsMaterialsData = {
+export type PostApiTransactionsByTransactionIdMaterialsData = {
   /**
-   * Payload to create a new transaction material.
+   * Create transaction line payload
    */
-  body: CreateTransactionMaterial;
-  path?: never;
+  body: CreateTransactionLinePayload;
+  path: {
+    transactionId: string;
+  };
   query?: never;
-  url: '/api/transactions/materials';
+  url: '/api/transactions/{transactionId}/materials';
 };
 
-export type PostApiTransactionsMaterialsErrors = {
+export type PostApiTransactionsByTransactionIdMaterialsErrors = {
   /**
    * Bad Request
    */
@@ -10339,6 +9659,13 @@ export type PostApiTransactionsMaterialsErrors = {
     error: string;
     message: string;
   };
+  /**
+   * Not Found
+   */
+  404: {
+    error: string;
+    message: string;
+  };
   /**
    * Internal Server Error
    */
@@ -10349,10 +9676,10 @@ export type PostApiTransactionsMaterialsErrors = {
   default: unknown;
 };
 
-export type PostApiTransactionsMaterialsError =
-  PostApiTransactionsMaterialsErrors[keyof PostApiTransactionsMaterialsErrors];
+export type PostApiTransactionsByTransactionIdMaterialsError =
+  PostApiTransactionsByTransactionIdMaterialsErrors[keyof PostApiTransactionsByTransactionIdMaterialsErrors];
 
-export type PostApiTransactionsMaterialsResponses = {
+export type PostApiTransactionsByTransactionIdMaterialsResponses = {
   /**
    * Transaction Material created successfully.
    */
@@ -10407,7 +9734,7 @@ export type PostApiTransactionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10425,7 +9752,7 @@ export type PostApiTransactionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10489,7 +9816,7 @@ export type PostApiTransactionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10507,7 +9834,7 @@ export type PostApiTransactionsMaterialsResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10531,8 +9858,8 @@ export type PostApiTransactionsMaterialsResponses = {
   };
 };
 
-export type PostApiTransactionsMaterialsResponse =
-  PostApiTransactionsMaterialsResponses[keyof PostApiTransactionsMaterialsResponses];
+export type PostApiTransactionsByTransactionIdMaterialsResponse =
+  PostApiTransactionsByTransactionIdMaterialsResponses[keyof PostApiTransactionsByTransactionIdMaterialsResponses];
 
 export type DeleteApiTransactionsMaterialsByIdData = {
   body?: never;
@@ -10640,7 +9967,7 @@ export type DeleteApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10658,7 +9985,7 @@ export type DeleteApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10722,7 +10049,7 @@ export type DeleteApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10740,7 +10067,7 @@ export type DeleteApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10875,7 +10202,7 @@ export type GetApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10893,7 +10220,7 @@ export type GetApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10957,7 +10284,7 @@ export type GetApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -10975,7 +10302,7 @@ export type GetApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11111,7 +10438,7 @@ export type PutApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11129,7 +10456,7 @@ export type PutApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11193,7 +10520,7 @@ export type PutApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11211,7 +10538,7 @@ export type PutApiTransactionsMaterialsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11238,243 +10565,6 @@ export type PutApiTransactionsMaterialsByIdResponses = {
 export type PutApiTransactionsMaterialsByIdResponse =
   PutApiTransactionsMaterialsByIdResponses[keyof PutApiTransactionsMaterialsByIdResponses];
 
-export type PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdData =
-  {
-    body?: never;
-    path: {
-      transactionId: string;
-      materialId: string;
-    };
-    query?: never;
-    url: '/api/transactions/unassign-material/{transactionId}/{materialId}';
-  };
-
-export type PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdErrors =
-  {
-    /**
-     * Bad Request
-     */
-    400: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Unauthorized
-     */
-    401: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Forbidden
-     */
-    403: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Not Found
-     */
-    404: {
-      error: string;
-      message: string;
-    };
-    /**
-     * Internal Server Error
-     */
-    500: {
-      error: string;
-      message: string;
-    };
-    default: unknown;
-  };
-
-export type PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdError =
-  PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdErrors[keyof PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdErrors];
-
-export type PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdResponses =
-  {
-    /**
-     * Material unassigned from Transaction successfully.
-     */
-    200: {
-      item?:
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            businessId: string | null;
-            businesses: Array<Business>;
-            createdAt: string;
-            id: string;
-            idNumber: string | null;
-            mfaEnabled: boolean;
-            mfaVerified: boolean;
-            name: string;
-            permissions: Array<string>;
-            roles: Roles;
-            type: 'system' | 'collector' | 'business';
-            updatedAt: string;
-            username: string;
-          }
-        | {
-            createdAt: string;
-            default: boolean;
-            description: string;
-            id: string;
-            name: string;
-            permissions: Array<string>;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor: string;
-            createdAt: string;
-            gwCode: string;
-            id: string;
-            name: string;
-            updatedAt: string;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: CollectionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: TransactionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            createdAt: string;
-            id: string;
-            name: string;
-            ownerId: string;
-            roles: Roles;
-            updatedAt: string;
-            users: Array<User>;
-          };
-      items?: Array<
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            businessId: string | null;
-            businesses: Array<Business>;
-            createdAt: string;
-            id: string;
-            idNumber: string | null;
-            mfaEnabled: boolean;
-            mfaVerified: boolean;
-            name: string;
-            permissions: Array<string>;
-            roles: Roles;
-            type: 'system' | 'collector' | 'business';
-            updatedAt: string;
-            username: string;
-          }
-        | {
-            createdAt: string;
-            default: boolean;
-            description: string;
-            id: string;
-            name: string;
-            permissions: Array<string>;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor: string;
-            createdAt: string;
-            gwCode: string;
-            id: string;
-            name: string;
-            updatedAt: string;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: CollectionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            buyer: Business;
-            createdAt: string;
-            id: string;
-            materials: TransactionMaterials;
-            seller: User;
-            updatedAt: string;
-          }
-        | {
-            carbonFactor?: string;
-            createdAt: string;
-            gwCode?: string;
-            id: string;
-            name?: string;
-            updatedAt: string;
-            value: number;
-            weight: number;
-          }
-        | {
-            address: Address;
-            bankDetails: BankDetails;
-            createdAt: string;
-            id: string;
-            name: string;
-            ownerId: string;
-            roles: Roles;
-            updatedAt: string;
-            users: Array<User>;
-          }
-        | {
-            name: string;
-            permissions: Permissions;
-            subGroups: Array<PermissionGroup>;
-          }
-      >;
-      pagination?: Pagination;
-    };
-  };
-
-export type PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdResponse =
-  PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdResponses[keyof PostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdResponses];
-
 export type DeleteApiTransactionsByIdData = {
   body?: never;
   path: {
@@ -11581,7 +10671,7 @@ export type DeleteApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11599,7 +10689,7 @@ export type DeleteApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11663,7 +10753,7 @@ export type DeleteApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11681,7 +10771,7 @@ export type DeleteApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11816,7 +10906,7 @@ export type GetApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11834,7 +10924,7 @@ export type GetApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11898,7 +10988,7 @@ export type GetApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -11916,7 +11006,7 @@ export type GetApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12052,7 +11142,7 @@ export type PutApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12070,7 +11160,7 @@ export type PutApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12134,7 +11224,7 @@ export type PutApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12152,7 +11242,7 @@ export type PutApiTransactionsByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12281,7 +11371,7 @@ export type GetApiUsersResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12299,7 +11389,7 @@ export type GetApiUsersResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12363,7 +11453,7 @@ export type GetApiUsersResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12381,7 +11471,7 @@ export type GetApiUsersResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12507,7 +11597,7 @@ export type PostApiUsersResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12525,7 +11615,7 @@ export type PostApiUsersResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12589,7 +11679,7 @@ export type PostApiUsersResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12607,7 +11697,7 @@ export type PostApiUsersResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12741,7 +11831,7 @@ export type PostApiUsersAssignRoleByUserIdByRoleIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12759,7 +11849,7 @@ export type PostApiUsersAssignRoleByUserIdByRoleIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12823,7 +11913,7 @@ export type PostApiUsersAssignRoleByUserIdByRoleIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12841,7 +11931,7 @@ export type PostApiUsersAssignRoleByUserIdByRoleIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12980,7 +12070,7 @@ export type GetApiUsersListRolesByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -12998,7 +12088,7 @@ export type GetApiUsersListRolesByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13062,7 +12152,7 @@ export type GetApiUsersListRolesByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13080,7 +12170,7 @@ export type GetApiUsersListRolesByUserIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13214,7 +12304,7 @@ export type PostApiUsersUnassignRoleByUserIdByRoleIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13232,7 +12322,7 @@ export type PostApiUsersUnassignRoleByUserIdByRoleIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13296,7 +12386,7 @@ export type PostApiUsersUnassignRoleByUserIdByRoleIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13314,7 +12404,7 @@ export type PostApiUsersUnassignRoleByUserIdByRoleIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13447,7 +12537,7 @@ export type DeleteApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13465,7 +12555,7 @@ export type DeleteApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13529,7 +12619,7 @@ export type DeleteApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13547,7 +12637,7 @@ export type DeleteApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13682,7 +12772,7 @@ export type GetApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13700,7 +12790,7 @@ export type GetApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13764,7 +12854,7 @@ export type GetApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13782,7 +12872,7 @@ export type GetApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13918,7 +13008,7 @@ export type PutApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -13936,7 +13026,7 @@ export type PutApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -14000,7 +13090,7 @@ export type PutApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
@@ -14018,7 +13108,7 @@ export type PutApiUsersByIdResponses = {
           id: string;
           name?: string;
           updatedAt: string;
-          value: number;
+          value: string;
           weight: number;
         }
       | {
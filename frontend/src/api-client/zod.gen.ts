@@ -23,7 +23,7 @@ export const zAssignCollectionMaterial = z.object({
   carbonFactor: z.string(),
   gwCode: z.string(),
   name: z.string(),
-  value: z.number().gte(0),
+  value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
   weight: z.number().gte(0),
 });
 
@@ -46,7 +46,7 @@ export const zAssignTransactionMaterial = z.object({
   gwCode: z.string(),
   id: z.optional(z.uuid()),
   name: z.string(),
-  value: z.number().gte(0),
+  value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
   weight: z.number().gte(0),
 });
 
@@ -127,9 +127,10 @@ export const zCollectionMaterial = z.object({
   createdAt: z.iso.datetime(),
   gwCode: z.optional(z.string()),
   id: z.uuid(),
+  materialId: z.optional(z.union([z.uuid(), z.null()])),
   name: z.optional(z.string()),
   updatedAt: z.iso.datetime(),
-  value: z.number().gte(0),
+  value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
   weight: z.number().gte(0),
 });
 
@@ -164,7 +165,7 @@ export const zCreateCollectionMaterial = z.object({
   carbonFactor: z.string(),
   gwCode: z.string(),
   name: z.string(),
-  value: z.number().gte(0),
+  value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
   weight: z.number().gte(0),
 });
 
@@ -193,7 +194,7 @@ export const zCreateTransactionMaterial = z.object({
   carbonFactor: z.string(),
   gwCode: z.string(),
   name: z.string(),
-  value: z.number().gte(0),
+  value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
   weight: z.number().gte(0),
 });
 
@@ -274,9 +275,10 @@ export const zTransactionMaterial = z.object({
   createdAt: z.iso.datetime(),
   gwCode: z.optional(z.string()),
   id: z.uuid(),
+  materialId: z.optional(z.union([z.uuid(), z.null()])),
   name: z.optional(z.string()),
   updatedAt: z.iso.datetime(),
-  value: z.number().gte(0),
+  value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
   weight: z.number().gte(0),
 });
 
@@ -342,7 +344,7 @@ export const zSuccessResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -360,7 +362,7 @@ export const zSuccessResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -436,7 +438,7 @@ export const zSuccessResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -454,7 +456,7 @@ export const zSuccessResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -504,11 +506,9 @@ export const zUpdateCollection = z.object({
 });
 
 export const zUpdateCollectionMaterial = z.object({
-  carbonFactor: z.string(),
-  gwCode: z.string(),
-  name: z.string(),
-  value: z.union([z.number().gte(0), z.null()]),
-  weight: z.union([z.number().gte(0), z.null()]),
+  materialId: z.uuid(),
+  value: z.optional(z.union([z.number().gte(0), z.null()])),
+  weight: z.number().gte(0),
 });
 
 export const zUpdateMaterial = z.object({
@@ -533,11 +533,9 @@ export const zUpdateTransaction = z.object({
 });
 
 export const zUpdateTransactionMaterial = z.object({
-  carbonFactor: z.string(),
-  gwCode: z.string(),
-  name: z.string(),
-  value: z.union([z.number().gte(0), z.null()]),
-  weight: z.union([z.number().gte(0), z.null()]),
+  materialId: z.uuid(),
+  value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
+  weight: z.number().gte(0),
 });
 
 export const zUpdateUser = z.object({
@@ -565,6 +563,26 @@ export const zUpdateUser = z.object({
 
 export const zUsers = z.array(zUser);
 
+/**
+ * Create collection line payload
+ */
+export const zCreateCollectionLinePayload = z.object({
+  materialId: z.uuid(),
+  value: z.optional(
+    z.union([z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/), z.null()])
+  ),
+  weight: z.number().gte(0),
+});
+
+/**
+ * Create transaction line payload
+ */
+export const zCreateTransactionLinePayload = z.object({
+  materialId: z.uuid(),
+  value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
+  weight: z.number().gte(0),
+});
+
 /**
  * Login payload
  */
@@ -667,7 +685,7 @@ export const zGetApiAuthenticationCheckResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -685,7 +703,7 @@ export const zGetApiAuthenticationCheckResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -761,7 +779,7 @@ export const zGetApiAuthenticationCheckResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -779,7 +797,7 @@ export const zGetApiAuthenticationCheckResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -873,7 +891,7 @@ export const zPostApiAuthenticationLoginResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -891,7 +909,7 @@ export const zPostApiAuthenticationLoginResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -967,7 +985,7 @@ export const zPostApiAuthenticationLoginResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -985,7 +1003,7 @@ export const zPostApiAuthenticationLoginResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -1079,7 +1097,7 @@ export const zPostApiAuthenticationLogoutResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -1097,7 +1115,7 @@ export const zPostApiAuthenticationLogoutResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -1173,7 +1191,7 @@ export const zPostApiAuthenticationLogoutResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -1191,7 +1209,7 @@ export const zPostApiAuthenticationLogoutResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -1291,7 +1309,7 @@ export const zPostApiAuthenticationMfaVerifyResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -1309,7 +1327,7 @@ export const zPostApiAuthenticationMfaVerifyResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -1385,7 +1403,7 @@ export const zPostApiAuthenticationMfaVerifyResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -1403,7 +1421,7 @@ export const zPostApiAuthenticationMfaVerifyResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -1497,7 +1515,7 @@ export const zGetApiAuthenticationPermissionsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -1515,7 +1533,7 @@ export const zGetApiAuthenticationPermissionsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -1591,7 +1609,7 @@ export const zGetApiAuthenticationPermissionsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -1609,7 +1627,7 @@ export const zGetApiAuthenticationPermissionsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -1703,7 +1721,7 @@ export const zPostApiAuthenticationRegisterResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -1721,7 +1739,7 @@ export const zPostApiAuthenticationRegisterResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -1797,7 +1815,7 @@ export const zPostApiAuthenticationRegisterResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -1815,7 +1833,7 @@ export const zPostApiAuthenticationRegisterResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -1915,7 +1933,7 @@ export const zGetApiBusinessesResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -1933,7 +1951,7 @@ export const zGetApiBusinessesResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -2009,7 +2027,7 @@ export const zGetApiBusinessesResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -2027,7 +2045,7 @@ export const zGetApiBusinessesResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -2121,7 +2139,7 @@ export const zPostApiBusinessesResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -2139,7 +2157,7 @@ export const zPostApiBusinessesResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -2215,7 +2233,7 @@ export const zPostApiBusinessesResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -2233,7 +2251,7 @@ export const zPostApiBusinessesResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -2331,7 +2349,7 @@ export const zPostApiBusinessesAssignUserByBusinessIdByUserIdResponse =
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -2349,7 +2367,7 @@ export const zPostApiBusinessesAssignUserByBusinessIdByUserIdResponse =
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -2425,7 +2443,7 @@ export const zPostApiBusinessesAssignUserByBusinessIdByUserIdResponse =
             id: z.uuid(),
             name: z.optional(z.string()),
             updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
+            value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
             weight: z.number().gte(0),
           }),
           z.object({
@@ -2443,7 +2461,7 @@ export const zPostApiBusinessesAssignUserByBusinessIdByUserIdResponse =
             id: z.uuid(),
             name: z.optional(z.string()),
             updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
+            value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
             weight: z.number().gte(0),
           }),
           z.object({
@@ -2545,7 +2563,7 @@ export const zGetApiBusinessesListUsersByBusinessIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -2563,7 +2581,7 @@ export const zGetApiBusinessesListUsersByBusinessIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -2639,7 +2657,7 @@ export const zGetApiBusinessesListUsersByBusinessIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -2657,7 +2675,7 @@ export const zGetApiBusinessesListUsersByBusinessIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -2755,7 +2773,7 @@ export const zPostApiBusinessesUnassignUserByBusinessIdByUserIdResponse =
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -2773,7 +2791,7 @@ export const zPostApiBusinessesUnassignUserByBusinessIdByUserIdResponse =
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -2849,7 +2867,7 @@ export const zPostApiBusinessesUnassignUserByBusinessIdByUserIdResponse =
             id: z.uuid(),
             name: z.optional(z.string()),
             updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
+            value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
             weight: z.number().gte(0),
           }),
           z.object({
@@ -2867,7 +2885,7 @@ export const zPostApiBusinessesUnassignUserByBusinessIdByUserIdResponse =
             id: z.uuid(),
             name: z.optional(z.string()),
             updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
+            value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
             weight: z.number().gte(0),
           }),
           z.object({
@@ -2963,7 +2981,7 @@ export const zDeleteApiBusinessesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -2981,7 +2999,7 @@ export const zDeleteApiBusinessesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -3057,7 +3075,7 @@ export const zDeleteApiBusinessesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -3075,7 +3093,7 @@ export const zDeleteApiBusinessesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -3175,7 +3193,7 @@ export const zGetApiBusinessesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -3193,7 +3211,7 @@ export const zGetApiBusinessesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -3269,7 +3287,7 @@ export const zGetApiBusinessesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -3287,7 +3305,7 @@ export const zGetApiBusinessesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -3383,7 +3401,7 @@ export const zPutApiBusinessesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -3401,7 +3419,7 @@ export const zPutApiBusinessesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -3477,7 +3495,7 @@ export const zPutApiBusinessesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -3495,7 +3513,7 @@ export const zPutApiBusinessesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -3595,7 +3613,7 @@ export const zGetApiCollectionsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -3613,7 +3631,7 @@ export const zGetApiCollectionsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -3689,7 +3707,7 @@ export const zGetApiCollectionsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -3707,7 +3725,7 @@ export const zGetApiCollectionsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -3801,7 +3819,7 @@ export const zPostApiCollectionsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -3819,7 +3837,7 @@ export const zPostApiCollectionsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -3895,7 +3913,7 @@ export const zPostApiCollectionsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -3913,7 +3931,7 @@ export const zPostApiCollectionsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -3938,217 +3956,6 @@ export const zPostApiCollectionsResponse = z.object({
   pagination: z.optional(zPagination),
 });
 
-export const zPostApiCollectionsAssignMaterialByCollectionIdByMaterialIdData =
-  z.object({
-    body: z.optional(z.never()),
-    path: z.object({
-      collectionId: z.uuid(),
-      materialId: z.uuid(),
-    }),
-    query: z.optional(z.never()),
-  });
-
-/**
- * Material assigned to Collection successfully.
- */
-export const zPostApiCollectionsAssignMaterialByCollectionIdByMaterialIdResponse =
-  z.object({
-    item: z.optional(
-      z.union([
-        z.object({
-          address: zAddress,
-          bankDetails: zBankDetails,
-          businessId: z.union([z.uuid(), z.null()]),
-          businesses: z.array(zBusiness),
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          idNumber: z.union([z.string(), z.null()]),
-          mfaEnabled: z.boolean(),
-          mfaVerified: z.boolean(),
-          name: z.string(),
-          permissions: z.array(
-            z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-          ),
-          roles: zRoles,
-          type: z.enum(['system', 'collector', 'business']),
-          updatedAt: z.iso.datetime(),
-          username: z
-            .string()
-            .regex(
-              /(?:\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b)|(?:\b(?:\+?\d{1,3}[-.\s]?)?(?:\(?\d{2,4}\)?[-.\s]?)?\d{3,4}[-.\s]?\d{3,4}\b)/
-            ),
-        }),
-        z.object({
-          createdAt: z.iso.datetime(),
-          default: z.boolean(),
-          description: z.string(),
-          id: z.uuid(),
-          name: z.string(),
-          permissions: z.array(
-            z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-          ),
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.string(),
-          createdAt: z.iso.datetime(),
-          gwCode: z.string(),
-          id: z.uuid(),
-          name: z.string(),
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          buyer: zBusiness,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          materials: zCollectionMaterials,
-          seller: zUser,
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.optional(z.string()),
-          createdAt: z.iso.datetime(),
-          gwCode: z.optional(z.string()),
-          id: z.uuid(),
-          name: z.optional(z.string()),
-          updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
-          weight: z.number().gte(0),
-        }),
-        z.object({
-          buyer: zBusiness,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          materials: zTransactionMaterials,
-          seller: zUser,
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.optional(z.string()),
-          createdAt: z.iso.datetime(),
-          gwCode: z.optional(z.string()),
-          id: z.uuid(),
-          name: z.optional(z.string()),
-          updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
-          weight: z.number().gte(0),
-        }),
-        z.object({
-          address: zAddress,
-          bankDetails: zBankDetails,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          name: z.string(),
-          ownerId: z.uuid(),
-          roles: zRoles,
-          updatedAt: z.iso.datetime(),
-          users: z.array(zUser),
-        }),
-      ])
-    ),
-    items: z.optional(
-      z.array(
-        z.union([
-          z.object({
-            address: zAddress,
-            bankDetails: zBankDetails,
-            businessId: z.union([z.uuid(), z.null()]),
-            businesses: z.array(zBusiness),
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            idNumber: z.union([z.string(), z.null()]),
-            mfaEnabled: z.boolean(),
-            mfaVerified: z.boolean(),
-            name: z.string(),
-            permissions: z.array(
-              z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-            ),
-            roles: zRoles,
-            type: z.enum(['system', 'collector', 'business']),
-            updatedAt: z.iso.datetime(),
-            username: z
-              .string()
-              .regex(
-                /(?:\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b)|(?:\b(?:\+?\d{1,3}[-.\s]?)?(?:\(?\d{2,4}\)?[-.\s]?)?\d{3,4}[-.\s]?\d{3,4}\b)/
-              ),
-          }),
-          z.object({
-            createdAt: z.iso.datetime(),
-            default: z.boolean(),
-            description: z.string(),
-            id: z.uuid(),
-            name: z.string(),
-            permissions: z.array(
-              z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-            ),
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.string(),
-            createdAt: z.iso.datetime(),
-            gwCode: z.string(),
-            id: z.uuid(),
-            name: z.string(),
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            buyer: zBusiness,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            materials: zCollectionMaterials,
-            seller: zUser,
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.optional(z.string()),
-            createdAt: z.iso.datetime(),
-            gwCode: z.optional(z.string()),
-            id: z.uuid(),
-            name: z.optional(z.string()),
-            updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
-            weight: z.number().gte(0),
-          }),
-          z.object({
-            buyer: zBusiness,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            materials: zTransactionMaterials,
-            seller: zUser,
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.optional(z.string()),
-            createdAt: z.iso.datetime(),
-            gwCode: z.optional(z.string()),
-            id: z.uuid(),
-            name: z.optional(z.string()),
-            updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
-            weight: z.number().gte(0),
-          }),
-          z.object({
-            address: zAddress,
-            bankDetails: zBankDetails,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            name: z.string(),
-            ownerId: z.uuid(),
-            roles: zRoles,
-            updatedAt: z.iso.datetime(),
-            users: z.array(zUser),
-          }),
-          z.object({
-            name: z.string(),
-            permissions: zPermissions,
-            subGroups: z.array(zPermissionGroup),
-          }),
-        ])
-      )
-    ),
-    pagination: z.optional(zPagination),
-  });
-
 export const zGetApiCollectionsListMaterialsByCollectionIdData = z.object({
   body: z.optional(z.never()),
   path: z.object({
@@ -4226,7 +4033,7 @@ export const zGetApiCollectionsListMaterialsByCollectionIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -4244,7 +4051,7 @@ export const zGetApiCollectionsListMaterialsByCollectionIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -4320,7 +4127,7 @@ export const zGetApiCollectionsListMaterialsByCollectionIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -4338,7 +4145,7 @@ export const zGetApiCollectionsListMaterialsByCollectionIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -4438,7 +4245,7 @@ export const zGetApiCollectionsMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -4456,7 +4263,7 @@ export const zGetApiCollectionsMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -4532,7 +4339,7 @@ export const zGetApiCollectionsMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -4550,7 +4357,7 @@ export const zGetApiCollectionsMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -4575,16 +4382,18 @@ export const zGetApiCollectionsMaterialsResponse = z.object({
   pagination: z.optional(zPagination),
 });
 
-export const zPostApiCollectionsMaterialsData = z.object({
-  body: zCreateCollectionMaterial,
-  path: z.optional(z.never()),
+export const zPostApiCollectionsByCollectionIdMaterialsData = z.object({
+  body: zCreateCollectionLinePayload,
+  path: z.object({
+    collectionId: z.uuid(),
+  }),
   query: z.optional(z.never()),
 });
 
 /**
  * Collection Material created successfully.
  */
-export const zPostApiCollectionsMaterialsResponse = z.object({
+export const zPostApiCollectionsByCollectionIdMaterialsResponse = z.object({
   item: z.optional(
     z.union([
       z.object({
@@ -4644,7 +4453,7 @@ export const zPostApiCollectionsMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -4662,7 +4471,7 @@ export const zPostApiCollectionsMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -4738,7 +4547,7 @@ export const zPostApiCollectionsMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -4756,7 +4565,7 @@ export const zPostApiCollectionsMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -4852,7 +4661,7 @@ export const zDeleteApiCollectionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -4870,7 +4679,7 @@ export const zDeleteApiCollectionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -4946,7 +4755,7 @@ export const zDeleteApiCollectionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -4964,7 +4773,7 @@ export const zDeleteApiCollectionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -5064,7 +4873,7 @@ export const zGetApiCollectionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -5082,7 +4891,7 @@ export const zGetApiCollectionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -5158,7 +4967,7 @@ export const zGetApiCollectionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -5176,7 +4985,7 @@ export const zGetApiCollectionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -5272,7 +5081,7 @@ export const zPutApiCollectionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -5290,7 +5099,7 @@ export const zPutApiCollectionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -5366,7 +5175,7 @@ export const zPutApiCollectionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -5384,7 +5193,7 @@ export const zPutApiCollectionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -5409,227 +5218,16 @@ export const zPutApiCollectionsMaterialsByIdResponse = z.object({
   pagination: z.optional(zPagination),
 });
 
-export const zPostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdData =
-  z.object({
-    body: z.optional(z.never()),
-    path: z.object({
-      collectionId: z.uuid(),
-      materialId: z.uuid(),
-    }),
-    query: z.optional(z.never()),
-  });
+export const zDeleteApiCollectionsByIdData = z.object({
+  body: z.optional(z.never()),
+  path: z.object({
+    id: z.uuid(),
+  }),
+  query: z.optional(z.never()),
+});
 
 /**
- * Material unassigned from Collection successfully.
- */
-export const zPostApiCollectionsUnassignMaterialByCollectionIdByMaterialIdResponse =
-  z.object({
-    item: z.optional(
-      z.union([
-        z.object({
-          address: zAddress,
-          bankDetails: zBankDetails,
-          businessId: z.union([z.uuid(), z.null()]),
-          businesses: z.array(zBusiness),
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          idNumber: z.union([z.string(), z.null()]),
-          mfaEnabled: z.boolean(),
-          mfaVerified: z.boolean(),
-          name: z.string(),
-          permissions: z.array(
-            z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-          ),
-          roles: zRoles,
-          type: z.enum(['system', 'collector', 'business']),
-          updatedAt: z.iso.datetime(),
-          username: z
-            .string()
-            .regex(
-              /(?:\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b)|(?:\b(?:\+?\d{1,3}[-.\s]?)?(?:\(?\d{2,4}\)?[-.\s]?)?\d{3,4}[-.\s]?\d{3,4}\b)/
-            ),
-        }),
-        z.object({
-          createdAt: z.iso.datetime(),
-          default: z.boolean(),
-          description: z.string(),
-          id: z.uuid(),
-          name: z.string(),
-          permissions: z.array(
-            z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-          ),
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.string(),
-          createdAt: z.iso.datetime(),
-          gwCode: z.string(),
-          id: z.uuid(),
-          name: z.string(),
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          buyer: zBusiness,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          materials: zCollectionMaterials,
-          seller: zUser,
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.optional(z.string()),
-          createdAt: z.iso.datetime(),
-          gwCode: z.optional(z.string()),
-          id: z.uuid(),
-          name: z.optional(z.string()),
-          updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
-          weight: z.number().gte(0),
-        }),
-        z.object({
-          buyer: zBusiness,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          materials: zTransactionMaterials,
-          seller: zUser,
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.optional(z.string()),
-          createdAt: z.iso.datetime(),
-          gwCode: z.optional(z.string()),
-          id: z.uuid(),
-          name: z.optional(z.string()),
-          updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
-          weight: z.number().gte(0),
-        }),
-        z.object({
-          address: zAddress,
-          bankDetails: zBankDetails,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          name: z.string(),
-          ownerId: z.uuid(),
-          roles: zRoles,
-          updatedAt: z.iso.datetime(),
-          users: z.array(zUser),
-        }),
-      ])
-    ),
-    items: z.optional(
-      z.array(
-        z.union([
-          z.object({
-            address: zAddress,
-            bankDetails: zBankDetails,
-            businessId: z.union([z.uuid(), z.null()]),
-            businesses: z.array(zBusiness),
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            idNumber: z.union([z.string(), z.null()]),
-            mfaEnabled: z.boolean(),
-            mfaVerified: z.boolean(),
-            name: z.string(),
-            permissions: z.array(
-              z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-            ),
-            roles: zRoles,
-            type: z.enum(['system', 'collector', 'business']),
-            updatedAt: z.iso.datetime(),
-            username: z
-              .string()
-              .regex(
-                /(?:\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b)|(?:\b(?:\+?\d{1,3}[-.\s]?)?(?:\(?\d{2,4}\)?[-.\s]?)?\d{3,4}[-.\s]?\d{3,4}\b)/
-              ),
-          }),
-          z.object({
-            createdAt: z.iso.datetime(),
-            default: z.boolean(),
-            description: z.string(),
-            id: z.uuid(),
-            name: z.string(),
-            permissions: z.array(
-              z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-            ),
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.string(),
-            createdAt: z.iso.datetime(),
-            gwCode: z.string(),
-            id: z.uuid(),
-            name: z.string(),
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            buyer: zBusiness,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            materials: zCollectionMaterials,
-            seller: zUser,
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.optional(z.string()),
-            createdAt: z.iso.datetime(),
-            gwCode: z.optional(z.string()),
-            id: z.uuid(),
-            name: z.optional(z.string()),
-            updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
-            weight: z.number().gte(0),
-          }),
-          z.object({
-            buyer: zBusiness,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            materials: zTransactionMaterials,
-            seller: zUser,
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.optional(z.string()),
-            createdAt: z.iso.datetime(),
-            gwCode: z.optional(z.string()),
-            id: z.uuid(),
-            name: z.optional(z.string()),
-            updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
-            weight: z.number().gte(0),
-          }),
-          z.object({
-            address: zAddress,
-            bankDetails: zBankDetails,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            name: z.string(),
-            ownerId: z.uuid(),
-            roles: zRoles,
-            updatedAt: z.iso.datetime(),
-            users: z.array(zUser),
-          }),
-          z.object({
-            name: z.string(),
-            permissions: zPermissions,
-            subGroups: z.array(zPermissionGroup),
-          }),
-        ])
-      )
-    ),
-    pagination: z.optional(zPagination),
-  });
-
-export const zDeleteApiCollectionsByIdData = z.object({
-  body: z.optional(z.never()),
-  path: z.object({
-    id: z.uuid(),
-  }),
-  query: z.optional(z.never()),
-});
-
-/**
- * Collection deleted successfully.
+ * Collection deleted successfully.
  */
 export const zDeleteApiCollectionsByIdResponse = z.object({
   item: z.optional(
@@ -5691,7 +5289,7 @@ export const zDeleteApiCollectionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -5709,7 +5307,7 @@ export const zDeleteApiCollectionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -5785,7 +5383,7 @@ export const zDeleteApiCollectionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -5803,7 +5401,7 @@ export const zDeleteApiCollectionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -5903,7 +5501,7 @@ export const zGetApiCollectionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -5921,7 +5519,7 @@ export const zGetApiCollectionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -5997,7 +5595,7 @@ export const zGetApiCollectionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -6015,7 +5613,7 @@ export const zGetApiCollectionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -6111,7 +5709,7 @@ export const zPutApiCollectionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -6129,7 +5727,7 @@ export const zPutApiCollectionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -6205,7 +5803,7 @@ export const zPutApiCollectionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -6223,7 +5821,7 @@ export const zPutApiCollectionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -6323,7 +5921,7 @@ export const zGetApiMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -6341,7 +5939,7 @@ export const zGetApiMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -6417,7 +6015,7 @@ export const zGetApiMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -6435,7 +6033,7 @@ export const zGetApiMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -6529,7 +6127,7 @@ export const zPostApiMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -6547,7 +6145,7 @@ export const zPostApiMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -6623,7 +6221,7 @@ export const zPostApiMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -6641,7 +6239,7 @@ export const zPostApiMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -6737,7 +6335,7 @@ export const zDeleteApiMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -6755,7 +6353,7 @@ export const zDeleteApiMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -6831,7 +6429,7 @@ export const zDeleteApiMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -6849,7 +6447,7 @@ export const zDeleteApiMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -6949,7 +6547,7 @@ export const zGetApiMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -6967,7 +6565,7 @@ export const zGetApiMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -7043,7 +6641,7 @@ export const zGetApiMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -7061,7 +6659,7 @@ export const zGetApiMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -7157,7 +6755,7 @@ export const zPutApiMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -7175,7 +6773,7 @@ export const zPutApiMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -7251,7 +6849,7 @@ export const zPutApiMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -7269,7 +6867,7 @@ export const zPutApiMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -7369,7 +6967,7 @@ export const zGetApiRolesResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -7387,7 +6985,7 @@ export const zGetApiRolesResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -7463,7 +7061,7 @@ export const zGetApiRolesResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -7481,7 +7079,7 @@ export const zGetApiRolesResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -7575,7 +7173,7 @@ export const zPostApiRolesResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -7593,7 +7191,7 @@ export const zPostApiRolesResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -7669,7 +7267,7 @@ export const zPostApiRolesResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -7687,7 +7285,7 @@ export const zPostApiRolesResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -7783,7 +7381,7 @@ export const zDeleteApiRolesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -7801,7 +7399,7 @@ export const zDeleteApiRolesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -7877,7 +7475,7 @@ export const zDeleteApiRolesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -7895,7 +7493,7 @@ export const zDeleteApiRolesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -7995,7 +7593,7 @@ export const zGetApiRolesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -8013,7 +7611,7 @@ export const zGetApiRolesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -8089,7 +7687,7 @@ export const zGetApiRolesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -8107,7 +7705,7 @@ export const zGetApiRolesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -8203,7 +7801,7 @@ export const zPutApiRolesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -8221,7 +7819,7 @@ export const zPutApiRolesByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -8297,7 +7895,7 @@ export const zPutApiRolesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -8315,7 +7913,7 @@ export const zPutApiRolesByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -8415,7 +8013,7 @@ export const zGetApiTransactionsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -8433,7 +8031,7 @@ export const zGetApiTransactionsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -8509,7 +8107,7 @@ export const zGetApiTransactionsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -8527,7 +8125,7 @@ export const zGetApiTransactionsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -8621,7 +8219,7 @@ export const zPostApiTransactionsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -8639,7 +8237,7 @@ export const zPostApiTransactionsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -8715,7 +8313,7 @@ export const zPostApiTransactionsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -8733,7 +8331,7 @@ export const zPostApiTransactionsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -8758,21 +8356,25 @@ export const zPostApiTransactionsResponse = z.object({
   pagination: z.optional(zPagination),
 });
 
-export const zPostApiTransactionsAssignMaterialByTransactionIdByMaterialIdData =
-  z.object({
-    body: z.optional(z.never()),
-    path: z.object({
-      transactionId: z.uuid(),
-      materialId: z.uuid(),
-    }),
-    query: z.optional(z.never()),
-  });
+export const zGetApiTransactionsListMaterialsByTransactionIdData = z.object({
+  body: z.optional(z.never()),
+  path: z.object({
+    transactionId: z.uuid(),
+  }),
+  query: z.object({
+    page: z.coerce.bigint().default(BigInt(1)),
+    pageSize: z.coerce.bigint().default(BigInt(10)),
+    preload: z.optional(z.array(z.string())),
+    searchTerm: z.optional(z.string()),
+    searchColumn: z.optional(z.array(z.string())),
+  }),
+});
 
 /**
- * Material assigned to Transaction successfully.
+ * Material retrieved from Transaction successfully.
  */
-export const zPostApiTransactionsAssignMaterialByTransactionIdByMaterialIdResponse =
-  z.object({
+export const zGetApiTransactionsListMaterialsByTransactionIdResponse = z.object(
+  {
     item: z.optional(
       z.union([
         z.object({
@@ -8832,7 +8434,7 @@ export const zPostApiTransactionsAssignMaterialByTransactionIdByMaterialIdRespon
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -8850,7 +8452,7 @@ export const zPostApiTransactionsAssignMaterialByTransactionIdByMaterialIdRespon
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -8926,7 +8528,7 @@ export const zPostApiTransactionsAssignMaterialByTransactionIdByMaterialIdRespon
             id: z.uuid(),
             name: z.optional(z.string()),
             updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
+            value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
             weight: z.number().gte(0),
           }),
           z.object({
@@ -8944,7 +8546,7 @@ export const zPostApiTransactionsAssignMaterialByTransactionIdByMaterialIdRespon
             id: z.uuid(),
             name: z.optional(z.string()),
             updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
+            value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
             weight: z.number().gte(0),
           }),
           z.object({
@@ -8967,13 +8569,12 @@ export const zPostApiTransactionsAssignMaterialByTransactionIdByMaterialIdRespon
       )
     ),
     pagination: z.optional(zPagination),
-  });
+  }
+);
 
-export const zGetApiTransactionsListMaterialsByTransactionIdData = z.object({
+export const zGetApiTransactionsMaterialsData = z.object({
   body: z.optional(z.never()),
-  path: z.object({
-    transactionId: z.uuid(),
-  }),
+  path: z.optional(z.never()),
   query: z.object({
     page: z.coerce.bigint().default(BigInt(1)),
     pageSize: z.coerce.bigint().default(BigInt(10)),
@@ -8984,246 +8585,32 @@ export const zGetApiTransactionsListMaterialsByTransactionIdData = z.object({
 });
 
 /**
- * Material retrieved from Transaction successfully.
+ * Transaction Material's retrieved successfully.
  */
-export const zGetApiTransactionsListMaterialsByTransactionIdResponse = z.object(
-  {
-    item: z.optional(
-      z.union([
-        z.object({
-          address: zAddress,
-          bankDetails: zBankDetails,
-          businessId: z.union([z.uuid(), z.null()]),
-          businesses: z.array(zBusiness),
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          idNumber: z.union([z.string(), z.null()]),
-          mfaEnabled: z.boolean(),
-          mfaVerified: z.boolean(),
-          name: z.string(),
-          permissions: z.array(
-            z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-          ),
-          roles: zRoles,
-          type: z.enum(['system', 'collector', 'business']),
-          updatedAt: z.iso.datetime(),
-          username: z
-            .string()
-            .regex(
-              /(?:\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b)|(?:\b(?:\+?\d{1,3}[-.\s]?)?(?:\(?\d{2,4}\)?[-.\s]?)?\d{3,4}[-.\s]?\d{3,4}\b)/
-            ),
-        }),
-        z.object({
-          createdAt: z.iso.datetime(),
-          default: z.boolean(),
-          description: z.string(),
-          id: z.uuid(),
-          name: z.string(),
-          permissions: z.array(
-            z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-          ),
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.string(),
-          createdAt: z.iso.datetime(),
-          gwCode: z.string(),
-          id: z.uuid(),
-          name: z.string(),
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          buyer: zBusiness,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          materials: zCollectionMaterials,
-          seller: zUser,
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.optional(z.string()),
-          createdAt: z.iso.datetime(),
-          gwCode: z.optional(z.string()),
-          id: z.uuid(),
-          name: z.optional(z.string()),
-          updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
-          weight: z.number().gte(0),
-        }),
-        z.object({
-          buyer: zBusiness,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          materials: zTransactionMaterials,
-          seller: zUser,
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.optional(z.string()),
-          createdAt: z.iso.datetime(),
-          gwCode: z.optional(z.string()),
-          id: z.uuid(),
-          name: z.optional(z.string()),
-          updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
-          weight: z.number().gte(0),
-        }),
-        z.object({
-          address: zAddress,
-          bankDetails: zBankDetails,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          name: z.string(),
-          ownerId: z.uuid(),
-          roles: zRoles,
-          updatedAt: z.iso.datetime(),
-          users: z.array(zUser),
-        }),
-      ])
-    ),
-    items: z.optional(
-      z.array(
-        z.union([
-          z.object({
-            address: zAddress,
-            bankDetails: zBankDetails,
-            businessId: z.union([z.uuid(), z.null()]),
-            businesses: z.array(zBusiness),
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            idNumber: z.union([z.string(), z.null()]),
-            mfaEnabled: z.boolean(),
-            mfaVerified: z.boolean(),
-            name: z.string(),
-            permissions: z.array(
-              z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-            ),
-            roles: zRoles,
-            type: z.enum(['system', 'collector', 'business']),
-            updatedAt: z.iso.datetime(),
-            username: z
-              .string()
-              .regex(
-                /(?:\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b)|(?:\b(?:\+?\d{1,3}[-.\s]?)?(?:\(?\d{2,4}\)?[-.\s]?)?\d{3,4}[-.\s]?\d{3,4}\b)/
-              ),
-          }),
-          z.object({
-            createdAt: z.iso.datetime(),
-            default: z.boolean(),
-            description: z.string(),
-            id: z.uuid(),
-            name: z.string(),
-            permissions: z.array(
-              z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-            ),
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.string(),
-            createdAt: z.iso.datetime(),
-            gwCode: z.string(),
-            id: z.uuid(),
-            name: z.string(),
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            buyer: zBusiness,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            materials: zCollectionMaterials,
-            seller: zUser,
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.optional(z.string()),
-            createdAt: z.iso.datetime(),
-            gwCode: z.optional(z.string()),
-            id: z.uuid(),
-            name: z.optional(z.string()),
-            updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
-            weight: z.number().gte(0),
-          }),
-          z.object({
-            buyer: zBusiness,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            materials: zTransactionMaterials,
-            seller: zUser,
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.optional(z.string()),
-            createdAt: z.iso.datetime(),
-            gwCode: z.optional(z.string()),
-            id: z.uuid(),
-            name: z.optional(z.string()),
-            updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
-            weight: z.number().gte(0),
-          }),
-          z.object({
-            address: zAddress,
-            bankDetails: zBankDetails,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            name: z.string(),
-            ownerId: z.uuid(),
-            roles: zRoles,
-            updatedAt: z.iso.datetime(),
-            users: z.array(zUser),
-          }),
-          z.object({
-            name: z.string(),
-            permissions: zPermissions,
-            subGroups: z.array(zPermissionGroup),
-          }),
-        ])
-      )
-    ),
-    pagination: z.optional(zPagination),
-  }
-);
-
-export const zGetApiTransactionsMaterialsData = z.object({
-  body: z.optional(z.never()),
-  path: z.optional(z.never()),
-  query: z.object({
-    page: z.coerce.bigint().default(BigInt(1)),
-    pageSize: z.coerce.bigint().default(BigInt(10)),
-    preload: z.optional(z.array(z.string())),
-    searchTerm: z.optional(z.string()),
-    searchColumn: z.optional(z.array(z.string())),
-  }),
-});
-
-/**
- * Transaction Material's retrieved successfully.
- */
-export const zGetApiTransactionsMaterialsResponse = z.object({
-  item: z.optional(
-    z.union([
-      z.object({
-        address: zAddress,
-        bankDetails: zBankDetails,
-        businessId: z.union([z.uuid(), z.null()]),
-        businesses: z.array(zBusiness),
-        createdAt: z.iso.datetime(),
-        id: z.uuid(),
-        idNumber: z.union([z.string(), z.null()]),
-        mfaEnabled: z.boolean(),
-        mfaVerified: z.boolean(),
-        name: z.string(),
-        permissions: z.array(
-          z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-        ),
-        roles: zRoles,
-        type: z.enum(['system', 'collector', 'business']),
-        updatedAt: z.iso.datetime(),
-        username: z
-          .string()
-          .regex(
-            /(?:\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b)|(?:\b(?:\+?\d{1,3}[-.\s]?)?(?:\(?\d{2,4}\)?[-.\s]?)?\d{3,4}[-.\s]?\d{3,4}\b)/
+export const zGetApiTransactionsMaterialsResponse = z.object({
+  item: z.optional(
+    z.union([
+      z.object({
+        address: zAddress,
+        bankDetails: zBankDetails,
+        businessId: z.union([z.uuid(), z.null()]),
+        businesses: z.array(zBusiness),
+        createdAt: z.iso.datetime(),
+        id: z.uuid(),
+        idNumber: z.union([z.string(), z.null()]),
+        mfaEnabled: z.boolean(),
+        mfaVerified: z.boolean(),
+        name: z.string(),
+        permissions: z.array(
+          z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
+        ),
+        roles: zRoles,
+        type: z.enum(['system', 'collector', 'business']),
+        updatedAt: z.iso.datetime(),
+        username: z
+          .string()
+          .regex(
+            /(?:\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b)|(?:\b(?:\+?\d{1,3}[-.\s]?)?(?:\(?\d{2,4}\)?[-.\s]?)?\d{3,4}[-.\s]?\d{3,4}\b)/
           ),
       }),
       z.object({
@@ -9260,7 +8647,7 @@ export const zGetApiTransactionsMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -9278,7 +8665,7 @@ export const zGetApiTransactionsMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -9354,7 +8741,7 @@ export const zGetApiTransactionsMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -9372,7 +8759,7 @@ export const zGetApiTransactionsMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -9397,16 +8784,18 @@ export const zGetApiTransactionsMaterialsResponse = z.object({
   pagination: z.optional(zPagination),
 });
 
-export const zPostApiTransactionsMaterialsData = z.object({
-  body: zCreateTransactionMaterial,
-  path: z.optional(z.never()),
+export const zPostApiTransactionsByTransactionIdMaterialsData = z.object({
+  body: zCreateTransactionLinePayload,
+  path: z.object({
+    transactionId: z.uuid(),
+  }),
   query: z.optional(z.never()),
 });
 
 /**
  * Transaction Material created successfully.
  */
-export const zPostApiTransactionsMaterialsResponse = z.object({
+export const zPostApiTransactionsByTransactionIdMaterialsResponse = z.object({
   item: z.optional(
     z.union([
       z.object({
@@ -9466,7 +8855,7 @@ export const zPostApiTransactionsMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -9484,7 +8873,7 @@ export const zPostApiTransactionsMaterialsResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -9560,7 +8949,7 @@ export const zPostApiTransactionsMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -9578,7 +8967,7 @@ export const zPostApiTransactionsMaterialsResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -9674,7 +9063,7 @@ export const zDeleteApiTransactionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -9692,7 +9081,7 @@ export const zDeleteApiTransactionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -9768,7 +9157,7 @@ export const zDeleteApiTransactionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -9786,7 +9175,7 @@ export const zDeleteApiTransactionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -9886,7 +9275,7 @@ export const zGetApiTransactionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -9904,7 +9293,7 @@ export const zGetApiTransactionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -9980,7 +9369,7 @@ export const zGetApiTransactionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -9998,7 +9387,7 @@ export const zGetApiTransactionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -10094,7 +9483,7 @@ export const zPutApiTransactionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -10112,7 +9501,7 @@ export const zPutApiTransactionsMaterialsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -10188,7 +9577,7 @@ export const zPutApiTransactionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -10206,7 +9595,7 @@ export const zPutApiTransactionsMaterialsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -10231,217 +9620,6 @@ export const zPutApiTransactionsMaterialsByIdResponse = z.object({
   pagination: z.optional(zPagination),
 });
 
-export const zPostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdData =
-  z.object({
-    body: z.optional(z.never()),
-    path: z.object({
-      transactionId: z.uuid(),
-      materialId: z.uuid(),
-    }),
-    query: z.optional(z.never()),
-  });
-
-/**
- * Material unassigned from Transaction successfully.
- */
-export const zPostApiTransactionsUnassignMaterialByTransactionIdByMaterialIdResponse =
-  z.object({
-    item: z.optional(
-      z.union([
-        z.object({
-          address: zAddress,
-          bankDetails: zBankDetails,
-          businessId: z.union([z.uuid(), z.null()]),
-          businesses: z.array(zBusiness),
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          idNumber: z.union([z.string(), z.null()]),
-          mfaEnabled: z.boolean(),
-          mfaVerified: z.boolean(),
-          name: z.string(),
-          permissions: z.array(
-            z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-          ),
-          roles: zRoles,
-          type: z.enum(['system', 'collector', 'business']),
-          updatedAt: z.iso.datetime(),
-          username: z
-            .string()
-            .regex(
-              /(?:\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b)|(?:\b(?:\+?\d{1,3}[-.\s]?)?(?:\(?\d{2,4}\)?[-.\s]?)?\d{3,4}[-.\s]?\d{3,4}\b)/
-            ),
-        }),
-        z.object({
-          createdAt: z.iso.datetime(),
-          default: z.boolean(),
-          description: z.string(),
-          id: z.uuid(),
-          name: z.string(),
-          permissions: z.array(
-            z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-          ),
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.string(),
-          createdAt: z.iso.datetime(),
-          gwCode: z.string(),
-          id: z.uuid(),
-          name: z.string(),
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          buyer: zBusiness,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          materials: zCollectionMaterials,
-          seller: zUser,
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.optional(z.string()),
-          createdAt: z.iso.datetime(),
-          gwCode: z.optional(z.string()),
-          id: z.uuid(),
-          name: z.optional(z.string()),
-          updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
-          weight: z.number().gte(0),
-        }),
-        z.object({
-          buyer: zBusiness,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          materials: zTransactionMaterials,
-          seller: zUser,
-          updatedAt: z.iso.datetime(),
-        }),
-        z.object({
-          carbonFactor: z.optional(z.string()),
-          createdAt: z.iso.datetime(),
-          gwCode: z.optional(z.string()),
-          id: z.uuid(),
-          name: z.optional(z.string()),
-          updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
-          weight: z.number().gte(0),
-        }),
-        z.object({
-          address: zAddress,
-          bankDetails: zBankDetails,
-          createdAt: z.iso.datetime(),
-          id: z.uuid(),
-          name: z.string(),
-          ownerId: z.uuid(),
-          roles: zRoles,
-          updatedAt: z.iso.datetime(),
-          users: z.array(zUser),
-        }),
-      ])
-    ),
-    items: z.optional(
-      z.array(
-        z.union([
-          z.object({
-            address: zAddress,
-            bankDetails: zBankDetails,
-            businessId: z.union([z.uuid(), z.null()]),
-            businesses: z.array(zBusiness),
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            idNumber: z.union([z.string(), z.null()]),
-            mfaEnabled: z.boolean(),
-            mfaVerified: z.boolean(),
-            name: z.string(),
-            permissions: z.array(
-              z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-            ),
-            roles: zRoles,
-            type: z.enum(['system', 'collector', 'business']),
-            updatedAt: z.iso.datetime(),
-            username: z
-              .string()
-              .regex(
-                /(?:\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b)|(?:\b(?:\+?\d{1,3}[-.\s]?)?(?:\(?\d{2,4}\)?[-.\s]?)?\d{3,4}[-.\s]?\d{3,4}\b)/
-              ),
-          }),
-          z.object({
-            createdAt: z.iso.datetime(),
-            default: z.boolean(),
-            description: z.string(),
-            id: z.uuid(),
-            name: z.string(),
-            permissions: z.array(
-              z.string().regex(/^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$/)
-            ),
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.string(),
-            createdAt: z.iso.datetime(),
-            gwCode: z.string(),
-            id: z.uuid(),
-            name: z.string(),
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            buyer: zBusiness,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            materials: zCollectionMaterials,
-            seller: zUser,
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.optional(z.string()),
-            createdAt: z.iso.datetime(),
-            gwCode: z.optional(z.string()),
-            id: z.uuid(),
-            name: z.optional(z.string()),
-            updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
-            weight: z.number().gte(0),
-          }),
-          z.object({
-            buyer: zBusiness,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            materials: zTransactionMaterials,
-            seller: zUser,
-            updatedAt: z.iso.datetime(),
-          }),
-          z.object({
-            carbonFactor: z.optional(z.string()),
-            createdAt: z.iso.datetime(),
-            gwCode: z.optional(z.string()),
-            id: z.uuid(),
-            name: z.optional(z.string()),
-            updatedAt: z.iso.datetime(),
-            value: z.number().gte(0),
-            weight: z.number().gte(0),
-          }),
-          z.object({
-            address: zAddress,
-            bankDetails: zBankDetails,
-            createdAt: z.iso.datetime(),
-            id: z.uuid(),
-            name: z.string(),
-            ownerId: z.uuid(),
-            roles: zRoles,
-            updatedAt: z.iso.datetime(),
-            users: z.array(zUser),
-          }),
-          z.object({
-            name: z.string(),
-            permissions: zPermissions,
-            subGroups: z.array(zPermissionGroup),
-          }),
-        ])
-      )
-    ),
-    pagination: z.optional(zPagination),
-  });
-
 export const zDeleteApiTransactionsByIdData = z.object({
   body: z.optional(z.never()),
   path: z.object({
@@ -10513,7 +9691,7 @@ export const zDeleteApiTransactionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -10531,7 +9709,7 @@ export const zDeleteApiTransactionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -10607,7 +9785,7 @@ export const zDeleteApiTransactionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -10625,7 +9803,7 @@ export const zDeleteApiTransactionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -10725,7 +9903,7 @@ export const zGetApiTransactionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -10743,7 +9921,7 @@ export const zGetApiTransactionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -10819,7 +9997,7 @@ export const zGetApiTransactionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -10837,7 +10015,7 @@ export const zGetApiTransactionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -10933,7 +10111,7 @@ export const zPutApiTransactionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -10951,7 +10129,7 @@ export const zPutApiTransactionsByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -11027,7 +10205,7 @@ export const zPutApiTransactionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -11045,7 +10223,7 @@ export const zPutApiTransactionsByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -11145,7 +10323,7 @@ export const zGetApiUsersResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -11163,7 +10341,7 @@ export const zGetApiUsersResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -11239,7 +10417,7 @@ export const zGetApiUsersResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -11257,7 +10435,7 @@ export const zGetApiUsersResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -11351,7 +10529,7 @@ export const zPostApiUsersResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -11369,7 +10547,7 @@ export const zPostApiUsersResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -11445,7 +10623,7 @@ export const zPostApiUsersResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -11463,7 +10641,7 @@ export const zPostApiUsersResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -11560,7 +10738,7 @@ export const zPostApiUsersAssignRoleByUserIdByRoleIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -11578,7 +10756,7 @@ export const zPostApiUsersAssignRoleByUserIdByRoleIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -11654,7 +10832,7 @@ export const zPostApiUsersAssignRoleByUserIdByRoleIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -11672,7 +10850,7 @@ export const zPostApiUsersAssignRoleByUserIdByRoleIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -11774,7 +10952,7 @@ export const zGetApiUsersListRolesByUserIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -11792,7 +10970,7 @@ export const zGetApiUsersListRolesByUserIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -11868,7 +11046,7 @@ export const zGetApiUsersListRolesByUserIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -11886,7 +11064,7 @@ export const zGetApiUsersListRolesByUserIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -11983,7 +11161,7 @@ export const zPostApiUsersUnassignRoleByUserIdByRoleIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -12001,7 +11179,7 @@ export const zPostApiUsersUnassignRoleByUserIdByRoleIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -12077,7 +11255,7 @@ export const zPostApiUsersUnassignRoleByUserIdByRoleIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -12095,7 +11273,7 @@ export const zPostApiUsersUnassignRoleByUserIdByRoleIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -12191,7 +11369,7 @@ export const zDeleteApiUsersByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -12209,7 +11387,7 @@ export const zDeleteApiUsersByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -12285,7 +11463,7 @@ export const zDeleteApiUsersByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -12303,7 +11481,7 @@ export const zDeleteApiUsersByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -12403,7 +11581,7 @@ export const zGetApiUsersByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -12421,7 +11599,7 @@ export const zGetApiUsersByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -12497,7 +11675,7 @@ export const zGetApiUsersByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -12515,7 +11693,7 @@ export const zGetApiUsersByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -12611,7 +11789,7 @@ export const zPutApiUsersByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -12629,7 +11807,7 @@ export const zPutApiUsersByIdResponse = z.object({
         id: z.uuid(),
         name: z.optional(z.string()),
         updatedAt: z.iso.datetime(),
-        value: z.number().gte(0),
+        value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
         weight: z.number().gte(0),
       }),
       z.object({
@@ -12705,7 +11883,7 @@ export const zPutApiUsersByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -12723,7 +11901,7 @@ export const zPutApiUsersByIdResponse = z.object({
           id: z.uuid(),
           name: z.optional(z.string()),
           updatedAt: z.iso.datetime(),
-          value: z.number().gte(0),
+          value: z.string().regex(/^-?[0-9]+(\.[0-9]+)?$/),
           weight: z.number().gte(0),
         }),
         z.object({
@@ -23,6 +23,40 @@ export const apiClient = createClient(
   })
 );
 
+const csrfCookie = import.meta.env.VITE_CSRF_COOKIE || 'threereco_csrf';
+const csrfHeader = import.meta.env.VITE_CSRF_HEADER || 'X-CSRF-Token';
+const safeMethods = ['GET', 'HEAD', 'OPTIONS', 'TRACE'];
+
+// The API rejects state-changing requests that don't echo the session's csrf
+// token, which it hands out in a cookie scripts can read.
+export function getCsrfToken(): string | undefined {
+  const prefix = `${csrfCookie}=`;
+
+  for (const cookie of document.cookie.split(';')) {
+    const trimmed = cookie.trim();
+
+    if (trimmed.startsWith(prefix)) {
+      return decodeURIComponent(trimmed.slice(prefix.length));
+    }
+  }
+
+  return undefined;
+}
+
+apiClient.interceptors.request.use((request) => {
+  if (safeMethods.includes(request.method.toUpperCase())) {
+    return request;
+  }
+
+  const csrfToken = getCsrfToken();
+
+  if (csrfToken) {
+    request.headers.set(csrfHeader, csrfToken);
+  }
+
+  return request;
+});
+
 export async function getUser(): Promise<{
   user?: User;
   error: boolean;
@@ -1,7 +1,6 @@
 import {
   deleteApiCollectionsMaterialsByIdMutation,
-  postApiCollectionsAssignMaterialByCollectionIdByMaterialIdMutation,
-  postApiCollectionsMaterialsMutation,
+  postApiCollectionsByCollectionIdMaterialsMutation,
   putApiCollectionsByIdMutation,
   putApiCollectionsMaterialsByIdMutation,
 } from '@/api-client/@tanstack/react-query.gen';
@@ -35,7 +34,6 @@ import {
   getApiUsers,
 } from '@/api-client';
 import {
-  zCreateCollectionMaterial,
   zUpdateCollection,
   zUpdateCollectionMaterial,
 } from '@/api-client/zod.gen';
@@ -127,7 +125,7 @@ export const materialsColumns: ColumnDef<CollectionMaterial>[] = [
         placeholder="Value"
         decimalScale={2}
         fixedDecimalScale
-        defaultValue={row.getValue<number>('Value')}
+        defaultValue={Number(row.getValue<string>('Value'))}
         onValueChange={(value) => {
           if (!value) return;
           table.options.meta?.updateValue(row.getValue('Actions'), value);
@@ -304,27 +302,8 @@ function RouteComponent() {
     },
   });
 
-  const assignCollectionMaterialMutation = useMutation({
-    ...postApiCollectionsAssignMaterialByCollectionIdByMaterialIdMutation({
-      client: apiClient,
-    }),
-    onError: ({ error, message }: ErrorResponse) =>
-      toast.error(error, {
-        description: message,
-        duration: 2000,
-      }),
-    onSuccess: () => {
-      toast.success('Success', {
-        description: 'The collection material has been assigned.',
-        duration: 2000,
-      });
-
-      return router.invalidate();
-    },
-  });
-
   const createCollectionMaterialMutation = useMutation({
-    ...postApiCollectionsMaterialsMutation({
+    ...postApiCollectionsByCollectionIdMaterialsMutation({
       client: apiClient,
     }),
     onError: ({ error, message }: ErrorResponse) =>
@@ -332,18 +311,13 @@ function RouteComponent() {
         description: message,
         duration: 2000,
       }),
-    onSuccess: (data) => {
+    onSuccess: () => {
       toast.success('Success', {
         description: 'The collection material has been added.',
         duration: 2000,
       });
 
-      assignCollectionMaterialMutation.mutate({
-        path: {
-          collectionId: id,
-          materialId: data as string,
-        },
-      });
+      return router.invalidate();
     },
   });
 
@@ -397,6 +371,8 @@ function RouteComponent() {
             ...zUpdateCollectionMaterial.parse(
               collection.materials.find((material) => material.id === id)
             ),
+            // Without a value the line is revalued from the price list.
+            value: null,
             weight: value,
           },
         });
@@ -408,7 +384,7 @@ function RouteComponent() {
             ...zUpdateCollectionMaterial.parse(
               collection.materials.find((material) => material.id === id)
             ),
-            value: value,
+            value: value.toFixed(2),
           },
         });
       },
@@ -651,7 +627,7 @@ function RouteComponent() {
                     {materials.filter(
                       (material) =>
                         !collection.materials.find(
-                          (_material) => _material.id === material.id
+                          (_material) => _material.materialId === material.id
                         )
                     ).length === 0 && (
                       <Label className="flex w-full h-9 items-center justify-center text-muted-foreground">
@@ -663,7 +639,7 @@ function RouteComponent() {
                       .filter(
                         (material) =>
                           !collection.materials.find(
-                            (_material) => _material.id === material.id
+                            (_material) => _material.materialId === material.id
                           )
                       )
                       .map((material) => (
@@ -679,11 +655,13 @@ function RouteComponent() {
                             className="w-6 h-6"
                             onClick={() =>
                               createCollectionMaterialMutation.mutate({
-                                body: zCreateCollectionMaterial.parse({
-                                  ...material,
-                                  weight: 0.0,
-                                  value: 0.0,
-                                }),
+                                path: { collectionId: id },
+                                // Lines need a weight; without a value the
+                                // line is valued from the price list.
+                                body: {
+                                  materialId: material.id,
+                                  weight: 1,
+                                },
                               })
                             }
                           >
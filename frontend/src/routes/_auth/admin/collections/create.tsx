@@ -129,7 +129,7 @@ export const materialsColumns: ColumnDef<AssignCollectionMaterial>[] = [
         placeholder="Value"
         decimalScale={2}
         fixedDecimalScale
-        defaultValue={row.getValue<number>('Value')}
+        defaultValue={Number(row.getValue<string>('Value'))}
         onValueChange={(value) => {
           if (!value) return;
           table.options.meta?.updateValue(row.getValue('Material'), value);
@@ -297,7 +297,7 @@ function RouteComponent() {
             material.name === name
               ? {
                   ...material,
-                  value: value,
+                  value: value.toFixed(2),
                 }
               : material
           )
@@ -602,7 +602,7 @@ function RouteComponent() {
                                     ...field.value,
                                     zAssignCollectionMaterial.parse({
                                       weight: 0.0,
-                                      value: 0.0,
+                                      value: '0.00',
                                       ...material,
                                     }),
                                   ])
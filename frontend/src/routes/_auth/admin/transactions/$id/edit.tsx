@@ -1,7 +1,6 @@
 import {
   deleteApiTransactionsMaterialsByIdMutation,
-  postApiTransactionsAssignMaterialByTransactionIdByMaterialIdMutation,
-  postApiTransactionsMaterialsMutation,
+  postApiTransactionsByTransactionIdMaterialsMutation,
   putApiTransactionsByIdMutation,
   putApiTransactionsMaterialsByIdMutation,
 } from '@/api-client/@tanstack/react-query.gen';
@@ -33,7 +32,6 @@ import {
   getApiTransactionsById,
 } from '@/api-client';
 import {
-  zCreateTransactionMaterial,
   zUpdateTransaction,
   zUpdateTransactionMaterial,
 } from '@/api-client/zod.gen';
@@ -125,7 +123,7 @@ export const materialsColumns: ColumnDef<TransactionMaterial>[] = [
         placeholder="Value"
         decimalScale={2}
         fixedDecimalScale
-        defaultValue={row.getValue<number>('Value')}
+        defaultValue={Number(row.getValue<string>('Value'))}
         onValueChange={(value) => {
           if (!value) return;
           table.options.meta?.updateValue(row.getValue('Actions'), value);
@@ -285,27 +283,8 @@ function RouteComponent() {
     },
   });
 
-  const assignTransactionMaterialMutation = useMutation({
-    ...postApiTransactionsAssignMaterialByTransactionIdByMaterialIdMutation({
-      client: apiClient,
-    }),
-    onError: ({ error, message }: ErrorResponse) =>
-      toast.error(error, {
-        description: message,
-        duration: 2000,
-      }),
-    onSuccess: () => {
-      toast.success('Success', {
-        description: 'The transaction material has been assigned.',
-        duration: 2000,
-      });
-
-      return router.invalidate();
-    },
-  });
-
   const createTransactionMaterialMutation = useMutation({
-    ...postApiTransactionsMaterialsMutation({
+    ...postApiTransactionsByTransactionIdMaterialsMutation({
       client: apiClient,
     }),
     onError: ({ error, message }: ErrorResponse) =>
@@ -313,18 +292,13 @@ function RouteComponent() {
         description: message,
         duration: 2000,
       }),
-    onSuccess: (data) => {
+    onSuccess: () => {
       toast.success('Success', {
         description: 'The transaction material has been added.',
         duration: 2000,
       });
 
-      assignTransactionMaterialMutation.mutate({
-        path: {
-          transactionId: id,
-          materialId: data as string,
-        },
-      });
+      return router.invalidate();
     },
   });
 
@@ -389,7 +363,7 @@ function RouteComponent() {
             ...zUpdateTransactionMaterial.parse(
               transaction.materials.find((material) => material.id === id)
             ),
-            value: value,
+            value: value.toFixed(2),
           },
         });
       },
@@ -627,7 +601,7 @@ function RouteComponent() {
                     {materials.filter(
                       (material) =>
                         !transaction.materials.find(
-                          (_material) => _material.id === material.id
+                          (_material) => _material.materialId === material.id
                         )
                     ).length === 0 && (
                       <Label className="flex w-full h-9 items-center justify-center text-muted-foreground">
@@ -639,7 +613,7 @@ function RouteComponent() {
                       .filter(
                         (material) =>
                           !transaction.materials.find(
-                            (_material) => _material.id === material.id
+                            (_material) => _material.materialId === material.id
                           )
                       )
                       .map((material) => (
@@ -655,11 +629,14 @@ function RouteComponent() {
                             className="w-6 h-6"
                             onClick={() =>
                               createTransactionMaterialMutation.mutate({
-                                body: zCreateTransactionMaterial.parse({
-                                  ...material,
-                                  weight: 0.0,
-                                  value: 0.0,
-                                }),
+                                path: { transactionId: id },
+                                // Lines need a weight; the weight and value
+                                // are edited in the table below.
+                                body: {
+                                  materialId: material.id,
+                                  weight: 1,
+                                  value: '0.00',
+                                },
                               })
                             }
                           >
@@ -128,7 +128,7 @@ export const materialsColumns: ColumnDef<AssignTransactionMaterial>[] = [
         placeholder="Value"
         decimalScale={2}
         fixedDecimalScale
-        defaultValue={row.getValue<number>('Value')}
+        defaultValue={Number(row.getValue<string>('Value'))}
         onValueChange={(value) => {
           if (!value) return;
           table.options.meta?.updateValue(row.getValue('Material'), value);
@@ -276,7 +276,7 @@ function RouteComponent() {
             material.name === name
               ? {
                   ...material,
-                  value: value,
+                  value: value.toFixed(2),
                 }
               : material
           )
@@ -573,7 +573,7 @@ function RouteComponent() {
                                     ...field.value,
                                     zAssignTransactionMaterial.parse({
                                       weight: 0.0,
-                                      value: 0.0,
+                                      value: '0.00',
                                       ...material,
                                     }),
                                   ])
@@ -121,7 +121,7 @@ export const materialsColumns: ColumnDef<AssignTransactionMaterial>[] = [
       <DebounceNumberInput
         min={0}
         placeholder="Value"
-        defaultValue={row.getValue<number>('Value')}
+        defaultValue={Number(row.getValue<string>('Value'))}
         onValueChange={(value) => {
           if (!value) return;
           table.options.meta?.updateValue(row.getValue('Material'), value);
@@ -269,7 +269,7 @@ function RouteComponent() {
             material.name === name
               ? {
                   ...material,
-                  value: value,
+                  value: value.toFixed(2),
                 }
               : material
           )
@@ -518,7 +518,7 @@ function RouteComponent() {
                                     ...field.value,
                                     zAssignTransactionMaterial.parse({
                                       weight: 0.0,
-                                      value: 0.0,
+                                      value: '0.00',
                                       ...material,
                                     }),
                                   ])
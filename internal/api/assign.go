@@ -164,7 +164,7 @@ func (c *assignmentApi[Parent, Child]) AssignRoute(middleware ...fiber.Handler)
 			var parentEntity Parent
 			var childEntity Child
 
-			if err := c.storage.Database().Model(&parentEntity).Where("id = ?", parentId).First(&parentEntity).Error; err != nil {
+			if err := c.parentQuery(ctx, &parentEntity).Where("id = ?", parentId).First(&parentEntity).Error; err != nil {
 				if err == gorm.ErrRecordNotFound {
 					return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
 						"error":   "Not Found",
@@ -321,7 +321,7 @@ func (c *assignmentApi[Parent, Child]) UnassignRoute(middleware ...fiber.Handler
 			var parentEntity Parent
 			var childEntity Child
 
-			if err := c.storage.Database().Model(&parentEntity).Where("id = ?", parentId).First(&parentEntity).Error; err != nil {
+			if err := c.parentQuery(ctx, &parentEntity).Where("id = ?", parentId).First(&parentEntity).Error; err != nil {
 				if err == gorm.ErrRecordNotFound {
 					return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
 						"error":   "Not Found",
@@ -504,7 +504,7 @@ func (c *assignmentApi[Parent, Child]) ListRoute(middleware ...fiber.Handler) ro
 
 			var parentEntity Parent
 
-			if err := c.storage.Database().Model(&parentEntity).Where("id = ?", parentId).First(&parentEntity).Error; err != nil {
+			if err := c.parentQuery(ctx, &parentEntity).Where("id = ?", parentId).First(&parentEntity).Error; err != nil {
 				if err == gorm.ErrRecordNotFound {
 					return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
 						"error":   "Not Found",
@@ -527,12 +527,6 @@ func (c *assignmentApi[Parent, Child]) ListRoute(middleware ...fiber.Handler) ro
 				})
 			}
 
-			policies, ok := ctx.Locals("policies").(clause.Expression)
-
-			if ok && policies != nil {
-				clauses = append(clauses, policies)
-			}
-
 			countQuery := c.storage.Database().Model(&parentEntity)
 
 			if len(clauses) > 0 {
@@ -603,3 +597,17 @@ func (c *assignmentApi[Parent, Child]) ListRoute(middleware ...fiber.Handler) ro
 		},
 	}
 }
+
+// parentQuery scopes the parent lookup with the request's policy filter, so a
+// record hidden by a policy can't have its assignments listed or changed.
+func (c *assignmentApi[Parent, Child]) parentQuery(ctx *fiber.Ctx, parentEntity *Parent) *gorm.DB {
+	query := c.storage.Database().Model(parentEntity)
+
+	policies, ok := ctx.Locals("policies").(clause.Expression)
+
+	if ok && policies != nil {
+		query = query.Clauses(policies)
+	}
+
+	return query
+}
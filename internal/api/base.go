@@ -1,12 +1,14 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"reflect"
 	"strings"
 
 	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
 	"github.com/connor-davis/threereco-nextgen/internal/routing"
 	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
 	"github.com/connor-davis/threereco-nextgen/internal/storage"
@@ -279,7 +281,15 @@ func (c *baseApi[Entity]) UpdateRoute(middleware ...fiber.Handler) routing.Route
 
 			var existingEntity Entity
 
-			if err := c.storage.Database().Where("id = ?", params.Id).First(&existingEntity).Error; err != nil {
+			existingQuery := c.storage.Database().Model(&existingEntity)
+
+			scope, _ := ctx.Locals("policies").(clause.Expression)
+
+			if scope != nil {
+				existingQuery = existingQuery.Clauses(scope)
+			}
+
+			if err := existingQuery.Where("id = ?", params.Id).First(&existingEntity).Error; err != nil {
 				if err == gorm.ErrRecordNotFound {
 					return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
 						"error":   "Not Found",
@@ -293,7 +303,21 @@ func (c *baseApi[Entity]) UpdateRoute(middleware ...fiber.Handler) routing.Route
 				})
 			}
 
-			if err := c.storage.Database().Model(&existingEntity).Updates(&entity).Error; err != nil {
+			if err := c.storage.Database().Transaction(func(tx *gorm.DB) error {
+				// Associations are left alone here; the ones an update may
+				// change are replaced explicitly below.
+				if err := tx.Model(&existingEntity).Omit(clause.Associations).Updates(&entity).Error; err != nil {
+					return err
+				}
+
+				if err := replaceAssociations(tx, &existingEntity, &entity); err != nil {
+					return err
+				}
+
+				// An update must not move the record out of the rows the
+				// user may access, e.g. by changing the owning business.
+				return policies.InScope(tx, new(Entity), params.Id, scope)
+			}); err != nil {
 				if err == gorm.ErrRecordNotFound {
 					return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
 						"error":   "Not Found",
@@ -301,13 +325,13 @@ func (c *baseApi[Entity]) UpdateRoute(middleware ...fiber.Handler) routing.Route
 					})
 				}
 
-				return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error":   "Internal Server Error",
-					"message": err.Error(),
-				})
-			}
+				if errors.Is(err, policies.ErrOutOfScope) {
+					return ctx.Status(fiber.StatusForbidden).JSON(fiber.Map{
+						"error":   "Forbidden",
+						"message": fmt.Sprintf("The %s can't be moved outside the records you can access.", strings.ToLower(c.name)),
+					})
+				}
 
-			if err := replaceAssociations(c.storage.Database(), &existingEntity, &entity); err != nil {
 				return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 					"error":   "Internal Server Error",
 					"message": err.Error(),
@@ -444,7 +468,30 @@ func (c *baseApi[Entity]) DeleteRoute(middleware ...fiber.Handler) routing.Route
 				})
 			}
 
-			if err := c.storage.Database().Model(new(Entity)).Where("id = ?", params.Id).Delete(new(Entity)).Error; err != nil {
+			query := c.storage.Database().Model(new(Entity))
+
+			if policies, ok := ctx.Locals("policies").(clause.Expression); ok && policies != nil {
+				query = query.Clauses(policies)
+			}
+
+			// The entity is loaded first so that its delete hooks see it.
+			var existingEntity Entity
+
+			if err := query.Where("id = ?", params.Id).First(&existingEntity).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": fmt.Sprintf("The %s was not found.", strings.ToLower(c.name)),
+					})
+				}
+
+				return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			if err := c.storage.Database().Where("id = ?", params.Id).Delete(&existingEntity).Error; err != nil {
 				if err == gorm.ErrRecordNotFound {
 					return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
 						"error":   "Not Found",
@@ -715,20 +762,33 @@ func (c *baseApi[Entity]) GetAllRoute(middleware ...fiber.Handler) routing.Route
 			}
 
 			clauses := []clause.Expression{}
+			searches := []clause.Expression{}
 
 			for _, column := range queryParams.SearchColumns {
-				clauses = append(clauses, clause.Like{
+				searches = append(searches, clause.Like{
 					Column: clause.Column{Name: column},
 					Value:  fmt.Sprintf("%%%s%%", queryParams.SearchTerm),
 				})
 			}
 
+			if len(searches) > 0 {
+				clauses = append(clauses, clause.Or(searches...))
+			}
+
 			policies, ok := ctx.Locals("policies").(clause.Expression)
 
 			if ok && policies != nil {
 				clauses = append(clauses, policies)
 			}
 
+			// Filters are set by route-specific middleware, such as the status
+			// filter of collections and transactions.
+			filters, ok := ctx.Locals("filters").(clause.Expression)
+
+			if ok && filters != nil {
+				clauses = append(clauses, filters)
+			}
+
 			var entities []Entity
 			totalEntities := int64(0)
 			countQuery := c.storage.Database().Model(new(Entity))
@@ -780,7 +840,7 @@ func (c *baseApi[Entity]) GetAllRoute(middleware ...fiber.Handler) routing.Route
 			}
 
 			if len(clauses) > 0 {
-				query = query.Clauses(clause.Or(clauses...))
+				query = query.Clauses(clauses...)
 			}
 
 			if err := query.Limit(limit).Offset(offset).Find(&entities).Error; err != nil {
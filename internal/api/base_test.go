@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/policies"
+	"github.com/connor-davis/threereco-nextgen/internal/testdb"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestUpdateRouteKeepsRecordsInScope(t *testing.T) {
+	store := testdb.Open(t)
+
+	collector := testdb.User(t, store, models.CollectorUser)
+	owner := testdb.User(t, store, models.BusinessUser)
+	buyer := testdb.Business(t, store, owner)
+	other := testdb.Business(t, store, owner)
+	collection := testdb.Collection(t, store, collector, buyer)
+
+	actor := &models.User{Type: models.BusinessUser, ActiveBusinessId: &buyer.Id}
+	decision := policies.Evaluate(policies.Registry, actor, models.CollectionsPolicy, policies.UpdateAction)
+
+	route := NewBaseApi[models.Collection](store, "/collections", "Collection", "", "").UpdateRoute(
+		func(c *fiber.Ctx) error {
+			c.Locals("policies", decision.Expression())
+
+			return c.Next()
+		},
+	)
+
+	app := fiber.New()
+	app.Put("/collections/:id", append(route.Middlewares, route.Handler)...)
+
+	tests := []struct {
+		name    string
+		buyerId string
+		status  int
+	}{
+		{name: "moving to another business", buyerId: other.Id.String(), status: fiber.StatusForbidden},
+		{name: "staying in the business", buyerId: buyer.Id.String(), status: fiber.StatusOK},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			request := httptest.NewRequest(
+				fiber.MethodPut,
+				fmt.Sprintf("/collections/%s", collection.Id),
+				strings.NewReader(fmt.Sprintf(`{"buyerId":%q}`, test.buyerId)),
+			)
+			request.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			response, err := app.Test(request)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if response.StatusCode != test.status {
+				t.Errorf("got status %d, want %d", response.StatusCode, test.status)
+			}
+
+			var updated models.Collection
+
+			if err := store.Database().First(&updated, "id = ?", collection.Id).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if updated.BuyerId != buyer.Id {
+				t.Errorf("the collection moved to %s", updated.BuyerId)
+			}
+		})
+	}
+}
@@ -0,0 +1,400 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/threereco-nextgen/internal/lifecycle"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/routing"
+	"github.com/connor-davis/threereco-nextgen/internal/routing/schemas"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/go-openapi/inflect"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LifecycleApi interface {
+	TransitionRoute(transition lifecycle.Transition, middleware ...fiber.Handler) routing.Route
+	HistoryRoute(middleware ...fiber.Handler) routing.Route
+	// StatusFilter adds the optional status query parameter to a GetAllRoute
+	// of the same entity.
+	StatusFilter(route routing.Route) routing.Route
+}
+
+type lifecycleApi struct {
+	storage   storage.Storage
+	lifecycle lifecycle.Lifecycle
+	entity    models.PolicyType
+	baseUrl   string
+	name      string
+}
+
+type TransitionParams struct {
+	Id uuid.UUID `param:"id"`
+}
+
+type HistoryParams struct {
+	Id uuid.UUID `param:"id"`
+}
+
+func NewLifecycleApi(storage storage.Storage, lifecycle lifecycle.Lifecycle, entity models.PolicyType, baseUrl string, name string) LifecycleApi {
+	return &lifecycleApi{
+		storage:   storage,
+		lifecycle: lifecycle,
+		entity:    entity,
+		baseUrl:   baseUrl,
+		name:      name,
+	}
+}
+
+func (c *lifecycleApi) TransitionRoute(transition lifecycle.Transition, middleware ...fiber.Handler) routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription(fmt.Sprintf("%s status changed successfully.", c.name)).
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("409", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Conflict").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	var requestBody *openapi3.RequestBodyRef
+
+	if transition.ReasonRequired {
+		requestBody = &openapi3.RequestBodyRef{
+			Ref: "#/components/requestBodies/StatusTransitionPayload",
+		}
+	}
+
+	from := []string{}
+
+	for _, status := range transition.From {
+		from = append(from, string(status))
+	}
+
+	description := fmt.Sprintf("%s Allowed from %s; moves the %s to %s.", transition.Description, strings.Join(from, ", "), strings.ToLower(c.name), transition.To)
+
+	if transition.ReasonRequired {
+		description = fmt.Sprintf("%s A reason is required.", description)
+	}
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     fmt.Sprintf("%s %s", inflect.Capitalize(transition.Name), c.name),
+			Description: description,
+			Tags:        []string{fmt.Sprintf("%s Lifecycle", inflect.Pluralize(c.name))},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("id").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: requestBody,
+			Responses:   responses,
+		},
+		Method:      routing.POST,
+		Path:        fmt.Sprintf("%s/{id}/%s", c.baseUrl, transition.Name),
+		Middlewares: middleware,
+		Handler: func(ctx *fiber.Ctx) error {
+			currentUser := ctx.Locals("user").(*models.User)
+
+			var params TransitionParams
+
+			if err := ctx.ParamsParser(&params); err != nil {
+				return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			var payload models.StatusTransitionPayload
+
+			if len(ctx.Body()) > 0 {
+				if err := ctx.BodyParser(&payload); err != nil {
+					return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": "The request body is invalid.",
+					})
+				}
+			}
+
+			scope, _ := ctx.Locals("policies").(clause.Expression)
+
+			change, err := c.lifecycle.Transition(c.entity, params.Id, scope, transition.Name, payload.Reason, currentUser.Id)
+
+			if err != nil {
+				switch {
+				case errors.Is(err, gorm.ErrRecordNotFound):
+					return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+						"error":   "Not Found",
+						"message": fmt.Sprintf("The %s was not found.", strings.ToLower(c.name)),
+					})
+				case errors.Is(err, lifecycle.ErrReasonRequired):
+					return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+						"error":   "Bad Request",
+						"message": err.Error(),
+					})
+				case errors.Is(err, lifecycle.ErrInvalidTransition), errors.Is(err, lifecycle.ErrHeldByPayout):
+					return ctx.Status(fiber.StatusConflict).JSON(fiber.Map{
+						"error":   "Conflict",
+						"message": err.Error(),
+					})
+				}
+
+				return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+				"item": change,
+			})
+		},
+	}
+}
+
+func (c *lifecycleApi) HistoryRoute(middleware ...fiber.Handler) routing.Route {
+	responses := openapi3.NewResponses()
+
+	responses.Set("200", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithDescription(fmt.Sprintf("%s status changes retrieved successfully.", c.name)).
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.SuccessSchema),
+			}),
+	})
+
+	responses.Set("400", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Bad Request").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("401", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Unauthorized").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("403", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Forbidden").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("404", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Not Found").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	responses.Set("500", &openapi3.ResponseRef{
+		Value: openapi3.NewResponse().
+			WithJSONSchemaRef(schemas.ErrorSchema).
+			WithDescription("Internal Server Error").
+			WithContent(openapi3.Content{
+				"application/json": openapi3.NewMediaType().
+					WithSchemaRef(schemas.ErrorSchema),
+			}),
+	})
+
+	return routing.Route{
+		OpenAPIMetadata: routing.OpenAPIMetadata{
+			Summary:     fmt.Sprintf("Get %s Status Changes", c.name),
+			Description: fmt.Sprintf("This endpoint retrieves every status change of a %s, oldest first, with who made it and why.", strings.ToLower(c.name)),
+			Tags:        []string{fmt.Sprintf("%s Lifecycle", inflect.Pluralize(c.name))},
+			Parameters: []*openapi3.ParameterRef{
+				{
+					Value: openapi3.NewPathParameter("id").
+						WithRequired(true).
+						WithSchema(openapi3.NewUUIDSchema()),
+				},
+			},
+			RequestBody: nil,
+			Responses:   responses,
+		},
+		Method:      routing.GET,
+		Path:        fmt.Sprintf("%s/{id}/status-changes", c.baseUrl),
+		Middlewares: middleware,
+		Handler: func(ctx *fiber.Ctx) error {
+			var params HistoryParams
+
+			if err := ctx.ParamsParser(&params); err != nil {
+				return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": err.Error(),
+				})
+			}
+
+			query := c.storage.Database().Table(string(c.entity))
+
+			if policies, ok := ctx.Locals("policies").(clause.Expression); ok && policies != nil {
+				query = query.Clauses(policies)
+			}
+
+			var count int64
+
+			if err := query.Where("id = ?", params.Id).Count(&count).Error; err != nil {
+				return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			if count == 0 {
+				return ctx.Status(fiber.StatusNotFound).JSON(fiber.Map{
+					"error":   "Not Found",
+					"message": fmt.Sprintf("The %s was not found.", strings.ToLower(c.name)),
+				})
+			}
+
+			changes, err := c.lifecycle.History(c.entity, params.Id)
+
+			if err != nil {
+				return ctx.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+					"error":   "Internal Server Error",
+					"message": err.Error(),
+				})
+			}
+
+			return ctx.Status(fiber.StatusOK).JSON(fiber.Map{
+				"items": changes,
+			})
+		},
+	}
+}
+
+func (c *lifecycleApi) StatusFilter(route routing.Route) routing.Route {
+	statuses := []string{}
+
+	for _, status := range models.Statuses {
+		statuses = append(statuses, string(status))
+	}
+
+	route.OpenAPIMetadata.Parameters = append(route.OpenAPIMetadata.Parameters, &openapi3.ParameterRef{
+		Value: openapi3.NewQueryParameter("status").
+			WithDescription("Only return records in one of these statuses, separated by commas.").
+			WithRequired(false).
+			WithSchema(openapi3.NewStringSchema()),
+	})
+
+	filter := func(ctx *fiber.Ctx) error {
+		query := strings.TrimSpace(ctx.Query("status"))
+
+		if query == "" {
+			return ctx.Next()
+		}
+
+		values := []any{}
+
+		for _, value := range strings.Split(query, ",") {
+			status := models.Status(strings.TrimSpace(value))
+
+			if !slices.Contains(models.Statuses, status) {
+				return ctx.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+					"error":   "Bad Request",
+					"message": fmt.Sprintf("The status must be one of %s.", strings.Join(statuses, ", ")),
+				})
+			}
+
+			values = append(values, string(status))
+		}
+
+		ctx.Locals("filters", clause.IN{
+			Column: clause.Column{Name: "status"},
+			Values: values,
+		})
+
+		return ctx.Next()
+	}
+
+	route.Middlewares = append(route.Middlewares, filter)
+
+	return route
+}
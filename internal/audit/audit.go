@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+type Audit interface {
+	Record(entry models.AuditLog) error
+	Request(c *fiber.Ctx, entry models.AuditLog)
+}
+
+type audit struct {
+	storage storage.Storage
+}
+
+func New(storage storage.Storage) Audit {
+	return &audit{
+		storage: storage,
+	}
+}
+
+func (a *audit) Record(entry models.AuditLog) error {
+	return a.storage.Database().Create(&entry).Error
+}
+
+// Request records an entry for the current request. Failures are logged
+// rather than returned so that auditing never changes the response.
+func (a *audit) Request(c *fiber.Ctx, entry models.AuditLog) {
+	entry.Method = c.Method()
+	entry.Path = c.Path()
+	entry.Ip = c.IP()
+
+	if entry.Status == 0 {
+		entry.Status = c.Response().StatusCode()
+	}
+
+	if err := a.Record(entry); err != nil {
+		log.Errorf("🔥 Failed to record audit log %s: %s", entry.Action, err.Error())
+	}
+}
@@ -0,0 +1,484 @@
+package carbon
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidFactor   = errors.New("the carbon factor must be a number of at least zero with at most four decimals")
+	ErrInvalidUnit     = errors.New("the carbon factor unit must be " + models.KgCO2ePerKg)
+	ErrFutureFactor    = errors.New("a carbon factor can't take effect in the future")
+	ErrInvalidInterval = errors.New("the interval must be day, week, month or year")
+)
+
+// maxFactor is the largest factor that fits the decimal(12,4) columns.
+var maxFactor = decimal.RequireFromString("99999999.9999")
+
+type Interval string
+
+const (
+	NoInterval    Interval = ""
+	DayInterval   Interval = "day"
+	WeekInterval  Interval = "week"
+	MonthInterval Interval = "month"
+	YearInterval  Interval = "year"
+)
+
+// Filter narrows the collections or transactions a report covers. From is
+// inclusive and To exclusive. CollectorId only applies to collections, and
+// BusinessId matches the buyer of a collection and either side of a
+// transaction.
+type Filter struct {
+	CollectorId *uuid.UUID
+	BusinessId  *uuid.UUID
+	From        *time.Time
+	To          *time.Time
+	Interval    Interval
+}
+
+type Carbon interface {
+	// Validate checks a factor and unit before they are stored. An empty unit
+	// is accepted and means kgCO2e/kg.
+	Validate(factor decimal.Decimal, unit string) error
+	// SetFactor records a new version of the material's carbon factor and
+	// refreshes the material's current factor, using tx so that it commits
+	// together with the caller's other writes.
+	SetFactor(tx *gorm.DB, materialId uuid.UUID, factor decimal.Decimal, unit string, effectiveFrom time.Time, createdById *uuid.UUID) (*models.MaterialCarbonFactor, error)
+	Factors(materialId uuid.UUID) ([]models.MaterialCarbonFactor, error)
+	Collection(collectionId uuid.UUID, scope clause.Expression) (*models.CarbonSavings, error)
+	Transaction(transactionId uuid.UUID, scope clause.Expression) (*models.CarbonSavings, error)
+	Collections(filter Filter, scope clause.Expression) (*models.CarbonSavings, error)
+	Transactions(filter Filter, scope clause.Expression) (*models.CarbonSavings, error)
+}
+
+type carbon struct {
+	storage storage.Storage
+}
+
+func New(storage storage.Storage) Carbon {
+	return &carbon{
+		storage: storage,
+	}
+}
+
+// record is a collection or transaction reduced to what the calculation needs.
+type record struct {
+	at    time.Time
+	lines []line
+}
+
+// line is a collection or transaction line. Lines recorded before lines
+// referenced the catalogue, and never matched since, have no materialId and
+// are identified by their GW code instead.
+type line struct {
+	materialId *uuid.UUID
+	name       string
+	gwCode     string
+	weight     float64
+	factor     float64
+}
+
+// key groups the line with the other lines of its material.
+func (l line) key() materialKey {
+	if l.materialId != nil {
+		return materialKey{id: *l.materialId}
+	}
+
+	return materialKey{gwCode: l.gwCode}
+}
+
+type materialKey struct {
+	id     uuid.UUID
+	gwCode string
+}
+
+// version is a factor version with the id and GW code of its material.
+type version struct {
+	MaterialId    uuid.UUID
+	GWCode        string
+	Factor        float64
+	EffectiveFrom time.Time
+}
+
+func (c *carbon) Validate(factor decimal.Decimal, unit string) error {
+	if factor.IsNegative() || factor.GreaterThan(maxFactor) || !factor.Equal(factor.Round(4)) {
+		return ErrInvalidFactor
+	}
+
+	if unit != "" && unit != models.KgCO2ePerKg {
+		return ErrInvalidUnit
+	}
+
+	return nil
+}
+
+func (c *carbon) SetFactor(tx *gorm.DB, materialId uuid.UUID, factor decimal.Decimal, unit string, effectiveFrom time.Time, createdById *uuid.UUID) (*models.MaterialCarbonFactor, error) {
+	if err := c.Validate(factor, unit); err != nil {
+		return nil, err
+	}
+
+	if effectiveFrom.After(time.Now()) {
+		return nil, ErrFutureFactor
+	}
+
+	if unit == "" {
+		unit = models.KgCO2ePerKg
+	}
+
+	carbonFactor := models.MaterialCarbonFactor{
+		MaterialId:    materialId,
+		Factor:        factor,
+		Unit:          unit,
+		EffectiveFrom: effectiveFrom,
+		CreatedById:   createdById,
+	}
+
+	if err := tx.Create(&carbonFactor).Error; err != nil {
+		return nil, err
+	}
+
+	// A backdated version doesn't replace a later one as the current factor.
+	var current models.MaterialCarbonFactor
+
+	if err := tx.
+		Where("material_id = ? AND effective_from <= ?", materialId, time.Now()).
+		Order("effective_from DESC, created_at DESC").
+		First(&current).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&models.Material{}).
+		Where("id = ?", materialId).
+		Updates(map[string]any{
+			"carbon_factor":      current.Factor,
+			"carbon_factor_unit": current.Unit,
+		}).Error; err != nil {
+		return nil, err
+	}
+
+	return &carbonFactor, nil
+}
+
+func (c *carbon) Factors(materialId uuid.UUID) ([]models.MaterialCarbonFactor, error) {
+	factors := []models.MaterialCarbonFactor{}
+
+	if err := c.storage.Database().
+		Where("material_id = ?", materialId).
+		Order("effective_from DESC, created_at DESC").
+		Find(&factors).Error; err != nil {
+		return nil, err
+	}
+
+	return factors, nil
+}
+
+func (c *carbon) Collection(collectionId uuid.UUID, scope clause.Expression) (*models.CarbonSavings, error) {
+	query := c.storage.Database().Model(&models.Collection{}).Where("id = ?", collectionId)
+
+	if scope != nil {
+		query = query.Clauses(scope)
+	}
+
+	var collection models.Collection
+
+	if err := query.Preload("Materials").First(&collection).Error; err != nil {
+		return nil, err
+	}
+
+	return c.calculate([]record{collectionRecord(collection)}, NoInterval)
+}
+
+func (c *carbon) Transaction(transactionId uuid.UUID, scope clause.Expression) (*models.CarbonSavings, error) {
+	query := c.storage.Database().Model(&models.Transaction{}).Where("id = ?", transactionId)
+
+	if scope != nil {
+		query = query.Clauses(scope)
+	}
+
+	var transaction models.Transaction
+
+	if err := query.Preload("Materials").First(&transaction).Error; err != nil {
+		return nil, err
+	}
+
+	return c.calculate([]record{transactionRecord(transaction)}, NoInterval)
+}
+
+func (c *carbon) Collections(filter Filter, scope clause.Expression) (*models.CarbonSavings, error) {
+	query := c.storage.Database().Model(&models.Collection{})
+
+	if filter.CollectorId != nil {
+		query = query.Where("seller_id = ?", *filter.CollectorId)
+	}
+
+	if filter.BusinessId != nil {
+		query = query.Where("buyer_id = ?", *filter.BusinessId)
+	}
+
+	collections := []models.Collection{}
+
+	if err := c.filter(query, filter, scope).Preload("Materials").Find(&collections).Error; err != nil {
+		return nil, err
+	}
+
+	records := make([]record, 0, len(collections))
+
+	for _, collection := range collections {
+		records = append(records, collectionRecord(collection))
+	}
+
+	return c.calculate(records, filter.Interval)
+}
+
+func (c *carbon) Transactions(filter Filter, scope clause.Expression) (*models.CarbonSavings, error) {
+	query := c.storage.Database().Model(&models.Transaction{})
+
+	if filter.BusinessId != nil {
+		query = query.Where("seller_id = ? OR buyer_id = ?", *filter.BusinessId, *filter.BusinessId)
+	}
+
+	transactions := []models.Transaction{}
+
+	if err := c.filter(query, filter, scope).Preload("Materials").Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+
+	records := make([]record, 0, len(transactions))
+
+	for _, transaction := range transactions {
+		records = append(records, transactionRecord(transaction))
+	}
+
+	return c.calculate(records, filter.Interval)
+}
+
+// filter also leaves out voided records, whose material never changed hands.
+func (c *carbon) filter(query *gorm.DB, filter Filter, scope clause.Expression) *gorm.DB {
+	query = query.Where("status <> ?", models.VoidedStatus)
+
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	if scope != nil {
+		query = query.Clauses(scope)
+	}
+
+	return query
+}
+
+// calculate multiplies every line's weight by the factor of its material that
+// was in effect when the collection or transaction was recorded, so later
+// factor changes don't rewrite history. Lines whose material has no factor
+// version from that time fall back to the factor copied onto the line.
+func (c *carbon) calculate(records []record, interval Interval) (*models.CarbonSavings, error) {
+	if !slices.Contains([]Interval{NoInterval, DayInterval, WeekInterval, MonthInterval, YearInterval}, interval) {
+		return nil, ErrInvalidInterval
+	}
+
+	materialIds := []uuid.UUID{}
+	gwCodes := []string{}
+
+	for _, record := range records {
+		for _, line := range record.lines {
+			if line.materialId != nil {
+				if !slices.Contains(materialIds, *line.materialId) {
+					materialIds = append(materialIds, *line.materialId)
+				}
+			} else if !slices.Contains(gwCodes, line.gwCode) {
+				gwCodes = append(gwCodes, line.gwCode)
+			}
+		}
+	}
+
+	versions := map[materialKey][]version{}
+
+	if len(materialIds) > 0 || len(gwCodes) > 0 {
+		rows := []version{}
+
+		if err := c.storage.Database().
+			Model(&models.MaterialCarbonFactor{}).
+			Select("materials.id AS material_id, materials.gw_code, material_carbon_factors.factor, material_carbon_factors.effective_from").
+			Joins("JOIN materials ON materials.id = material_carbon_factors.material_id").
+			Where("materials.id IN ? OR materials.gw_code IN ?", materialIds, gwCodes).
+			Order("material_carbon_factors.effective_from ASC, material_carbon_factors.created_at ASC").
+			Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			byId := materialKey{id: row.MaterialId}
+			byCode := materialKey{gwCode: row.GWCode}
+
+			versions[byId] = append(versions[byId], row)
+			versions[byCode] = append(versions[byCode], row)
+		}
+	}
+
+	savings := models.CarbonSavings{
+		Unit:      "kgCO2e",
+		Count:     len(records),
+		Materials: []models.MaterialCarbonSavings{},
+	}
+
+	materials := map[materialKey]*models.MaterialCarbonSavings{}
+	periods := map[time.Time]*models.PeriodCarbonSavings{}
+
+	for _, record := range records {
+		var period *models.PeriodCarbonSavings
+
+		if interval != NoInterval {
+			start := truncate(record.at, interval)
+
+			if _, ok := periods[start]; !ok {
+				periods[start] = &models.PeriodCarbonSavings{Start: start}
+			}
+
+			period = periods[start]
+			period.Count++
+		}
+
+		for _, line := range record.lines {
+			key := line.key()
+			co2e := line.weight * factorAt(versions[key], record.at, line.factor)
+
+			material, ok := materials[key]
+
+			if !ok {
+				material = &models.MaterialCarbonSavings{
+					MaterialId: line.materialId,
+					Name:       line.name,
+					GWCode:     line.gwCode,
+				}
+
+				materials[key] = material
+			}
+
+			material.Weight += line.weight
+			material.CO2e += co2e
+			savings.Weight += line.weight
+			savings.CO2e += co2e
+
+			if period != nil {
+				period.Weight += line.weight
+				period.CO2e += co2e
+			}
+		}
+	}
+
+	for _, material := range materials {
+		material.Weight = round(material.Weight)
+		material.CO2e = round(material.CO2e)
+
+		savings.Materials = append(savings.Materials, *material)
+	}
+
+	slices.SortFunc(savings.Materials, func(a models.MaterialCarbonSavings, b models.MaterialCarbonSavings) int {
+		return strings.Compare(a.GWCode, b.GWCode)
+	})
+
+	if interval != NoInterval {
+		savings.Periods = []models.PeriodCarbonSavings{}
+
+		for _, period := range periods {
+			period.Weight = round(period.Weight)
+			period.CO2e = round(period.CO2e)
+
+			savings.Periods = append(savings.Periods, *period)
+		}
+
+		slices.SortFunc(savings.Periods, func(a models.PeriodCarbonSavings, b models.PeriodCarbonSavings) int {
+			return a.Start.Compare(b.Start)
+		})
+	}
+
+	savings.Weight = round(savings.Weight)
+	savings.CO2e = round(savings.CO2e)
+
+	return &savings, nil
+}
+
+// factorAt returns the last version that took effect at or before at, from
+// versions ordered oldest first.
+func factorAt(versions []version, at time.Time, fallback float64) float64 {
+	factor := fallback
+
+	for _, version := range versions {
+		if version.EffectiveFrom.After(at) {
+			break
+		}
+
+		factor = version.Factor
+	}
+
+	return factor
+}
+
+func truncate(at time.Time, interval Interval) time.Time {
+	year, month, day := at.Date()
+
+	switch interval {
+	case DayInterval:
+		return time.Date(year, month, day, 0, 0, 0, 0, at.Location())
+	case WeekInterval:
+		// Weeks start on Monday.
+		offset := (int(at.Weekday()) + 6) % 7
+
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, at.Location())
+	case MonthInterval:
+		return time.Date(year, month, 1, 0, 0, 0, 0, at.Location())
+	default:
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, at.Location())
+	}
+}
+
+func round(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
+
+func collectionRecord(collection models.Collection) record {
+	lines := make([]line, 0, len(collection.Materials))
+
+	for _, material := range collection.Materials {
+		lines = append(lines, line{
+			materialId: material.MaterialId,
+			name:       material.Name,
+			gwCode:     material.GWCode,
+			weight:     material.Weight,
+			factor:     material.CarbonFactor.InexactFloat64(),
+		})
+	}
+
+	return record{at: collection.CreatedAt, lines: lines}
+}
+
+func transactionRecord(transaction models.Transaction) record {
+	lines := make([]line, 0, len(transaction.Materials))
+
+	for _, material := range transaction.Materials {
+		lines = append(lines, line{
+			materialId: material.MaterialId,
+			name:       material.Name,
+			gwCode:     material.GWCode,
+			weight:     material.Weight,
+			factor:     material.CarbonFactor.InexactFloat64(),
+		})
+	}
+
+	return record{at: transaction.CreatedAt, lines: lines}
+}
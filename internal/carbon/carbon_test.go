@@ -0,0 +1,35 @@
+package carbon
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		factor string
+		unit   string
+		err    error
+	}{
+		{factor: "0", unit: ""},
+		{factor: "1.2345", unit: models.KgCO2ePerKg},
+		{factor: "99999999.9999", unit: ""},
+		{factor: "1.23456", unit: "", err: ErrInvalidFactor},
+		{factor: "-0.5", unit: "", err: ErrInvalidFactor},
+		{factor: "100000000", unit: "", err: ErrInvalidFactor},
+		{factor: "1", unit: "kgCO2/kg", err: ErrInvalidUnit},
+	}
+
+	c := New(nil)
+
+	for _, test := range tests {
+		t.Run(test.factor, func(t *testing.T) {
+			if err := c.Validate(decimal.RequireFromString(test.factor), test.unit); !errors.Is(err, test.err) {
+				t.Errorf("got %v, want %v", err, test.err)
+			}
+		})
+	}
+}
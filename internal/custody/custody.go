@@ -0,0 +1,486 @@
+package custody
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrUnknownMethod = errors.New("the allocation method must be fifo or proportional")
+
+// Method decides which of the lots a business bought a sale is attributed to.
+type Method string
+
+const (
+	// FifoMethod attributes every sale, and every manual stock write-off, to
+	// the oldest stock the business still held when it was approved.
+	FifoMethod Method = "fifo"
+	// ProportionalMethod attributes a sale to everything the business bought
+	// before it was approved, in proportion to the quantities bought (mass
+	// balance).
+	ProportionalMethod Method = "proportional"
+)
+
+// maxDepth is how many sales upstream a trace follows before the rest of the
+// material is left unattributed.
+const maxDepth = 25
+
+// epsilon is the quantity, in kg, below which a remainder is rounding noise.
+const epsilon = 1e-6
+
+// counted are the statuses in which a record's material has changed hands,
+// the same ones the inventory ledger books.
+var counted = []models.Status{
+	models.ApprovedStatus,
+	models.PaidStatus,
+}
+
+type Custody interface {
+	// Trace follows the material of the transaction with the given id that
+	// scope allows back through the transactions its seller bought it in, to
+	// the collections and collectors it came from. Only the given material is
+	// traced when materialId is set. Transactions that haven't been approved
+	// yet are traced as if they were approved now. It returns
+	// gorm.ErrRecordNotFound when scope hides the transaction.
+	Trace(transactionId uuid.UUID, materialId *uuid.UUID, method Method, scope clause.Expression) (*models.Lineage, error)
+}
+
+type custody struct {
+	storage storage.Storage
+}
+
+func New(storage storage.Storage) Custody {
+	return &custody{
+		storage: storage,
+	}
+}
+
+// event is a quantity of one material coming into or going out of a
+// business's stock. PartyId is the collector of a collection and the other
+// business of a transaction.
+type event struct {
+	Id         uuid.UUID
+	Type       models.LineageNodeType
+	PartyId    *uuid.UUID
+	ApprovedAt time.Time
+	Quantity   float64
+}
+
+// allocation is the part of a sale that came from one lot.
+type allocation struct {
+	lot      event
+	quantity float64
+}
+
+// ledger is what came into and went out of a business's stock of a material,
+// both oldest first. Write-offs are outgoing with a positive quantity.
+type ledger struct {
+	in          []event
+	out         []event
+	allocations map[uuid.UUID][]allocation
+}
+
+type key struct {
+	businessId uuid.UUID
+	materialId uuid.UUID
+}
+
+type edgeKey struct {
+	from       uuid.UUID
+	to         uuid.UUID
+	materialId uuid.UUID
+}
+
+// tracer holds the state of one trace.
+type tracer struct {
+	db      *gorm.DB
+	method  Method
+	ledgers map[key]*ledger
+	lineage *models.Lineage
+	nodes   map[uuid.UUID]bool
+	edges   map[edgeKey]int
+}
+
+func (c *custody) Trace(transactionId uuid.UUID, materialId *uuid.UUID, method Method, scope clause.Expression) (*models.Lineage, error) {
+	if method == "" {
+		method = FifoMethod
+	}
+
+	if method != FifoMethod && method != ProportionalMethod {
+		return nil, ErrUnknownMethod
+	}
+
+	query := c.storage.Database().Model(&models.Transaction{}).Where("id = ?", transactionId)
+
+	if scope != nil {
+		query = query.Clauses(scope)
+	}
+
+	var transaction models.Transaction
+
+	if err := query.Preload("Materials").First(&transaction).Error; err != nil {
+		return nil, err
+	}
+
+	materialIds := []uuid.UUID{}
+	quantities := map[uuid.UUID]float64{}
+
+	for _, line := range transaction.Materials {
+		if line.MaterialId == nil || (materialId != nil && *line.MaterialId != *materialId) {
+			continue
+		}
+
+		if _, ok := quantities[*line.MaterialId]; !ok {
+			materialIds = append(materialIds, *line.MaterialId)
+		}
+
+		quantities[*line.MaterialId] += line.Weight
+	}
+
+	t := &tracer{
+		db:      c.storage.Database(),
+		method:  method,
+		ledgers: map[key]*ledger{},
+		lineage: &models.Lineage{
+			RootId: transaction.Id,
+			Method: string(method),
+			Nodes:  []models.LineageNode{},
+			Edges:  []models.LineageEdge{},
+		},
+		nodes: map[uuid.UUID]bool{},
+		edges: map[edgeKey]int{},
+	}
+
+	root := models.LineageNode{
+		Id:         transaction.Id,
+		Type:       models.TransactionLineageNode,
+		BusinessId: &transaction.SellerId,
+	}
+
+	for _, materialId := range materialIds {
+		ledger, err := t.ledger(transaction.SellerId, materialId)
+
+		if err != nil {
+			return nil, err
+		}
+
+		index := slices.IndexFunc(ledger.out, func(sale event) bool {
+			return sale.Id == transaction.Id
+		})
+
+		if index < 0 {
+			ledger.out = append(ledger.out, event{
+				Id:         transaction.Id,
+				Type:       models.TransactionLineageNode,
+				PartyId:    &transaction.BuyerId,
+				ApprovedAt: time.Now(),
+				Quantity:   quantities[materialId],
+			})
+
+			index = len(ledger.out) - 1
+		} else if slices.Contains(counted, transaction.Status) {
+			root.ApprovedAt = &ledger.out[index].ApprovedAt
+		}
+
+		if err := t.walk(ledger.out[index], transaction.SellerId, materialId, quantities[materialId], map[uuid.UUID]bool{transaction.Id: true}); err != nil {
+			return nil, err
+		}
+	}
+
+	// The root comes first, unless material went round in a circle back
+	// through it.
+	if !t.nodes[root.Id] {
+		t.lineage.Nodes = append([]models.LineageNode{root}, t.lineage.Nodes...)
+	}
+
+	for index := range t.lineage.Edges {
+		t.lineage.Edges[index].Quantity = math.Round(t.lineage.Edges[index].Quantity*100) / 100
+	}
+
+	t.lineage.Edges = slices.DeleteFunc(t.lineage.Edges, func(edge models.LineageEdge) bool {
+		return edge.Quantity <= 0
+	})
+
+	return t.lineage, nil
+}
+
+// walk adds the lots that quantity kg of the sale by sellerId came from to
+// the lineage, and follows the lots that were bought from other businesses
+// further upstream. path holds the sales being walked, so that material that
+// went round in a circle is left unattributed instead of followed forever.
+func (t *tracer) walk(sale event, sellerId uuid.UUID, materialId uuid.UUID, quantity float64, path map[uuid.UUID]bool) error {
+	allocations, err := t.allocate(sellerId, materialId, sale)
+
+	if err != nil {
+		return err
+	}
+
+	scale := 0.0
+
+	if sale.Quantity > 0 {
+		scale = quantity / sale.Quantity
+	}
+
+	attributed := 0.0
+
+	for _, allocation := range allocations {
+		lot := allocation.lot
+		share := allocation.quantity * scale
+
+		if share <= epsilon {
+			continue
+		}
+
+		attributed += share
+
+		switch lot.Type {
+		case models.CollectionLineageNode:
+			t.node(models.LineageNode{Id: lot.Id, Type: lot.Type, BusinessId: &sellerId, ApprovedAt: &lot.ApprovedAt})
+			t.node(models.LineageNode{Id: *lot.PartyId, Type: models.CollectorLineageNode})
+			t.edge(*lot.PartyId, lot.Id, materialId, share)
+		case models.AdjustmentLineageNode:
+			t.node(models.LineageNode{Id: lot.Id, Type: lot.Type, BusinessId: &sellerId, ApprovedAt: &lot.ApprovedAt})
+		case models.TransactionLineageNode:
+			t.node(models.LineageNode{Id: lot.Id, Type: lot.Type, BusinessId: lot.PartyId, ApprovedAt: &lot.ApprovedAt})
+
+			if path[lot.Id] || len(path) > maxDepth {
+				t.unattributed(*lot.PartyId, lot.Id, materialId, share)
+
+				break
+			}
+
+			path[lot.Id] = true
+
+			if err := t.walk(lot, *lot.PartyId, materialId, share, path); err != nil {
+				return err
+			}
+
+			delete(path, lot.Id)
+		}
+
+		t.edge(lot.Id, sale.Id, materialId, share)
+	}
+
+	if remainder := quantity - attributed; remainder > epsilon {
+		t.unattributed(sellerId, sale.Id, materialId, remainder)
+	}
+
+	return nil
+}
+
+// allocate splits all of the sale's material between the lots of the
+// seller's stock it came from. What's left over came from stock that can't be
+// traced.
+func (t *tracer) allocate(sellerId uuid.UUID, materialId uuid.UUID, sale event) ([]allocation, error) {
+	ledger, err := t.ledger(sellerId, materialId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if allocations, ok := ledger.allocations[sale.Id]; ok {
+		return allocations, nil
+	}
+
+	switch t.method {
+	case FifoMethod:
+		// Every earlier sale and write-off has to be taken out of the stock
+		// first, so all of them are allocated at once.
+		remaining := make([]float64, len(ledger.in))
+
+		for index, lot := range ledger.in {
+			remaining[index] = lot.Quantity
+		}
+
+		for _, out := range ledger.out {
+			allocations := []allocation{}
+			needed := out.Quantity
+
+			for index, lot := range ledger.in {
+				if needed <= epsilon || lot.ApprovedAt.After(out.ApprovedAt) {
+					break
+				}
+
+				if remaining[index] <= epsilon {
+					continue
+				}
+
+				taken := math.Min(remaining[index], needed)
+				remaining[index] -= taken
+				needed -= taken
+
+				allocations = append(allocations, allocation{lot: lot, quantity: taken})
+			}
+
+			ledger.allocations[out.Id] = allocations
+		}
+	case ProportionalMethod:
+		total := 0.0
+
+		for _, lot := range ledger.in {
+			if !lot.ApprovedAt.After(sale.ApprovedAt) {
+				total += lot.Quantity
+			}
+		}
+
+		allocations := []allocation{}
+
+		if total > epsilon {
+			share := math.Min(sale.Quantity/total, 1)
+
+			for _, lot := range ledger.in {
+				if !lot.ApprovedAt.After(sale.ApprovedAt) {
+					allocations = append(allocations, allocation{lot: lot, quantity: lot.Quantity * share})
+				}
+			}
+		}
+
+		ledger.allocations[sale.Id] = allocations
+	}
+
+	return ledger.allocations[sale.Id], nil
+}
+
+// ledger loads what came into and went out of the business's stock of the
+// material: approved collections and purchases in, approved sales out, and
+// manual adjustments either way.
+func (t *tracer) ledger(businessId uuid.UUID, materialId uuid.UUID) (*ledger, error) {
+	if ledger, ok := t.ledgers[key{businessId, materialId}]; ok {
+		return ledger, nil
+	}
+
+	ledger := &ledger{
+		in:          []event{},
+		out:         []event{},
+		allocations: map[uuid.UUID][]allocation{},
+	}
+
+	for _, source := range []struct {
+		table    string
+		lines    string
+		owner    string
+		business string
+		party    string
+		nodeType models.LineageNodeType
+		events   *[]event
+	}{
+		{"collections", "collection_materials", "collection_id", "buyer_id", "seller_id", models.CollectionLineageNode, &ledger.in},
+		{"transactions", "transaction_materials", "transaction_id", "buyer_id", "seller_id", models.TransactionLineageNode, &ledger.in},
+		{"transactions", "transaction_materials", "transaction_id", "seller_id", "buyer_id", models.TransactionLineageNode, &ledger.out},
+	} {
+		rows := []struct {
+			Id         uuid.UUID
+			PartyId    uuid.UUID
+			ApprovedAt time.Time
+			Quantity   float64
+		}{}
+
+		if err := t.db.Table(source.table).
+			Select(fmt.Sprintf("%[1]s.id, %[1]s.%[2]s AS party_id, %[3]s AS approved_at, SUM(%[4]s.weight) AS quantity", source.table, source.party, approvedAt(source.table), source.lines)).
+			Joins(fmt.Sprintf("JOIN %[1]s ON %[1]s.%[2]s = %[3]s.id", source.lines, source.owner, source.table)).
+			Where(fmt.Sprintf("%[1]s.%[2]s = ? AND %[3]s.material_id = ? AND %[1]s.status IN ?", source.table, source.business, source.lines), businessId, materialId, counted).
+			Group(fmt.Sprintf("%s.id", source.table)).
+			Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			*source.events = append(*source.events, event{
+				Id:         row.Id,
+				Type:       source.nodeType,
+				PartyId:    &row.PartyId,
+				ApprovedAt: row.ApprovedAt,
+				Quantity:   row.Quantity,
+			})
+		}
+	}
+
+	adjustments := []models.StockMovement{}
+
+	if err := t.db.
+		Where("business_id = ? AND material_id = ? AND type = ?", businessId, materialId, models.AdjustmentStockMovement).
+		Find(&adjustments).Error; err != nil {
+		return nil, err
+	}
+
+	for _, adjustment := range adjustments {
+		adjustmentEvent := event{
+			Id:         adjustment.Id,
+			Type:       models.AdjustmentLineageNode,
+			ApprovedAt: adjustment.CreatedAt,
+			Quantity:   math.Abs(adjustment.Quantity),
+		}
+
+		if adjustment.Quantity > 0 {
+			ledger.in = append(ledger.in, adjustmentEvent)
+		} else {
+			ledger.out = append(ledger.out, adjustmentEvent)
+		}
+	}
+
+	for _, events := range [][]event{ledger.in, ledger.out} {
+		slices.SortFunc(events, func(a event, b event) int {
+			if order := a.ApprovedAt.Compare(b.ApprovedAt); order != 0 {
+				return order
+			}
+
+			return strings.Compare(a.Id.String(), b.Id.String())
+		})
+	}
+
+	t.ledgers[key{businessId, materialId}] = ledger
+
+	return ledger, nil
+}
+
+func (t *tracer) node(node models.LineageNode) {
+	if t.nodes[node.Id] {
+		return
+	}
+
+	t.nodes[node.Id] = true
+	t.lineage.Nodes = append(t.lineage.Nodes, node)
+}
+
+func (t *tracer) edge(from uuid.UUID, to uuid.UUID, materialId uuid.UUID, quantity float64) {
+	key := edgeKey{from, to, materialId}
+
+	if index, ok := t.edges[key]; ok {
+		t.lineage.Edges[index].Quantity += quantity
+
+		return
+	}
+
+	t.edges[key] = len(t.lineage.Edges)
+	t.lineage.Edges = append(t.lineage.Edges, models.LineageEdge{
+		From:       from,
+		To:         to,
+		MaterialId: materialId,
+		Quantity:   quantity,
+	})
+}
+
+// unattributed records quantity kg that reached to from the business's stock
+// without a lot it can be traced to.
+func (t *tracer) unattributed(businessId uuid.UUID, to uuid.UUID, materialId uuid.UUID, quantity float64) {
+	t.node(models.LineageNode{Id: businessId, Type: models.UnattributedLineageNode, BusinessId: &businessId})
+	t.edge(businessId, to, materialId, quantity)
+}
+
+// approvedAt is when a record of table was last approved, or last updated
+// for records approved before transitions were recorded.
+func approvedAt(table string) string {
+	return fmt.Sprintf(
+		`COALESCE((SELECT MAX(status_changes.created_at) FROM status_changes WHERE status_changes.owner_type = '%[1]s' AND status_changes.owner_id = %[1]s.id AND status_changes."to" = '%[2]s'), %[1]s.updated_at)`,
+		table, models.ApprovedStatus,
+	)
+}
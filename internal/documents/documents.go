@@ -0,0 +1,241 @@
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/carbon"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrNotReceiptable = errors.New("receipts can't be printed for draft or voided collections")
+	ErrNotInvoiceable = errors.New("only approved or paid transactions can be invoiced")
+	ErrNotIssued      = errors.New("the transaction's invoice hasn't been issued")
+)
+
+// receiptStatuses are the statuses of collections that can be given a receipt.
+var receiptStatuses = []models.Status{
+	models.SubmittedStatus,
+	models.WeighedStatus,
+	models.ApprovedStatus,
+	models.PaidStatus,
+	models.DisputedStatus,
+}
+
+// invoiceStatuses are the statuses of transactions that can be invoiced.
+var invoiceStatuses = []models.Status{
+	models.ApprovedStatus,
+	models.PaidStatus,
+}
+
+type Documents interface {
+	// Receipt renders the slip a collector is given for a collection: the
+	// seller and buyer, every line with its weight, value and CO2e, and a QR
+	// code linking back to the collection. It returns the PDF and its name,
+	// and gorm.ErrRecordNotFound when scope hides the collection.
+	Receipt(collectionId uuid.UUID, scope clause.Expression) ([]byte, string, error)
+	// IssueInvoice issues the seller's invoice for a transaction with the
+	// seller's next invoice number. A transaction is only invoiced once, so
+	// issuing it again returns the invoice it was given. It returns
+	// gorm.ErrRecordNotFound when scope hides the transaction.
+	IssueInvoice(transactionId uuid.UUID, scope clause.Expression, actorId uuid.UUID) (*models.Invoice, error)
+	// Invoice renders the invoice issued for a transaction. It returns the
+	// PDF and its name, gorm.ErrRecordNotFound when scope hides the
+	// transaction and ErrNotIssued when it hasn't been invoiced yet.
+	Invoice(transactionId uuid.UUID, scope clause.Expression) ([]byte, string, error)
+}
+
+type documents struct {
+	storage storage.Storage
+	carbon  carbon.Carbon
+	// collectionLink is where a receipt's QR code points, with {id} standing
+	// for the collection's id.
+	collectionLink string
+}
+
+func New(storage storage.Storage, carbon carbon.Carbon) Documents {
+	return &documents{
+		storage: storage,
+		carbon:  carbon,
+		collectionLink: common.EnvString(
+			"APP_COLLECTION_LINK",
+			strings.TrimSuffix(common.EnvString("APP_BASE_URL", "http://localhost:3000"), "/")+"/admin/collections/{id}",
+		),
+	}
+}
+
+func (d *documents) Receipt(collectionId uuid.UUID, scope clause.Expression) ([]byte, string, error) {
+	query := d.storage.Database().Model(&models.Collection{}).Where("id = ?", collectionId)
+
+	if scope != nil {
+		query = query.Clauses(scope)
+	}
+
+	var collection models.Collection
+
+	if err := query.
+		Preload("Seller").
+		Preload("Buyer").
+		Preload("Materials", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		First(&collection).Error; err != nil {
+		return nil, "", err
+	}
+
+	if !slices.Contains(receiptStatuses, collection.Status) {
+		return nil, "", ErrNotReceiptable
+	}
+
+	savings, err := d.carbon.Collection(collection.Id, nil)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	file, err := renderReceipt(&collection, savings, strings.ReplaceAll(d.collectionLink, "{id}", collection.Id.String()))
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return file, fmt.Sprintf("receipt-%s.pdf", collection.Id), nil
+}
+
+func (d *documents) IssueInvoice(transactionId uuid.UUID, scope clause.Expression, actorId uuid.UUID) (*models.Invoice, error) {
+	var invoice models.Invoice
+
+	if err := d.storage.Database().Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.Transaction{}).Where("id = ?", transactionId)
+
+		if scope != nil {
+			query = query.Clauses(scope)
+		}
+
+		// Locking the transaction keeps two requests from issuing it two
+		// invoices.
+		if err := query.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Take(&models.Transaction{}).Error; err != nil {
+			return err
+		}
+
+		err := tx.Where("transaction_id = ?", transactionId).First(&invoice).Error
+
+		if err == nil {
+			return nil
+		}
+
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		var transaction models.Transaction
+
+		if err := tx.Preload("Seller").Where("id = ?", transactionId).First(&transaction).Error; err != nil {
+			return err
+		}
+
+		if !slices.Contains(invoiceStatuses, transaction.Status) {
+			return ErrNotInvoiceable
+		}
+
+		invoice, err = d.issue(tx, &transaction, actorId)
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+func (d *documents) Invoice(transactionId uuid.UUID, scope clause.Expression) ([]byte, string, error) {
+	query := d.storage.Database().
+		Preload("Seller").
+		Preload("Buyer").
+		Preload("Materials", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Where("id = ?", transactionId)
+
+	if scope != nil {
+		query = query.Clauses(scope)
+	}
+
+	var transaction models.Transaction
+
+	if err := query.First(&transaction).Error; err != nil {
+		return nil, "", err
+	}
+
+	var invoice models.Invoice
+
+	if err := d.storage.Database().Where("transaction_id = ?", transaction.Id).First(&invoice).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrNotIssued
+		}
+
+		return nil, "", err
+	}
+
+	file, err := renderInvoice(&transaction, &invoice)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	return file, fmt.Sprintf("%s.pdf", invoice.Reference), nil
+}
+
+// issue takes the seller's next invoice number and stores the invoice with
+// the transaction's amounts, which were worked out at the VAT rate it was
+// created with. The number is taken in tx, so a rolled back invoice doesn't
+// leave a gap in the sequence.
+func (d *documents) issue(tx *gorm.DB, transaction *models.Transaction, actorId uuid.UUID) (models.Invoice, error) {
+	var number int
+
+	if err := tx.Raw(`
+		INSERT INTO invoice_sequences (business_id, last) VALUES (?, 1)
+		ON CONFLICT (business_id) DO UPDATE SET last = invoice_sequences.last + 1
+		RETURNING last
+	`, transaction.SellerId).Scan(&number).Error; err != nil {
+		return models.Invoice{}, err
+	}
+
+	var vatNumber *string
+
+	if transaction.Seller.VatRegistered {
+		vatNumber = transaction.Seller.VatNumber
+	}
+
+	invoice := models.Invoice{
+		BusinessId:    transaction.SellerId,
+		TransactionId: transaction.Id,
+		Number:        number,
+		Reference:     fmt.Sprintf("INV-%06d", number),
+		VatNumber:     vatNumber,
+		Currency:      transaction.Currency,
+		VatRate:       transaction.VatRate,
+		Net:           transaction.Net,
+		Vat:           transaction.Vat,
+		Gross:         transaction.Gross,
+		IssuedAt:      time.Now(),
+		IssuedById:    actorId,
+	}
+
+	if err := tx.Omit(clause.Associations).Create(&invoice).Error; err != nil {
+		return models.Invoice{}, err
+	}
+
+	return invoice, nil
+}
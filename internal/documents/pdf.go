@@ -0,0 +1,432 @@
+package documents
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/qr"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/go-pdf/fpdf"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// margin is the page margin of every document, in mm.
+const margin = 10.0
+
+// column is a column of a document's table. Width is in mm, and Align is an
+// fpdf alignment such as "L" or "R".
+type column struct {
+	Title string
+	Width float64
+	Align string
+}
+
+// document is an fpdf document with the layouts receipts and invoices share.
+// Text goes through translate because the core fonts only cover cp1252.
+type document struct {
+	pdf       *fpdf.Fpdf
+	translate func(string) string
+}
+
+func newDocument(size string, title string) *document {
+	pdf := fpdf.New("P", "mm", size, "")
+
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(true, margin)
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("3rEco", true)
+	pdf.AddPage()
+
+	return &document{
+		pdf:       pdf,
+		translate: pdf.UnicodeTranslatorFromDescriptor(""),
+	}
+}
+
+// width is the width of the page between the margins.
+func (d *document) width() float64 {
+	width, _ := d.pdf.GetPageSize()
+
+	return width - 2*margin
+}
+
+// heading writes the document's title with its details, such as its number
+// and date, as label and value pairs under it.
+func (d *document) heading(title string, details [][2]string) {
+	d.pdf.SetFont("Helvetica", "B", 16)
+	d.pdf.CellFormat(0, 9, d.translate(title), "", 1, "L", false, 0, "")
+	d.pdf.Ln(1)
+
+	for _, detail := range details {
+		d.pdf.SetFont("Helvetica", "B", 9)
+		d.pdf.CellFormat(30, 5, d.translate(detail[0]), "", 0, "L", false, 0, "")
+		d.pdf.SetFont("Helvetica", "", 9)
+		d.pdf.CellFormat(0, 5, d.translate(detail[1]), "", 1, "L", false, 0, "")
+	}
+
+	d.pdf.Ln(4)
+}
+
+// parties writes the two sides of a sale next to each other, each with a
+// label and its lines.
+func (d *document) parties(leftLabel string, left []string, rightLabel string, right []string) {
+	half := d.width() / 2
+	top := d.pdf.GetY()
+
+	bottom := d.party(margin, top, half-2, leftLabel, left)
+	bottom = max(bottom, d.party(margin+half+2, top, half-2, rightLabel, right))
+
+	d.pdf.SetXY(margin, bottom+4)
+}
+
+func (d *document) party(x float64, y float64, width float64, label string, lines []string) float64 {
+	d.pdf.SetXY(x, y)
+	d.pdf.SetFont("Helvetica", "B", 9)
+	d.pdf.CellFormat(width, 5, d.translate(strings.ToUpper(label)), "B", 2, "L", false, 0, "")
+	d.pdf.Ln(1)
+
+	for index, line := range lines {
+		d.pdf.SetX(x)
+
+		if index == 0 {
+			d.pdf.SetFont("Helvetica", "B", 10)
+		} else {
+			d.pdf.SetFont("Helvetica", "", 9)
+		}
+
+		d.pdf.MultiCell(width, 5, d.translate(line), "", "L", false)
+	}
+
+	return d.pdf.GetY()
+}
+
+// table writes a table with a shaded header row. Widths that add up to less
+// than the page leave the remainder to the first column.
+func (d *document) table(columns []column, rows [][]string) {
+	used := 0.0
+
+	for _, column := range columns[1:] {
+		used += column.Width
+	}
+
+	columns[0].Width = d.width() - used
+
+	d.pdf.SetFont("Helvetica", "B", 9)
+	d.pdf.SetFillColor(230, 236, 230)
+
+	for _, column := range columns {
+		d.pdf.CellFormat(column.Width, 7, d.translate(column.Title), "B", 0, column.Align, true, 0, "")
+	}
+
+	d.pdf.Ln(-1)
+	d.pdf.SetFont("Helvetica", "", 9)
+
+	for _, row := range rows {
+		for index, column := range columns {
+			d.pdf.CellFormat(column.Width, 6, d.translate(row[index]), "B", 0, column.Align, false, 0, "")
+		}
+
+		d.pdf.Ln(-1)
+	}
+
+	d.pdf.Ln(3)
+}
+
+// totals writes label and value pairs aligned to the right of the page. The
+// last pair is printed in bold.
+func (d *document) totals(rows [][2]string) {
+	for index, row := range rows {
+		style := ""
+
+		if index == len(rows)-1 {
+			style = "B"
+		}
+
+		d.pdf.SetFont("Helvetica", style, 10)
+		d.pdf.SetX(margin + d.width() - 90)
+		d.pdf.CellFormat(55, 6, d.translate(row[0]), "", 0, "R", false, 0, "")
+		d.pdf.CellFormat(35, 6, d.translate(row[1]), "", 1, "R", false, 0, "")
+	}
+
+	d.pdf.Ln(4)
+}
+
+// note writes a paragraph of small print.
+func (d *document) note(text string) {
+	d.pdf.SetFont("Helvetica", "", 8)
+	d.pdf.MultiCell(0, 4, d.translate(text), "", "L", false)
+	d.pdf.Ln(2)
+}
+
+// qrCode writes a QR code for link, which is also a clickable link, with a
+// caption next to it.
+func (d *document) qrCode(link string, caption string) error {
+	code, err := qr.Encode(link, qr.M, qr.Auto)
+
+	if err != nil {
+		return err
+	}
+
+	code, err = barcode.Scale(code, 256, 256)
+
+	if err != nil {
+		return err
+	}
+
+	var image bytes.Buffer
+
+	if err := png.Encode(&image, code); err != nil {
+		return err
+	}
+
+	options := fpdf.ImageOptions{ImageType: "PNG"}
+	size := 30.0
+
+	if d.pdf.GetY()+size > d.pageBottom() {
+		d.pdf.AddPage()
+	}
+
+	x, y := margin, d.pdf.GetY()
+
+	d.pdf.RegisterImageOptionsReader("qr", options, &image)
+	d.pdf.ImageOptions("qr", x, y, size, size, false, options, 0, link)
+	d.pdf.SetXY(x+size+4, y+size/2-6)
+	d.pdf.SetFont("Helvetica", "", 8)
+	d.pdf.MultiCell(d.width()-size-4, 4, d.translate(caption+"\n"+link), "", "L", false)
+	d.pdf.SetXY(margin, y+size+4)
+
+	return nil
+}
+
+func (d *document) pageBottom() float64 {
+	_, height := d.pdf.GetPageSize()
+
+	return height - margin
+}
+
+func (d *document) bytes() ([]byte, error) {
+	var buffer bytes.Buffer
+
+	if err := d.pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// renderReceipt writes an A5 collection receipt.
+func renderReceipt(collection *models.Collection, savings *models.CarbonSavings, link string) ([]byte, error) {
+	document := newDocument("A5", "Collection Receipt")
+
+	document.heading("Collection Receipt", [][2]string{
+		{"Receipt no.", shortId(collection.Id)},
+		{"Date", collection.CreatedAt.Format("2 January 2006 15:04")},
+		{"Status", string(collection.Status)},
+	})
+
+	seller := []string{collection.Seller.Name}
+
+	if collection.Seller.IdNumber != nil {
+		seller = append(seller, "ID "+*collection.Seller.IdNumber)
+	}
+
+	document.parties(
+		"Sold by", append(seller, addressLines(collection.Seller.Address)...),
+		"Bought by", businessLines(&collection.Buyer),
+	)
+
+	// The savings are per material, and a material's lines share its factor,
+	// so each line gets the part of its material's CO2e that its weight is.
+	materials := map[string]models.MaterialCarbonSavings{}
+
+	for _, material := range savings.Materials {
+		materials[materialKey(material.MaterialId, material.GWCode)] = material
+	}
+
+	rows := [][]string{}
+	weight, value := 0.0, decimal.Zero
+
+	for _, line := range collection.Materials {
+		co2e := 0.0
+
+		if material, ok := materials[materialKey(line.MaterialId, line.GWCode)]; ok && material.Weight != 0 {
+			co2e = material.CO2e * line.Weight / material.Weight
+		}
+
+		rows = append(rows, []string{
+			fmt.Sprintf("%s (%s)", line.Name, line.GWCode),
+			quantity(line.Weight),
+			money(line.Value),
+			quantity(co2e),
+		})
+
+		weight += line.Weight
+		value = value.Add(line.Value)
+	}
+
+	document.table([]column{
+		{Title: "Material", Align: "L"},
+		{Title: "Weight (kg)", Width: 22, Align: "R"},
+		{Title: fmt.Sprintf("Value (%s)", collection.Currency), Width: 22, Align: "R"},
+		{Title: "CO2e (kg)", Width: 20, Align: "R"},
+	}, rows)
+
+	document.totals([][2]string{
+		{"Total weight (kg)", quantity(weight)},
+		{"CO2e saved (kg)", quantity(savings.CO2e)},
+		{fmt.Sprintf("Total value (%s)", collection.Currency), money(value)},
+	})
+
+	if err := document.qrCode(link, "Scan to view this collection."); err != nil {
+		return nil, err
+	}
+
+	return document.bytes()
+}
+
+// renderInvoice writes an A4 invoice. It is headed as a tax invoice when the
+// seller was registered for VAT when it was issued.
+func renderInvoice(transaction *models.Transaction, invoice *models.Invoice) ([]byte, error) {
+	title := "Invoice"
+
+	if invoice.VatNumber != nil {
+		title = "Tax Invoice"
+	}
+
+	document := newDocument("A4", title)
+
+	document.heading(title, [][2]string{
+		{"Invoice no.", invoice.Reference},
+		{"Date", invoice.IssuedAt.Format("2 January 2006")},
+		{"Transaction", transaction.Id.String()},
+	})
+
+	seller := businessLines(&transaction.Seller)
+
+	if invoice.VatNumber != nil {
+		seller = append(seller, "VAT no. "+*invoice.VatNumber)
+	}
+
+	document.parties(
+		"From", seller,
+		"To", businessLines(&transaction.Buyer),
+	)
+
+	rows := [][]string{}
+
+	for _, line := range transaction.Materials {
+		price := decimal.Zero
+
+		if line.Weight != 0 {
+			price = line.Value.Div(decimal.NewFromFloat(line.Weight))
+		}
+
+		rows = append(rows, []string{
+			line.Name,
+			line.GWCode,
+			quantity(line.Weight),
+			money(price),
+			money(line.Value),
+			money(line.Vat),
+			money(line.Value.Add(line.Vat)),
+		})
+	}
+
+	document.table([]column{
+		{Title: "Material", Align: "L"},
+		{Title: "GW code", Width: 22, Align: "L"},
+		{Title: "Weight (kg)", Width: 22, Align: "R"},
+		{Title: "Price/kg", Width: 20, Align: "R"},
+		{Title: "Net", Width: 24, Align: "R"},
+		{Title: "VAT", Width: 22, Align: "R"},
+		{Title: "Amount", Width: 26, Align: "R"},
+	}, rows)
+
+	document.totals([][2]string{
+		{"Net", money(invoice.Net)},
+		{fmt.Sprintf("VAT (%s%%)", invoice.VatRate.String()), money(invoice.Vat)},
+		{fmt.Sprintf("Total (%s)", invoice.Currency), money(invoice.Gross)},
+	})
+
+	if invoice.VatNumber == nil {
+		document.note("The seller is not registered for VAT, so no VAT is charged.")
+	}
+
+	if bankDetails := transaction.Seller.BankDetails; bankDetails != nil {
+		document.note(fmt.Sprintf(
+			"Please pay into %s, account %s, branch %s, %s, using %s as the reference.",
+			bankDetails.AccountHolder,
+			bankDetails.AccountNumber,
+			bankDetails.BranchCode,
+			bankDetails.BankName,
+			invoice.Reference,
+		))
+	}
+
+	return document.bytes()
+}
+
+func businessLines(business *models.Business) []string {
+	return append([]string{business.Name}, addressLines(business.Address)...)
+}
+
+func addressLines(address *models.Address) []string {
+	if address == nil {
+		return nil
+	}
+
+	lines := []string{address.LineOne}
+
+	if address.LineTwo != nil && *address.LineTwo != "" {
+		lines = append(lines, *address.LineTwo)
+	}
+
+	return append(lines,
+		strings.Join([]string{address.City, address.ZipCode}, " "),
+		strings.Join([]string{address.Province, address.Country}, ", "),
+	)
+}
+
+func materialKey(materialId *uuid.UUID, gwCode string) string {
+	if materialId != nil {
+		return materialId.String()
+	}
+
+	return gwCode
+}
+
+// shortId is the first block of an id, which is enough for a person to quote.
+func shortId(id uuid.UUID) string {
+	return strings.ToUpper(strings.SplitN(id.String(), "-", 2)[0])
+}
+
+func quantity(value float64) string {
+	return group(strconv.FormatFloat(value, 'f', 2, 64))
+}
+
+// money formats an amount to the cent, rounding half away from zero.
+func money(value decimal.Decimal) string {
+	return group(value.StringFixed(2))
+}
+
+// group separates the thousands of a formatted number with spaces.
+func group(number string) string {
+	sign := ""
+
+	if strings.HasPrefix(number, "-") {
+		sign, number = "-", number[1:]
+	}
+
+	whole, fraction, _ := strings.Cut(number, ".")
+
+	for index := len(whole) - 3; index > 0; index -= 3 {
+		whole = whole[:index] + " " + whole[index:]
+	}
+
+	return sign + whole + "." + fraction
+}
@@ -0,0 +1,145 @@
+package impersonation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotImpersonating = errors.New("the session is not impersonating a user")
+	ErrEnded            = errors.New("the impersonation has ended")
+	ErrInvalidTarget    = errors.New("only collector and business users can be impersonated")
+	ErrSelf             = errors.New("you can't impersonate yourself")
+)
+
+// sessionKey holds the id of the active impersonation. The session's user_id
+// keeps pointing at the real actor for the whole impersonation.
+const sessionKey = "impersonation_id"
+
+type Impersonation interface {
+	Start(currentSession *session.Session, actor *models.User, target *models.User, reason string) (*models.Impersonation, error)
+	Stop(currentSession *session.Session) (*models.Impersonation, error)
+	Resolve(currentSession *session.Session, actorId uuid.UUID) (*models.Impersonation, error)
+}
+
+type impersonation struct {
+	storage  storage.Storage
+	lifetime time.Duration
+}
+
+func New(storage storage.Storage) Impersonation {
+	return &impersonation{
+		storage:  storage,
+		lifetime: common.EnvDuration("APP_IMPERSONATION_LIFETIME", 1*time.Hour),
+	}
+}
+
+func (i *impersonation) Start(currentSession *session.Session, actor *models.User, target *models.User, reason string) (*models.Impersonation, error) {
+	if actor.Id == target.Id {
+		return nil, ErrSelf
+	}
+
+	if target.Type != models.CollectorUser && target.Type != models.BusinessUser {
+		return nil, ErrInvalidTarget
+	}
+
+	impersonation := models.Impersonation{
+		ActorId:   actor.Id,
+		UserId:    target.Id,
+		ExpiresAt: time.Now().Add(i.lifetime),
+	}
+
+	if reason != "" {
+		impersonation.Reason = &reason
+	}
+
+	if err := i.storage.Database().Create(&impersonation).Error; err != nil {
+		return nil, err
+	}
+
+	currentSession.Set(sessionKey, impersonation.Id.String())
+
+	if err := currentSession.Save(); err != nil {
+		return nil, err
+	}
+
+	return &impersonation, nil
+}
+
+func (i *impersonation) Stop(currentSession *session.Session) (*models.Impersonation, error) {
+	rawId, ok := currentSession.Get(sessionKey).(string)
+
+	if !ok || rawId == "" {
+		return nil, ErrNotImpersonating
+	}
+
+	currentSession.Delete(sessionKey)
+
+	if err := currentSession.Save(); err != nil {
+		return nil, err
+	}
+
+	var impersonation models.Impersonation
+
+	if err := i.storage.Database().Where("id = ?", rawId).First(&impersonation).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrNotImpersonating
+		}
+
+		return nil, err
+	}
+
+	if impersonation.EndedAt == nil {
+		now := time.Now()
+
+		impersonation.EndedAt = &now
+
+		if err := i.storage.Database().
+			Model(&impersonation).
+			Update("ended_at", now).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return &impersonation, nil
+}
+
+// Resolve returns the session's active impersonation, or nil when the session
+// isn't impersonating anyone. Expired impersonations are stopped and reported
+// with ErrEnded so the caller never silently falls back to the real actor.
+func (i *impersonation) Resolve(currentSession *session.Session, actorId uuid.UUID) (*models.Impersonation, error) {
+	rawId, ok := currentSession.Get(sessionKey).(string)
+
+	if !ok || rawId == "" {
+		return nil, nil
+	}
+
+	var impersonation models.Impersonation
+
+	err := i.storage.Database().
+		Where("id = ? AND actor_id = ?", rawId, actorId).
+		First(&impersonation).Error
+
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+
+	if err == gorm.ErrRecordNotFound || impersonation.EndedAt != nil || !impersonation.ExpiresAt.After(time.Now()) {
+		stopped, stopErr := i.Stop(currentSession)
+
+		if stopErr != nil && !errors.Is(stopErr, ErrNotImpersonating) {
+			return nil, stopErr
+		}
+
+		return stopped, ErrEnded
+	}
+
+	return &impersonation, nil
+}
@@ -0,0 +1,327 @@
+package inventory
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidQuantity = errors.New("the quantity must be a number other than zero")
+	ErrReasonRequired  = errors.New("an adjustment needs a reason")
+)
+
+// maxQuantity is the largest quantity that fits the decimal(12,2) column.
+const maxQuantity = 9999999999.99
+
+// Level is how much of a material a business holds.
+type Level struct {
+	MaterialId uuid.UUID `json:"materialId"`
+	Name       string    `json:"name"`
+	GWCode     string    `json:"gwCode"`
+	Quantity   float64   `json:"quantity"`
+}
+
+// Shortfall is a material a transaction sells more of than its seller holds.
+type Shortfall struct {
+	MaterialId uuid.UUID `json:"materialId"`
+	Name       string    `json:"name"`
+	GWCode     string    `json:"gwCode"`
+	Available  float64   `json:"available"`
+	Required   float64   `json:"required"`
+	Shortfall  float64   `json:"shortfall"`
+}
+
+type Inventory interface {
+	// Book updates the ledger for a status change of a collection or
+	// transaction, inside the transaction tx that changes the status. Stock
+	// is booked when a record is approved and reversed when an approved
+	// record is disputed or voided.
+	Book(tx *gorm.DB, entity models.PolicyType, id uuid.UUID, from models.Status, to models.Status, reason *string, actorId uuid.UUID) error
+	// Stock returns what the business holds of every material it has
+	// movements for.
+	Stock(businessId uuid.UUID) ([]Level, error)
+	// Movements returns the business's ledger, optionally for one material
+	// and a period, newest first.
+	Movements(businessId uuid.UUID, materialId *uuid.UUID, from *time.Time, to *time.Time) ([]models.StockMovement, error)
+	// Adjust records a manual adjustment. It returns gorm.ErrRecordNotFound
+	// when the material doesn't exist.
+	Adjust(businessId uuid.UUID, payload models.StockAdjustmentPayload, actorId uuid.UUID) (*models.StockMovement, error)
+	// Shortfalls returns the materials the transaction would drive its
+	// seller's stock negative in, ignoring what the transaction itself has
+	// already booked.
+	Shortfalls(transaction *models.Transaction) ([]Shortfall, error)
+}
+
+type inventory struct {
+	storage storage.Storage
+}
+
+func New(storage storage.Storage) Inventory {
+	return &inventory{
+		storage: storage,
+	}
+}
+
+func (i *inventory) Book(tx *gorm.DB, entity models.PolicyType, id uuid.UUID, from models.Status, to models.Status, reason *string, actorId uuid.UUID) error {
+	switch {
+	case to == models.ApprovedStatus:
+		return i.book(tx, entity, id, reason, actorId)
+	case from == models.ApprovedStatus && (to == models.DisputedStatus || to == models.VoidedStatus):
+		return i.reverse(tx, entity, id, reason, actorId)
+	}
+
+	return nil
+}
+
+// book moves the weight of every catalogue line of the record: into the
+// buyer's stock, and for transactions out of the seller's stock as well.
+func (i *inventory) book(tx *gorm.DB, entity models.PolicyType, id uuid.UUID, reason *string, actorId uuid.UUID) error {
+	movements := []models.StockMovement{}
+
+	switch entity {
+	case models.CollectionsPolicy:
+		var collection models.Collection
+
+		if err := tx.Preload("Materials").Where("id = ?", id).First(&collection).Error; err != nil {
+			return err
+		}
+
+		for _, line := range collection.Materials {
+			if line.MaterialId == nil {
+				continue
+			}
+
+			movements = append(movements, movement(collection.BuyerId, *line.MaterialId, line.Weight, models.CollectionStockMovement, id, line.Id, reason, actorId))
+		}
+	case models.TransactionsPolicy:
+		var transaction models.Transaction
+
+		if err := tx.Preload("Materials").Where("id = ?", id).First(&transaction).Error; err != nil {
+			return err
+		}
+
+		for _, line := range transaction.Materials {
+			if line.MaterialId == nil {
+				continue
+			}
+
+			movements = append(movements,
+				movement(transaction.SellerId, *line.MaterialId, -line.Weight, models.TransactionStockMovement, id, line.Id, reason, actorId),
+				movement(transaction.BuyerId, *line.MaterialId, line.Weight, models.TransactionStockMovement, id, line.Id, reason, actorId),
+			)
+		}
+	default:
+		return nil
+	}
+
+	if len(movements) == 0 {
+		return nil
+	}
+
+	return tx.Omit("Material").Create(&movements).Error
+}
+
+// reverse cancels what the record has booked so far, so approving it again
+// books it afresh.
+func (i *inventory) reverse(tx *gorm.DB, entity models.PolicyType, id uuid.UUID, reason *string, actorId uuid.UUID) error {
+	var movementType models.StockMovementType
+
+	switch entity {
+	case models.CollectionsPolicy:
+		movementType = models.CollectionStockMovement
+	case models.TransactionsPolicy:
+		movementType = models.TransactionStockMovement
+	default:
+		return nil
+	}
+
+	balances := []struct {
+		BusinessId uuid.UUID
+		MaterialId uuid.UUID
+		LineId     *uuid.UUID
+		Quantity   float64
+	}{}
+
+	if err := tx.Model(&models.StockMovement{}).
+		Select("business_id, material_id, line_id, SUM(quantity) AS quantity").
+		Where("type = ? AND source_id = ?", movementType, id).
+		Group("business_id, material_id, line_id").
+		Having("SUM(quantity) <> 0").
+		Scan(&balances).Error; err != nil {
+		return err
+	}
+
+	movements := []models.StockMovement{}
+
+	for _, balance := range balances {
+		reversal := movement(balance.BusinessId, balance.MaterialId, -balance.Quantity, movementType, id, uuid.Nil, reason, actorId)
+		reversal.LineId = balance.LineId
+
+		movements = append(movements, reversal)
+	}
+
+	if len(movements) == 0 {
+		return nil
+	}
+
+	return tx.Omit("Material").Create(&movements).Error
+}
+
+func (i *inventory) Stock(businessId uuid.UUID) ([]Level, error) {
+	levels := []Level{}
+
+	if err := i.storage.Database().
+		Model(&models.StockMovement{}).
+		Select("stock_movements.material_id, materials.name, materials.gw_code, SUM(stock_movements.quantity) AS quantity").
+		Joins("JOIN materials ON materials.id = stock_movements.material_id").
+		Where("stock_movements.business_id = ?", businessId).
+		Group("stock_movements.material_id, materials.name, materials.gw_code").
+		Order("materials.name ASC").
+		Scan(&levels).Error; err != nil {
+		return nil, err
+	}
+
+	return levels, nil
+}
+
+func (i *inventory) Movements(businessId uuid.UUID, materialId *uuid.UUID, from *time.Time, to *time.Time) ([]models.StockMovement, error) {
+	query := i.storage.Database().Where("business_id = ?", businessId)
+
+	if materialId != nil {
+		query = query.Where("material_id = ?", *materialId)
+	}
+
+	if from != nil {
+		query = query.Where("created_at >= ?", *from)
+	}
+
+	if to != nil {
+		query = query.Where("created_at < ?", *to)
+	}
+
+	movements := []models.StockMovement{}
+
+	if err := query.
+		Preload("Material").
+		Order("created_at DESC").
+		Find(&movements).Error; err != nil {
+		return nil, err
+	}
+
+	return movements, nil
+}
+
+func (i *inventory) Adjust(businessId uuid.UUID, payload models.StockAdjustmentPayload, actorId uuid.UUID) (*models.StockMovement, error) {
+	quantity := math.Round(payload.Quantity*100) / 100
+
+	if math.IsNaN(quantity) || quantity == 0 || math.Abs(quantity) > maxQuantity {
+		return nil, ErrInvalidQuantity
+	}
+
+	reason := strings.TrimSpace(payload.Reason)
+
+	if reason == "" {
+		return nil, ErrReasonRequired
+	}
+
+	var material models.Material
+
+	if err := i.storage.Database().Where("id = ?", payload.MaterialId).First(&material).Error; err != nil {
+		return nil, err
+	}
+
+	adjustment := movement(businessId, material.Id, quantity, models.AdjustmentStockMovement, uuid.Nil, uuid.Nil, &reason, actorId)
+
+	if err := i.storage.Database().Omit("Material").Create(&adjustment).Error; err != nil {
+		return nil, err
+	}
+
+	adjustment.Material = &material
+
+	return &adjustment, nil
+}
+
+func (i *inventory) Shortfalls(transaction *models.Transaction) ([]Shortfall, error) {
+	required := map[uuid.UUID]float64{}
+	materialIds := []uuid.UUID{}
+
+	for _, line := range transaction.Materials {
+		if line.MaterialId == nil {
+			continue
+		}
+
+		if _, ok := required[*line.MaterialId]; !ok {
+			materialIds = append(materialIds, *line.MaterialId)
+		}
+
+		required[*line.MaterialId] += line.Weight
+	}
+
+	shortfalls := []Shortfall{}
+
+	if len(materialIds) == 0 {
+		return shortfalls, nil
+	}
+
+	available := []Level{}
+
+	if err := i.storage.Database().
+		Model(&models.Material{}).
+		Select("materials.id AS material_id, materials.name, materials.gw_code, COALESCE(SUM(stock_movements.quantity), 0) AS quantity").
+		Joins(
+			"LEFT JOIN stock_movements ON stock_movements.material_id = materials.id AND stock_movements.business_id = ? AND (stock_movements.type <> ? OR stock_movements.source_id <> ?)",
+			transaction.SellerId, models.TransactionStockMovement, transaction.Id,
+		).
+		Where("materials.id IN ?", materialIds).
+		Group("materials.id, materials.name, materials.gw_code").
+		Scan(&available).Error; err != nil {
+		return nil, err
+	}
+
+	for _, level := range available {
+		needed := math.Round(required[level.MaterialId]*100) / 100
+
+		if level.Quantity >= needed {
+			continue
+		}
+
+		shortfalls = append(shortfalls, Shortfall{
+			MaterialId: level.MaterialId,
+			Name:       level.Name,
+			GWCode:     level.GWCode,
+			Available:  level.Quantity,
+			Required:   needed,
+			Shortfall:  math.Round((needed-level.Quantity)*100) / 100,
+		})
+	}
+
+	return shortfalls, nil
+}
+
+func movement(businessId uuid.UUID, materialId uuid.UUID, quantity float64, movementType models.StockMovementType, sourceId uuid.UUID, lineId uuid.UUID, reason *string, actorId uuid.UUID) models.StockMovement {
+	movement := models.StockMovement{
+		BusinessId: businessId,
+		MaterialId: materialId,
+		Quantity:   quantity,
+		Type:       movementType,
+		Reason:     reason,
+		ActorId:    &actorId,
+	}
+
+	if sourceId != uuid.Nil {
+		movement.SourceId = &sourceId
+	}
+
+	if lineId != uuid.Nil {
+		movement.LineId = &lineId
+	}
+
+	return movement
+}
@@ -0,0 +1,343 @@
+package invitations
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/notifications"
+	"github.com/connor-davis/threereco-nextgen/internal/permissions"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidInvitation = errors.New("the invitation is invalid, expired, revoked or already accepted")
+	ErrInvalidRecipient  = errors.New("the recipient must be an email address or a phone number")
+	ErrInvalidUserType   = errors.New("invitations can only be sent to business or collector users")
+	ErrRoleNotHeld       = errors.New("you can only invite with a role whose permissions you already have")
+	ErrNotPending        = errors.New("the invitation is no longer pending")
+)
+
+var phonePattern = regexp.MustCompile(`^\+?[0-9]{7,15}$`)
+
+type Invitations interface {
+	Invite(business *models.Business, inviter *models.User, payload models.CreateInvitationPayload) (*models.Invitation, error)
+	Resend(invitation *models.Invitation) error
+	Revoke(invitation *models.Invitation) error
+	Resolve(token string) (*models.Invitation, error)
+	Accept(invitation *models.Invitation, user *models.User) error
+}
+
+type invitations struct {
+	storage       storage.Storage
+	notifications notifications.Notifications
+	secret        []byte
+	lifetime      time.Duration
+	baseUrl       string
+}
+
+func New(storage storage.Storage, notifications notifications.Notifications) Invitations {
+	secret := []byte(common.EnvString("APP_INVITATION_SECRET", ""))
+
+	if len(secret) == 0 {
+		log.Warnf("⚠️ APP_INVITATION_SECRET is not set, invitation links will stop working when the api restarts")
+
+		secret = make([]byte, 32)
+
+		if _, err := rand.Read(secret); err != nil {
+			log.Errorf("🔥 Failed to generate invitation secret: %s", err.Error())
+		}
+	}
+
+	return &invitations{
+		storage:       storage,
+		notifications: notifications,
+		secret:        secret,
+		lifetime:      common.EnvDuration("APP_INVITATION_LIFETIME", 7*24*time.Hour),
+		baseUrl:       strings.TrimSuffix(common.EnvString("APP_BASE_URL", "http://localhost:3000"), "/"),
+	}
+}
+
+// NormalizeRecipient lowercases email addresses and strips formatting from
+// phone numbers so that the recipient can be used as a username.
+func NormalizeRecipient(recipient string) (string, error) {
+	recipient = strings.TrimSpace(recipient)
+
+	if strings.Contains(recipient, "@") {
+		return strings.ToLower(recipient), nil
+	}
+
+	phone := strings.NewReplacer(" ", "", "-", "", "(", "", ")", "").Replace(recipient)
+
+	if !phonePattern.MatchString(phone) {
+		return "", ErrInvalidRecipient
+	}
+
+	return phone, nil
+}
+
+func (i *invitations) sign(invitation *models.Invitation) string {
+	mac := hmac.New(sha256.New, i.secret)
+
+	fmt.Fprintf(mac, "%s|%s|%d", invitation.Id, invitation.Nonce, invitation.ExpiresAt.Unix())
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (i *invitations) token(invitation *models.Invitation) string {
+	return fmt.Sprintf("%s.%s", invitation.Id, i.sign(invitation))
+}
+
+func newNonce() (string, error) {
+	bytes := make([]byte, 16)
+
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func (i *invitations) Invite(business *models.Business, inviter *models.User, payload models.CreateInvitationPayload) (*models.Invitation, error) {
+	recipient, err := NormalizeRecipient(payload.Recipient)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The invitation is only stored when it can be sent.
+	if err := i.notifications.Check(recipient); err != nil {
+		return nil, err
+	}
+
+	userType := payload.UserType
+
+	if userType == "" {
+		userType = models.BusinessUser
+	}
+
+	if userType != models.BusinessUser && userType != models.CollectorUser {
+		return nil, ErrInvalidUserType
+	}
+
+	var role models.Role
+
+	if err := i.storage.Database().
+		Where("id = ? AND (business_id IS NULL OR business_id = ?)", payload.RoleId, business.Id).
+		First(&role).Error; err != nil {
+		return nil, err
+	}
+
+	inviterPermissions := permissions.Effective(inviter)
+
+	for _, permission := range role.Permissions {
+		if !permissions.Allows(inviterPermissions, permission) {
+			return nil, ErrRoleNotHeld
+		}
+	}
+
+	nonce, err := newNonce()
+
+	if err != nil {
+		return nil, err
+	}
+
+	invitation := models.Invitation{
+		BusinessId:  business.Id,
+		Recipient:   recipient,
+		UserType:    userType,
+		RoleId:      role.Id,
+		Status:      models.PendingInvitation,
+		Nonce:       nonce,
+		ExpiresAt:   time.Now().Add(i.lifetime),
+		SentAt:      time.Now(),
+		InvitedById: inviter.Id,
+	}
+
+	if err := i.storage.Database().Create(&invitation).Error; err != nil {
+		return nil, err
+	}
+
+	invitation.Business = *business
+	invitation.Role = role
+
+	if err := i.send(&invitation); err != nil {
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (i *invitations) send(invitation *models.Invitation) error {
+	token := i.token(invitation)
+
+	if err := i.notifications.Send(notifications.Message{
+		Type:    notifications.InvitationMessage,
+		To:      invitation.Recipient,
+		Subject: fmt.Sprintf("You have been invited to join %s", invitation.Business.Name),
+		Body: fmt.Sprintf(
+			"You have been invited to join %s as %s.\n\nAccept the invitation: %s/invitations/accept?token=%s\n\nOr enter this code: %s\n\nThe invitation expires on %s.",
+			invitation.Business.Name,
+			invitation.Role.Name,
+			i.baseUrl,
+			token,
+			token,
+			invitation.ExpiresAt.Format(time.RFC1123),
+		),
+	}); err != nil {
+		return err
+	}
+
+	return i.storage.Database().
+		Model(invitation).
+		Updates(map[string]any{
+			"sent_at":    invitation.SentAt,
+			"send_count": gorm.Expr("send_count + 1"),
+		}).Error
+}
+
+// Resend rotates the invitation's nonce and expiry before sending it again,
+// which invalidates any link that was sent before.
+func (i *invitations) Resend(invitation *models.Invitation) error {
+	if invitation.Status != models.PendingInvitation {
+		return ErrNotPending
+	}
+
+	nonce, err := newNonce()
+
+	if err != nil {
+		return err
+	}
+
+	invitation.Nonce = nonce
+	invitation.ExpiresAt = time.Now().Add(i.lifetime)
+	invitation.SentAt = time.Now()
+
+	if err := i.storage.Database().
+		Model(invitation).
+		Updates(map[string]any{
+			"nonce":      invitation.Nonce,
+			"expires_at": invitation.ExpiresAt,
+		}).Error; err != nil {
+		return err
+	}
+
+	return i.send(invitation)
+}
+
+func (i *invitations) Revoke(invitation *models.Invitation) error {
+	if invitation.Status != models.PendingInvitation {
+		return ErrNotPending
+	}
+
+	now := time.Now()
+
+	return i.storage.Database().
+		Model(invitation).
+		Updates(map[string]any{
+			"status":     models.RevokedInvitation,
+			"revoked_at": now,
+		}).Error
+}
+
+func (i *invitations) Resolve(token string) (*models.Invitation, error) {
+	rawId, signature, found := strings.Cut(strings.TrimSpace(token), ".")
+
+	if !found {
+		return nil, ErrInvalidInvitation
+	}
+
+	id, err := uuid.Parse(rawId)
+
+	if err != nil {
+		return nil, ErrInvalidInvitation
+	}
+
+	var invitation models.Invitation
+
+	if err := i.storage.Database().
+		Preload("Business").
+		Preload("Role").
+		Where("id = ?", id).
+		First(&invitation).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrInvalidInvitation
+		}
+
+		return nil, err
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(i.sign(&invitation))) {
+		return nil, ErrInvalidInvitation
+	}
+
+	if invitation.Status != models.PendingInvitation || !invitation.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidInvitation
+	}
+
+	return &invitation, nil
+}
+
+// Accept links the user to the invitation's business. A user without an id is
+// created in the same transaction, so a failed accept leaves no account behind.
+func (i *invitations) Accept(invitation *models.Invitation, user *models.User) error {
+	return i.storage.Database().Transaction(func(tx *gorm.DB) error {
+		if user.Id == uuid.Nil {
+			if err := tx.Create(user).Error; err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+
+		result := tx.Model(&models.Invitation{}).
+			Where("id = ? AND status = ?", invitation.Id, models.PendingInvitation).
+			Updates(map[string]any{
+				"status":         models.AcceptedInvitation,
+				"accepted_by_id": user.Id,
+				"accepted_at":    now,
+			})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrInvalidInvitation
+		}
+
+		if err := tx.Model(user).Association("Businesses").Append(&invitation.Business); err != nil {
+			return err
+		}
+
+		membershipRole := models.MembershipRole{
+			BusinessId: invitation.BusinessId,
+			UserId:     user.Id,
+			RoleId:     invitation.RoleId,
+		}
+
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&membershipRole).Error; err != nil {
+			return err
+		}
+
+		if user.BusinessId == nil {
+			if err := tx.Model(user).Update("business_id", invitation.BusinessId).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
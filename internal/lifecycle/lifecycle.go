@@ -0,0 +1,299 @@
+package lifecycle
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/threereco-nextgen/internal/inventory"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUnknownTransition = errors.New("the transition does not exist")
+	ErrInvalidTransition = errors.New("the transition is not allowed from the current status")
+	ErrReasonRequired    = errors.New("the transition needs a reason")
+	ErrHeldByPayout      = errors.New("the collection is held by an open payout run, which must be confirmed or cancelled first")
+)
+
+// Transition moves a collection or transaction from one of From to To. Every
+// transition has its own permission, <entity>.status.<name>, so roles decide
+// who may take it.
+type Transition struct {
+	Name           string
+	Description    string
+	From           []models.Status
+	To             models.Status
+	ReasonRequired bool
+}
+
+// Transitions is the lifecycle shared by collections and transactions. Paid
+// and voided are final.
+var Transitions = []Transition{
+	{
+		Name:        "submit",
+		Description: "Submits a draft for weighing.",
+		From:        []models.Status{models.DraftStatus},
+		To:          models.SubmittedStatus,
+	},
+	{
+		Name:        "weigh",
+		Description: "Confirms the weights of a submitted record.",
+		From:        []models.Status{models.SubmittedStatus},
+		To:          models.WeighedStatus,
+	},
+	{
+		Name:        "approve",
+		Description: "Approves a weighed record, locking its lines.",
+		From:        []models.Status{models.WeighedStatus},
+		To:          models.ApprovedStatus,
+	},
+	{
+		Name:        "pay",
+		Description: "Marks an approved record as paid.",
+		From:        []models.Status{models.ApprovedStatus},
+		To:          models.PaidStatus,
+	},
+	{
+		Name:           "dispute",
+		Description:    "Disputes a record that is waiting to be weighed, approved or paid.",
+		From:           []models.Status{models.SubmittedStatus, models.WeighedStatus, models.ApprovedStatus},
+		To:             models.DisputedStatus,
+		ReasonRequired: true,
+	},
+	{
+		Name:        "reopen",
+		Description: "Settles a dispute by sending the record back to be weighed again.",
+		From:        []models.Status{models.DisputedStatus},
+		To:          models.SubmittedStatus,
+	},
+	{
+		Name:           "void",
+		Description:    "Voids a record that has not been paid.",
+		From:           []models.Status{models.DraftStatus, models.SubmittedStatus, models.WeighedStatus, models.ApprovedStatus, models.DisputedStatus},
+		To:             models.VoidedStatus,
+		ReasonRequired: true,
+	},
+}
+
+// LockedStatuses are the statuses in which a record and its lines can no
+// longer be edited.
+var LockedStatuses = []models.Status{
+	models.ApprovedStatus,
+	models.PaidStatus,
+	models.VoidedStatus,
+}
+
+type Lifecycle interface {
+	// Transition applies the named transition to the record of entity with
+	// the given id that scope allows, records who took it and why, and books
+	// the change in the inventory ledger. It returns gorm.ErrRecordNotFound
+	// when scope hides the record, and ErrHeldByPayout for a collection that
+	// an open payout run holds, since only the run may then pay it.
+	Transition(entity models.PolicyType, id uuid.UUID, scope clause.Expression, name string, reason *string, actorId uuid.UUID) (*models.StatusChange, error)
+	// TransitionWith applies a transition like Transition, using tx so that
+	// it commits together with the caller's other writes. It doesn't check
+	// payout runs, so that a run can pay the collections it holds.
+	TransitionWith(tx *gorm.DB, entity models.PolicyType, id uuid.UUID, scope clause.Expression, name string, reason *string, actorId uuid.UUID) (*models.StatusChange, error)
+	// History returns the record's status changes, oldest first.
+	History(entity models.PolicyType, id uuid.UUID) ([]models.StatusChange, error)
+	// Locked reports whether the record has a locked status. Records that
+	// don't exist aren't locked.
+	Locked(entity models.PolicyType, id uuid.UUID) (bool, error)
+	// LineLocked reports whether the line belongs to a locked record.
+	LineLocked(entity models.PolicyType, lineId uuid.UUID) (bool, error)
+}
+
+type lifecycle struct {
+	storage   storage.Storage
+	inventory inventory.Inventory
+}
+
+func New(storage storage.Storage, inventory inventory.Inventory) Lifecycle {
+	return &lifecycle{
+		storage:   storage,
+		inventory: inventory,
+	}
+}
+
+// Find returns the named transition.
+func Find(name string) (*Transition, error) {
+	for index := range Transitions {
+		if Transitions[index].Name == name {
+			return &Transitions[index], nil
+		}
+	}
+
+	return nil, ErrUnknownTransition
+}
+
+func (l *lifecycle) Transition(entity models.PolicyType, id uuid.UUID, scope clause.Expression, name string, reason *string, actorId uuid.UUID) (*models.StatusChange, error) {
+	var change *models.StatusChange
+
+	if err := l.storage.Database().Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		change, err = l.transition(tx, entity, id, scope, name, reason, actorId, true)
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return change, nil
+}
+
+func (l *lifecycle) TransitionWith(tx *gorm.DB, entity models.PolicyType, id uuid.UUID, scope clause.Expression, name string, reason *string, actorId uuid.UUID) (*models.StatusChange, error) {
+	return l.transition(tx, entity, id, scope, name, reason, actorId, false)
+}
+
+func (l *lifecycle) transition(tx *gorm.DB, entity models.PolicyType, id uuid.UUID, scope clause.Expression, name string, reason *string, actorId uuid.UUID, checkPayouts bool) (*models.StatusChange, error) {
+	transition, err := Find(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if reason != nil {
+		trimmed := strings.TrimSpace(*reason)
+
+		if trimmed == "" {
+			reason = nil
+		} else {
+			reason = &trimmed
+		}
+	}
+
+	if transition.ReasonRequired && reason == nil {
+		return nil, ErrReasonRequired
+	}
+
+	change := models.StatusChange{
+		OwnerType:  entity,
+		OwnerId:    id,
+		Transition: transition.Name,
+		To:         transition.To,
+		Reason:     reason,
+		ActorId:    actorId,
+	}
+
+	query := tx.Table(string(entity)).Clauses(clause.Locking{Strength: "UPDATE"})
+
+	if scope != nil {
+		query = query.Clauses(scope)
+	}
+
+	var record struct {
+		Status models.Status
+	}
+
+	if err := query.Select("status").Where("id = ?", id).Take(&record).Error; err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(transition.From, record.Status) {
+		return nil, fmt.Errorf("%w: %s can't %s", ErrInvalidTransition, record.Status, transition.Name)
+	}
+
+	// The record is locked by now, and payout runs lock the collections they
+	// take, so no run can take the collection while this runs.
+	if checkPayouts && entity == models.CollectionsPolicy {
+		held, err := heldByPayout(tx, id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if held {
+			return nil, ErrHeldByPayout
+		}
+	}
+
+	change.From = record.Status
+
+	if err := tx.Table(string(entity)).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":     transition.To,
+			"updated_at": gorm.Expr("NOW()"),
+		}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Create(&change).Error; err != nil {
+		return nil, err
+	}
+
+	if err := l.inventory.Book(tx, entity, id, change.From, change.To, reason, actorId); err != nil {
+		return nil, err
+	}
+
+	return &change, nil
+}
+
+// heldByPayout reports whether an unreleased item of a payout run that hasn't
+// been confirmed or cancelled holds the collection.
+func heldByPayout(tx *gorm.DB, collectionId uuid.UUID) (bool, error) {
+	var count int64
+
+	if err := tx.Table("payout_items").
+		Joins("JOIN payout_statements ON payout_statements.id = payout_items.payout_statement_id").
+		Joins("JOIN payout_runs ON payout_runs.id = payout_statements.payout_run_id").
+		Where("payout_items.collection_id = ? AND payout_items.released = false", collectionId).
+		Where("payout_runs.status IN ?", []models.PayoutRunStatus{models.GeneratedPayoutRun, models.ExportedPayoutRun}).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (l *lifecycle) History(entity models.PolicyType, id uuid.UUID) ([]models.StatusChange, error) {
+	changes := []models.StatusChange{}
+
+	if err := l.storage.Database().
+		Where("owner_type = ? AND owner_id = ?", entity, id).
+		Order("created_at ASC").
+		Find(&changes).Error; err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+func (l *lifecycle) Locked(entity models.PolicyType, id uuid.UUID) (bool, error) {
+	var count int64
+
+	if err := l.storage.Database().
+		Table(string(entity)).
+		Where("id = ? AND status IN ?", id, LockedStatuses).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
+
+func (l *lifecycle) LineLocked(entity models.PolicyType, lineId uuid.UUID) (bool, error) {
+	// The lines of <record>s are in <record>_materials, with a <record>_id
+	// column.
+	record := strings.TrimSuffix(string(entity), "s")
+	lines := fmt.Sprintf("%s_materials", record)
+
+	var count int64
+
+	if err := l.storage.Database().
+		Table(string(entity)).
+		Joins(fmt.Sprintf("JOIN %s ON %s.%s_id = %s.id", lines, lines, record, entity)).
+		Where(fmt.Sprintf("%s.id = ? AND %s.status IN ?", lines, entity), lineId, LockedStatuses).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+
+	return count > 0, nil
+}
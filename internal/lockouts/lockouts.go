@@ -0,0 +1,205 @@
+package lockouts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Key struct {
+	Scope models.LockoutScope
+	Value string
+}
+
+type Lockouts interface {
+	Check(keys ...Key) (time.Duration, error)
+	Fail(keys ...Key) (time.Duration, error)
+	Reset(keys ...Key) error
+}
+
+type lockouts struct {
+	storage          storage.Storage
+	accountThreshold int
+	ipThreshold      int
+	baseDuration     time.Duration
+	maxDuration      time.Duration
+	window           time.Duration
+}
+
+func New(storage storage.Storage) Lockouts {
+	return &lockouts{
+		storage:          storage,
+		accountThreshold: common.EnvInt("APP_LOCKOUT_ACCOUNT_THRESHOLD", 5),
+		ipThreshold:      common.EnvInt("APP_LOCKOUT_IP_THRESHOLD", 20),
+		baseDuration:     common.EnvDuration("APP_LOCKOUT_BASE_DURATION", 30*time.Second),
+		maxDuration:      common.EnvDuration("APP_LOCKOUT_MAX_DURATION", 1*time.Hour),
+		window:           common.EnvDuration("APP_LOCKOUT_WINDOW", 1*time.Hour),
+	}
+}
+
+// Account keys are hashed so submitted usernames, including ones that don't
+// exist, never end up in the lockouts table in plain text.
+func Account(scope models.LockoutScope, value string) Key {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(value))))
+
+	return Key{
+		Scope: scope,
+		Value: hex.EncodeToString(sum[:]),
+	}
+}
+
+func Ip(scope models.LockoutScope, value string) Key {
+	return Key{
+		Scope: scope,
+		Value: value,
+	}
+}
+
+func (l *lockouts) threshold(scope models.LockoutScope) int {
+	switch scope {
+	case models.LoginIpLockout, models.MfaIpLockout:
+		return l.ipThreshold
+	default:
+		return l.accountThreshold
+	}
+}
+
+// backoff doubles the lockout duration for every failure past the threshold,
+// capped at the configured maximum.
+func (l *lockouts) backoff(failures int, threshold int) time.Duration {
+	exponent := failures - threshold
+
+	if exponent < 0 {
+		return 0
+	}
+
+	if exponent > 30 {
+		return l.maxDuration
+	}
+
+	duration := l.baseDuration * time.Duration(1<<exponent)
+
+	if duration > l.maxDuration || duration <= 0 {
+		return l.maxDuration
+	}
+
+	return duration
+}
+
+func (l *lockouts) Check(keys ...Key) (time.Duration, error) {
+	now := time.Now()
+	retryAfter := time.Duration(0)
+
+	for _, key := range keys {
+		var lockout models.Lockout
+
+		if err := l.storage.Database().
+			Where("scope = ? AND key = ?", key.Scope, key.Value).
+			First(&lockout).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				continue
+			}
+
+			return 0, err
+		}
+
+		if lockout.LockedUntil != nil && lockout.LockedUntil.After(now) {
+			if remaining := lockout.LockedUntil.Sub(now); remaining > retryAfter {
+				retryAfter = remaining
+			}
+		}
+	}
+
+	return retryAfter, nil
+}
+
+func (l *lockouts) Fail(keys ...Key) (time.Duration, error) {
+	now := time.Now()
+	retryAfter := time.Duration(0)
+
+	for _, key := range keys {
+		if err := l.storage.Database().Transaction(func(tx *gorm.DB) error {
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Lockout{
+				Scope:         key.Scope,
+				Key:           key.Value,
+				LastFailureAt: now,
+			}).Error; err != nil {
+				return err
+			}
+
+			var lockout models.Lockout
+
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("scope = ? AND key = ?", key.Scope, key.Value).
+				First(&lockout).Error; err != nil {
+				return err
+			}
+
+			if now.Sub(lockout.LastFailureAt) > l.window {
+				lockout.Failures = 0
+				lockout.LockedUntil = nil
+			}
+
+			lockout.Failures++
+			lockout.LastFailureAt = now
+
+			if duration := l.backoff(lockout.Failures, l.threshold(key.Scope)); duration > 0 {
+				lockedUntil := now.Add(duration)
+				lockout.LockedUntil = &lockedUntil
+
+				if duration > retryAfter {
+					retryAfter = duration
+				}
+			}
+
+			return tx.Model(&lockout).Select("failures", "last_failure_at", "locked_until").Updates(&lockout).Error
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := l.prune(now); err != nil {
+		return 0, err
+	}
+
+	return retryAfter, nil
+}
+
+// prune deletes lockouts whose failures fell out of the window and which are
+// no longer locked, so keys that stop failing don't stay in the table.
+func (l *lockouts) prune(now time.Time) error {
+	return l.storage.Database().
+		Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", now.Add(-l.window), now).
+		Delete(&models.Lockout{}).Error
+}
+
+func (l *lockouts) Reset(keys ...Key) error {
+	for _, key := range keys {
+		if err := l.storage.Database().
+			Where("scope = ? AND key = ?", key.Scope, key.Value).
+			Delete(&models.Lockout{}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func RetryAfterSeconds(retryAfter time.Duration) string {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return strconv.FormatInt(seconds, 10)
+}
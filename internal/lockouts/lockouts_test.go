@@ -0,0 +1,106 @@
+package lockouts
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/testdb"
+	"github.com/google/uuid"
+)
+
+func TestBackoff(t *testing.T) {
+	l := &lockouts{
+		baseDuration: 30 * time.Second,
+		maxDuration:  time.Hour,
+	}
+
+	tests := []struct {
+		name      string
+		failures  int
+		threshold int
+		want      time.Duration
+	}{
+		{name: "below the threshold", failures: 4, threshold: 5, want: 0},
+		{name: "at the threshold", failures: 5, threshold: 5, want: 30 * time.Second},
+		{name: "doubles past the threshold", failures: 7, threshold: 5, want: 2 * time.Minute},
+		{name: "capped at the maximum", failures: 12, threshold: 5, want: time.Hour},
+		{name: "overflow is capped", failures: 100, threshold: 5, want: time.Hour},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := l.backoff(test.failures, test.threshold); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestAccount(t *testing.T) {
+	key := Account(models.LoginAccountLockout, " Alice ")
+
+	if key != Account(models.LoginAccountLockout, "alice") {
+		t.Error("expected usernames to be normalised before hashing")
+	}
+
+	if strings.Contains(key.Value, "alice") {
+		t.Errorf("expected the username to be hashed, got %q", key.Value)
+	}
+}
+
+func TestFailPrunesExpiredLockouts(t *testing.T) {
+	store := testdb.Open(t)
+
+	l := &lockouts{
+		storage:          store,
+		accountThreshold: 5,
+		ipThreshold:      20,
+		baseDuration:     30 * time.Second,
+		maxDuration:      time.Hour,
+		window:           time.Hour,
+	}
+
+	now := time.Now()
+	lockedUntil := now.Add(time.Hour)
+
+	stale := models.Lockout{Scope: models.LoginIpLockout, Key: uuid.NewString(), Failures: 3, LastFailureAt: now.Add(-2 * time.Hour)}
+	locked := models.Lockout{Scope: models.LoginIpLockout, Key: uuid.NewString(), Failures: 30, LastFailureAt: now.Add(-2 * time.Hour), LockedUntil: &lockedUntil}
+
+	for _, lockout := range []*models.Lockout{&stale, &locked} {
+		if err := store.Database().Create(lockout).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	key := Ip(models.LoginIpLockout, uuid.NewString())
+
+	if _, err := l.Fail(key); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		key  string
+		want int64
+	}{
+		{name: "stale counter is pruned", key: stale.Key, want: 0},
+		{name: "active lockout is kept", key: locked.Key, want: 1},
+		{name: "new failure is recorded", key: key.Value, want: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var count int64
+
+			if err := store.Database().Model(&models.Lockout{}).Where("key = ?", test.key).Count(&count).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			if count != test.want {
+				t.Errorf("got %d lockouts, want %d", count, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	ImpersonationStartedAudit AuditAction = "impersonation.started"
+	ImpersonationStoppedAudit AuditAction = "impersonation.stopped"
+	ImpersonationRequestAudit AuditAction = "impersonation.request"
+	ImpersonationBlockedAudit AuditAction = "impersonation.blocked"
+)
+
+type AuditLog struct {
+	Base
+	Action          AuditAction `json:"action" gorm:"type:text;not null;index"`
+	ActorId         uuid.UUID   `json:"actorId" gorm:"type:uuid;not null;index"`
+	UserId          *uuid.UUID  `json:"userId" gorm:"type:uuid;index"`
+	ImpersonationId *uuid.UUID  `json:"impersonationId" gorm:"type:uuid;index"`
+	Method          string      `json:"method" gorm:"type:text"`
+	Path            string      `json:"path" gorm:"type:text"`
+	Status          int         `json:"status"`
+	Ip              string      `json:"ip" gorm:"type:text"`
+	Details         *string     `json:"details" gorm:"type:text"`
+}
@@ -17,7 +17,6 @@ type PolicyType string
 const (
 	CollectionsPolicy  PolicyType = "collections"
 	TransactionsPolicy PolicyType = "transactions"
-	SystemPolicy       PolicyType = "system"
 )
 
 type VerifyMfaPayload struct {
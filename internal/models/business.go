@@ -1,6 +1,9 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
 
 type Business struct {
 	Base
@@ -9,4 +12,19 @@ type Business struct {
 	BankDetails *BankDetails `json:"bankDetails" gorm:"type:jsonb;"`
 	Users       []User       `json:"users" gorm:"many2many:businesses_users;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	OwnerId     uuid.UUID    `json:"ownerId" gorm:"type:uuid;not null"`
+	// The currency and VAT settings are only written by the business's VAT
+	// settings route, which validates them.
+	Currency      string          `json:"currency" gorm:"<-:false;type:char(3);not null;default:'ZAR'"`
+	VatRegistered bool            `json:"vatRegistered" gorm:"<-:false;not null;default:false"`
+	VatNumber     *string         `json:"vatNumber" gorm:"<-:false;type:text;"`
+	VatRate       decimal.Decimal `json:"vatRate" gorm:"<-:false;type:decimal(5,2);not null;default:15"`
+}
+
+// VatSettingsPayload sets the currency a business trades in and whether, and
+// at what rate in percent, it charges VAT.
+type VatSettingsPayload struct {
+	Currency      string          `json:"currency"`
+	VatRegistered bool            `json:"vatRegistered"`
+	VatNumber     *string         `json:"vatNumber"`
+	VatRate       decimal.Decimal `json:"vatRate"`
 }
@@ -1,21 +1,100 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
 
+// Collection starts as a draft and only changes status through its lifecycle
+// transitions, which is why Status is never written by updates. Its values
+// are in the buyer's currency as it was when the collection was created.
 type Collection struct {
 	Base
 	SellerId  uuid.UUID            `json:"sellerId" gorm:"type:uuid;not null"`
 	Seller    User                 `json:"seller" gorm:"foreignKey:SellerId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	BuyerId   uuid.UUID            `json:"buyerId" gorm:"type:uuid;not null"`
 	Buyer     Business             `json:"buyer" gorm:"foreignKey:BuyerId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Materials []CollectionMaterial `json:"materials" gorm:"many2many:collections_materials;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Materials []CollectionMaterial `json:"materials" gorm:"foreignKey:CollectionId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Status    Status               `json:"status" gorm:"<-:create;type:text;not null;default:draft;index"`
+	Currency  string               `json:"currency" gorm:"<-:create;type:char(3);not null;default:'ZAR'"`
 }
 
+func (c *Collection) BeforeCreate(tx *gorm.DB) error {
+	c.Status = DraftStatus
+
+	var buyer Business
+
+	if err := tx.Session(&gorm.Session{NewDB: true}).
+		Select("currency").
+		Where("id = ?", c.BuyerId).
+		Take(&buyer).Error; err != nil {
+		return err
+	}
+
+	c.Currency = buyer.Currency
+
+	return nil
+}
+
+// CollectionMaterial is a line of a collection, which owns it. Lines valued from the buyer's
+// price list keep the price they used; when the value was entered by hand
+// instead, ComputedValue holds what the price list would have given.
 type CollectionMaterial struct {
 	Base
-	Name         string  `json:"name" gorm:"type:text;not null"`
-	GWCode       string  `json:"gwCode" gorm:"type:text;not null"`
-	CarbonFactor string  `json:"carbonFactor" gorm:"type:text;not null"`
-	Weight       float64 `json:"weight" gorm:"type:decimal(10,2);not null"`
-	Value        float64 `json:"value" gorm:"type:decimal(10,2);not null"`
+	CollectionId    uuid.UUID        `json:"collectionId" gorm:"<-:create;type:uuid;not null;index"`
+	MaterialId      *uuid.UUID       `json:"materialId" gorm:"type:uuid;index"`
+	Material        *Material        `json:"material,omitempty" gorm:"foreignKey:MaterialId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Name            string           `json:"name" gorm:"type:text;not null"`
+	GWCode          string           `json:"gwCode" gorm:"type:text;not null"`
+	CarbonFactor    decimal.Decimal  `json:"carbonFactor" gorm:"type:decimal(12,4);not null;default:0"`
+	Weight          float64          `json:"weight" gorm:"type:decimal(10,2);not null"`
+	Value           decimal.Decimal  `json:"value" gorm:"type:decimal(10,2);not null"`
+	PriceId         *uuid.UUID       `json:"priceId" gorm:"type:uuid"`
+	PricePerKg      *decimal.Decimal `json:"pricePerKg" gorm:"type:decimal(12,4)"`
+	ComputedValue   *decimal.Decimal `json:"computedValue" gorm:"type:decimal(10,2)"`
+	ValueOverridden bool             `json:"valueOverridden" gorm:"not null;default:false"`
+	OverriddenById  *uuid.UUID       `json:"overriddenById" gorm:"type:uuid"`
+}
+
+// BeforeCreate drops any material sent with the line, so creating a line can
+// never write to the catalogue, and snapshots the catalogue material instead.
+func (l *CollectionMaterial) BeforeCreate(tx *gorm.DB) error {
+	l.Material = nil
+
+	return l.SnapshotMaterial(tx)
+}
+
+// SnapshotMaterial copies the line's catalogue material onto the line. Call
+// it when the material of an existing line changes.
+func (l *CollectionMaterial) SnapshotMaterial(tx *gorm.DB) error {
+	return snapshotMaterial(tx, &l.MaterialId, &l.Name, &l.GWCode, &l.CarbonFactor)
+}
+
+// CreateCollectionLinePayload adds a line for a catalogue material to a
+// collection. Value is optional: without it the line is valued from the
+// buyer's price list, and with it the value is recorded as a manual override.
+type CreateCollectionLinePayload struct {
+	MaterialId uuid.UUID        `json:"materialId"`
+	Weight     float64          `json:"weight"`
+	Value      *decimal.Decimal `json:"value"`
+}
+
+// UpdateCollectionLinePayload replaces the material, weight and value of a
+// line. As when adding a line, the value is optional: without it the line is
+// revalued from the buyer's price list.
+type UpdateCollectionLinePayload struct {
+	MaterialId uuid.UUID        `json:"materialId"`
+	Weight     float64          `json:"weight"`
+	Value      *decimal.Decimal `json:"value"`
+}
+
+// CreateCollectionPayload creates a collection together with its lines. The
+// totals are optional and, when given, must match the lines.
+type CreateCollectionPayload struct {
+	SellerId    uuid.UUID                     `json:"sellerId"`
+	BuyerId     uuid.UUID                     `json:"buyerId"`
+	Materials   []CreateCollectionLinePayload `json:"materials"`
+	TotalWeight *float64                      `json:"totalWeight"`
+	TotalValue  *decimal.Decimal              `json:"totalValue"`
 }
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Impersonation struct {
+	Base
+	ActorId   uuid.UUID  `json:"actorId" gorm:"type:uuid;not null;index"`
+	Actor     User       `json:"-" gorm:"foreignKey:ActorId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserId    uuid.UUID  `json:"userId" gorm:"type:uuid;not null;index"`
+	User      User       `json:"-" gorm:"foreignKey:UserId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Reason    *string    `json:"reason" gorm:"type:text"`
+	ExpiresAt time.Time  `json:"expiresAt" gorm:"not null"`
+	EndedAt   *time.Time `json:"endedAt"`
+}
+
+type StartImpersonationPayload struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,37 @@
+package models
+
+import "github.com/google/uuid"
+
+// StockMovementType is what moved stock in or out of a business.
+type StockMovementType string
+
+const (
+	CollectionStockMovement  StockMovementType = "collection"
+	TransactionStockMovement StockMovementType = "transaction"
+	AdjustmentStockMovement  StockMovementType = "adjustment"
+)
+
+// StockMovement is one entry of a business's inventory ledger: Quantity kg of
+// a material came in when positive, or went out when negative. The stock a
+// business holds is the sum of its movements. Collection and transaction
+// movements reference the record and line they came from, and adjustments
+// carry the reason they were made.
+type StockMovement struct {
+	Base
+	BusinessId uuid.UUID         `json:"businessId" gorm:"type:uuid;not null;index:idx_stock_movements_business_material"`
+	Business   Business          `json:"-" gorm:"foreignKey:BusinessId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	MaterialId uuid.UUID         `json:"materialId" gorm:"type:uuid;not null;index:idx_stock_movements_business_material"`
+	Material   *Material         `json:"material,omitempty" gorm:"foreignKey:MaterialId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Quantity   float64           `json:"quantity" gorm:"type:decimal(12,2);not null"`
+	Type       StockMovementType `json:"type" gorm:"type:text;not null"`
+	SourceId   *uuid.UUID        `json:"sourceId" gorm:"type:uuid;index"`
+	LineId     *uuid.UUID        `json:"lineId" gorm:"type:uuid;index"`
+	Reason     *string           `json:"reason" gorm:"type:text"`
+	ActorId    *uuid.UUID        `json:"actorId" gorm:"type:uuid"`
+}
+
+type StockAdjustmentPayload struct {
+	MaterialId uuid.UUID `json:"materialId"`
+	Quantity   float64   `json:"quantity"`
+	Reason     string    `json:"reason"`
+}
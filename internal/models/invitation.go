@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type InvitationStatus string
+
+const (
+	PendingInvitation  InvitationStatus = "pending"
+	AcceptedInvitation InvitationStatus = "accepted"
+	RevokedInvitation  InvitationStatus = "revoked"
+)
+
+type Invitation struct {
+	Base
+	BusinessId   uuid.UUID        `json:"businessId" gorm:"type:uuid;not null;index"`
+	Business     Business         `json:"business" gorm:"foreignKey:BusinessId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Recipient    string           `json:"recipient" gorm:"type:text;not null"`
+	UserType     UserType         `json:"userType" gorm:"type:text;not null;default:'business'"`
+	RoleId       uuid.UUID        `json:"roleId" gorm:"type:uuid;not null"`
+	Role         Role             `json:"role" gorm:"foreignKey:RoleId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Status       InvitationStatus `json:"status" gorm:"type:text;not null;default:'pending'"`
+	Nonce        string           `json:"-" gorm:"type:text;not null"`
+	ExpiresAt    time.Time        `json:"expiresAt" gorm:"not null"`
+	SentAt       time.Time        `json:"sentAt" gorm:"not null"`
+	SendCount    int              `json:"sendCount" gorm:"not null;default:0"`
+	InvitedById  uuid.UUID        `json:"invitedById" gorm:"type:uuid;not null"`
+	AcceptedById *uuid.UUID       `json:"acceptedById" gorm:"type:uuid"`
+	AcceptedAt   *time.Time       `json:"acceptedAt"`
+	RevokedAt    *time.Time       `json:"revokedAt"`
+}
+
+type CreateInvitationPayload struct {
+	Recipient string    `json:"recipient"`
+	UserType  UserType  `json:"userType"`
+	RoleId    uuid.UUID `json:"roleId"`
+}
+
+type AcceptInvitationPayload struct {
+	Token    string `json:"token"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Invoice is the tax invoice a seller issued for a transaction. Numbers run
+// from 1 per seller without gaps, and the amounts are fixed when the invoice
+// is issued so that a reprint always shows what was first printed.
+type Invoice struct {
+	Base
+	BusinessId    uuid.UUID       `json:"businessId" gorm:"type:uuid;not null;uniqueIndex:idx_invoices_business_number"`
+	Business      Business        `json:"-" gorm:"foreignKey:BusinessId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	TransactionId uuid.UUID       `json:"transactionId" gorm:"type:uuid;not null;uniqueIndex"`
+	Transaction   Transaction     `json:"-" gorm:"foreignKey:TransactionId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Number        int             `json:"number" gorm:"not null;uniqueIndex:idx_invoices_business_number"`
+	Reference     string          `json:"reference" gorm:"type:text;not null"`
+	VatNumber     *string         `json:"vatNumber" gorm:"type:text"`
+	Currency      string          `json:"currency" gorm:"type:char(3);not null;default:'ZAR'"`
+	VatRate       decimal.Decimal `json:"vatRate" gorm:"type:decimal(5,2);not null"`
+	Net           decimal.Decimal `json:"net" gorm:"type:decimal(12,2);not null"`
+	Vat           decimal.Decimal `json:"vat" gorm:"type:decimal(12,2);not null"`
+	Gross         decimal.Decimal `json:"gross" gorm:"type:decimal(12,2);not null"`
+	IssuedAt      time.Time       `json:"issuedAt" gorm:"not null"`
+	IssuedById    uuid.UUID       `json:"issuedById" gorm:"type:uuid;not null"`
+}
+
+// InvoiceSequence holds the last invoice number a business issued.
+type InvoiceSequence struct {
+	BusinessId uuid.UUID `json:"businessId" gorm:"type:uuid;primaryKey"`
+	Last       int       `json:"last" gorm:"not null;default:0"`
+}
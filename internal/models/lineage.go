@@ -0,0 +1,46 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LineageNodeType is what a node of a lineage graph stands for.
+type LineageNodeType string
+
+const (
+	TransactionLineageNode  LineageNodeType = "transaction"
+	CollectionLineageNode   LineageNodeType = "collection"
+	CollectorLineageNode    LineageNodeType = "collector"
+	AdjustmentLineageNode   LineageNodeType = "adjustment"
+	UnattributedLineageNode LineageNodeType = "unattributed"
+)
+
+// LineageNode is a transaction, collection or collector that material passed
+// through, a manual stock adjustment it came from, or the stock of a business
+// that can't be traced further, whose Id is the business's id.
+type LineageNode struct {
+	Id         uuid.UUID       `json:"id"`
+	Type       LineageNodeType `json:"type"`
+	BusinessId *uuid.UUID      `json:"businessId"`
+	ApprovedAt *time.Time      `json:"approvedAt"`
+}
+
+// LineageEdge is Quantity kg of a material that moved from one node to
+// another.
+type LineageEdge struct {
+	From       uuid.UUID `json:"from"`
+	To         uuid.UUID `json:"to"`
+	MaterialId uuid.UUID `json:"materialId"`
+	Quantity   float64   `json:"quantity"`
+}
+
+// Lineage traces the material of a transaction back to where it was
+// collected. Edges point downstream, towards the transaction in RootId.
+type Lineage struct {
+	RootId uuid.UUID     `json:"rootId"`
+	Method string        `json:"method"`
+	Nodes  []LineageNode `json:"nodes"`
+	Edges  []LineageEdge `json:"edges"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type LockoutScope string
+
+const (
+	LoginAccountLockout LockoutScope = "login.account"
+	LoginIpLockout      LockoutScope = "login.ip"
+	MfaAccountLockout   LockoutScope = "mfa.account"
+	MfaIpLockout        LockoutScope = "mfa.ip"
+)
+
+type Lockout struct {
+	Base
+	Scope         LockoutScope `json:"scope" gorm:"type:text;not null;uniqueIndex:idx_lockouts_scope_key"`
+	Key           string       `json:"key" gorm:"type:text;not null;uniqueIndex:idx_lockouts_scope_key"`
+	Failures      int          `json:"failures" gorm:"not null;default:0"`
+	LastFailureAt time.Time    `json:"lastFailureAt" gorm:"not null;index"`
+	LockedUntil   *time.Time   `json:"lockedUntil"`
+}
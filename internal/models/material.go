@@ -1,8 +1,128 @@
 package models
 
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+var ErrUnknownMaterial = errors.New("the material is not in the catalogue")
+
+// KgCO2ePerKg is the unit carbon factors are expressed in: kilograms of CO2
+// equivalent avoided per kilogram of material recycled.
+const KgCO2ePerKg = "kgCO2e/kg"
+
 type Material struct {
 	Base
-	Name         string `json:"name" gorm:"type:text;not null;uniqueIndex"`
-	GWCode       string `json:"gwCode" gorm:"type:text;not null;uniqueIndex"`
-	CarbonFactor string `json:"carbonFactor" gorm:"type:text;not null"`
+	Name             string          `json:"name" gorm:"type:text;not null;uniqueIndex"`
+	GWCode           string          `json:"gwCode" gorm:"type:text;not null;uniqueIndex"`
+	CarbonFactor     decimal.Decimal `json:"carbonFactor" gorm:"type:decimal(12,4);not null;default:0"`
+	CarbonFactorUnit string          `json:"carbonFactorUnit" gorm:"type:text;not null;default:'kgCO2e/kg'"`
+}
+
+// snapshotMaterial links a new collection or transaction line to its catalogue
+// material, given by id or else found by GW code, and copies the material's
+// name, GW code and carbon factor onto the line as they are at creation, so
+// later catalogue edits don't rewrite the line.
+func snapshotMaterial(tx *gorm.DB, materialId **uuid.UUID, name *string, gwCode *string, carbonFactor *decimal.Decimal) error {
+	query := tx.Session(&gorm.Session{NewDB: true})
+
+	if *materialId != nil {
+		query = query.Where("id = ?", **materialId)
+	} else {
+		query = query.Where("gw_code = ?", strings.TrimSpace(*gwCode))
+	}
+
+	var material Material
+
+	if err := query.Take(&material).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrUnknownMaterial
+		}
+
+		return err
+	}
+
+	*materialId = &material.Id
+	*name = material.Name
+	*gwCode = material.GWCode
+	*carbonFactor = material.CarbonFactor
+
+	return nil
+}
+
+// MaterialCarbonFactor is one version of a material's carbon factor. A version
+// applies from EffectiveFrom until the next version of the same material.
+type MaterialCarbonFactor struct {
+	Base
+	MaterialId    uuid.UUID       `json:"materialId" gorm:"type:uuid;not null;index"`
+	Material      Material        `json:"-" gorm:"foreignKey:MaterialId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Factor        decimal.Decimal `json:"factor" gorm:"type:decimal(12,4);not null"`
+	Unit          string          `json:"unit" gorm:"type:text;not null"`
+	EffectiveFrom time.Time       `json:"effectiveFrom" gorm:"not null;index"`
+	CreatedById   *uuid.UUID      `json:"createdById" gorm:"type:uuid"`
+}
+
+type CreateMaterialPayload struct {
+	Name             string          `json:"name"`
+	GWCode           string          `json:"gwCode"`
+	CarbonFactor     decimal.Decimal `json:"carbonFactor"`
+	CarbonFactorUnit string          `json:"carbonFactorUnit"`
+}
+
+type UpdateMaterialPayload struct {
+	Name             *string          `json:"name"`
+	GWCode           *string          `json:"gwCode"`
+	CarbonFactor     *decimal.Decimal `json:"carbonFactor"`
+	CarbonFactorUnit *string          `json:"carbonFactorUnit"`
+}
+
+type CreateCarbonFactorPayload struct {
+	Factor        decimal.Decimal `json:"factor"`
+	Unit          string          `json:"unit"`
+	EffectiveFrom *time.Time      `json:"effectiveFrom"`
+}
+
+// CarbonSavings is the CO2e avoided by the recycled weight in a set of
+// collections or transactions.
+type CarbonSavings struct {
+	Weight    float64                 `json:"weight"`
+	CO2e      float64                 `json:"co2e"`
+	Unit      string                  `json:"unit"`
+	Count     int                     `json:"count"`
+	Materials []MaterialCarbonSavings `json:"materials"`
+	Periods   []PeriodCarbonSavings   `json:"periods,omitempty"`
+}
+
+// MaterialCarbonSavings is the part of the savings from one catalogue
+// material. Unlinked lines are grouped by GW code and have no MaterialId.
+type MaterialCarbonSavings struct {
+	MaterialId *uuid.UUID `json:"materialId"`
+	Name       string     `json:"name"`
+	GWCode     string     `json:"gwCode"`
+	Weight     float64    `json:"weight"`
+	CO2e       float64    `json:"co2e"`
+}
+
+type PeriodCarbonSavings struct {
+	Start  time.Time `json:"start"`
+	Weight float64   `json:"weight"`
+	CO2e   float64   `json:"co2e"`
+	Count  int       `json:"count"`
+}
+
+// UnmatchedLine is a collection or transaction line that isn't linked to a
+// catalogue material. OwnerId is the collection or transaction it belongs to.
+type UnmatchedLine struct {
+	Id        uuid.UUID       `json:"id"`
+	OwnerId   uuid.UUID       `json:"ownerId"`
+	Name      string          `json:"name"`
+	GWCode    string          `json:"gwCode"`
+	Weight    float64         `json:"weight"`
+	Value     decimal.Decimal `json:"value"`
+	CreatedAt time.Time       `json:"createdAt"`
 }
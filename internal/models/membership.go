@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Membership is a user's membership of a business. It lives on the
+// businesses_users join table so every existing business assignment is a
+// membership.
+type Membership struct {
+	BusinessId uuid.UUID `json:"businessId" gorm:"type:uuid;primaryKey"`
+	UserId     uuid.UUID `json:"userId" gorm:"type:uuid;primaryKey"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"autoCreateTime"`
+	User       *User     `json:"user,omitempty" gorm:"-"`
+	Roles      []Role    `json:"roles" gorm:"-"`
+}
+
+func (Membership) TableName() string {
+	return "businesses_users"
+}
+
+// MembershipRole grants a role to a user inside a single business only.
+type MembershipRole struct {
+	BusinessId uuid.UUID `json:"businessId" gorm:"type:uuid;primaryKey"`
+	Business   Business  `json:"-" gorm:"foreignKey:BusinessId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	UserId     uuid.UUID `json:"userId" gorm:"type:uuid;primaryKey"`
+	User       User      `json:"-" gorm:"foreignKey:UserId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	RoleId     uuid.UUID `json:"roleId" gorm:"type:uuid;primaryKey"`
+	Role       Role      `json:"-" gorm:"foreignKey:RoleId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedAt  time.Time `json:"createdAt" gorm:"autoCreateTime"`
+}
+
+func (MembershipRole) TableName() string {
+	return "businesses_users_roles"
+}
+
+type BusinessRolePayload struct {
+	Name        string   `json:"name"`
+	Description *string  `json:"description"`
+	Permissions []string `json:"permissions"`
+}
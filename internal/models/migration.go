@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// SchemaMigration records a one-off data migration that has run, for
+// migrations whose effect can't be told from the schema.
+type SchemaMigration struct {
+	Name      string    `json:"name" gorm:"type:text;primaryKey"`
+	AppliedAt time.Time `json:"appliedAt" gorm:"not null"`
+}
@@ -0,0 +1,101 @@
+package models
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+// DefaultCurrency is the currency of businesses that haven't chosen one, and
+// of the records they made before currencies were recorded.
+const DefaultCurrency = "ZAR"
+
+// Currencies are the ISO 4217 codes amounts can be recorded in. Amounts are
+// stored to the cent, so only currencies with two minor units are listed.
+var Currencies = []string{
+	"AUD", "BWP", "CAD", "CHF", "CNY", "EUR", "GBP", "GHS", "INR", "KES",
+	"LSL", "MWK", "MZN", "NAD", "NGN", "SZL", "USD", "ZAR", "ZMW",
+}
+
+var (
+	ErrUnknownCurrency  = errors.New("the currency must be a supported ISO 4217 code")
+	ErrInvalidVatRate   = errors.New("the VAT rate must be between 0 and 100 with at most two decimals")
+	ErrVatNumberMissing = errors.New("a VAT-registered business needs a VAT number")
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// Cents rounds an amount to the cent, half away from zero.
+func Cents(amount decimal.Decimal) decimal.Decimal {
+	return amount.Round(2)
+}
+
+// VatOn is the VAT on a net amount at rate percent, rounded to the cent.
+func VatOn(net decimal.Decimal, rate decimal.Decimal) decimal.Decimal {
+	return Cents(net.Mul(rate).Div(hundred))
+}
+
+// ValidateVat checks a business's currency and VAT settings before they are
+// stored.
+func ValidateVat(currency string, registered bool, vatNumber *string, rate decimal.Decimal) error {
+	if !slices.Contains(Currencies, currency) {
+		return ErrUnknownCurrency
+	}
+
+	if rate.IsNegative() || rate.GreaterThan(hundred) || !rate.Equal(Cents(rate)) {
+		return ErrInvalidVatRate
+	}
+
+	if registered && (vatNumber == nil || *vatNumber == "") {
+		return ErrVatNumberMissing
+	}
+
+	return nil
+}
+
+// RefreshTransactionAmounts recomputes the VAT of every line of a transaction
+// at the transaction's VAT rate, and the transaction's net, VAT and gross
+// totals. VAT is rounded per line, so the lines add up to the totals.
+func RefreshTransactionAmounts(tx *gorm.DB, transactionId uuid.UUID) error {
+	db := tx.Session(&gorm.Session{NewDB: true})
+
+	var transaction Transaction
+
+	if err := db.Select("id", "vat_rate").Where("id = ?", transactionId).Take(&transaction).Error; err != nil {
+		return err
+	}
+
+	lines := []TransactionMaterial{}
+
+	if err := db.Select("id", "value", "vat").Where("transaction_id = ?", transactionId).Find(&lines).Error; err != nil {
+		return err
+	}
+
+	net, vat := decimal.Zero, decimal.Zero
+
+	for _, line := range lines {
+		lineVat := VatOn(line.Value, transaction.VatRate)
+
+		if !lineVat.Equal(line.Vat) {
+			if err := db.Table("transaction_materials").
+				Where("id = ?", line.Id).
+				Update("vat", lineVat).Error; err != nil {
+				return err
+			}
+		}
+
+		net = net.Add(line.Value)
+		vat = vat.Add(lineVat)
+	}
+
+	return db.Table("transactions").
+		Where("id = ?", transactionId).
+		Updates(map[string]any{
+			"net":   net,
+			"vat":   vat,
+			"gross": net.Add(vat),
+		}).Error
+}
@@ -0,0 +1,24 @@
+package models
+
+import "github.com/google/uuid"
+
+type PasswordHistory struct {
+	Base
+	UserId   uuid.UUID `json:"userId" gorm:"type:uuid;not null;index"`
+	User     User      `json:"-" gorm:"foreignKey:UserId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Password []byte    `json:"-" gorm:"type:bytea;not null"`
+}
+
+type PasswordViolation struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ChangePasswordPayload struct {
+	CurrentPassword string `json:"currentPassword"`
+	NewPassword     string `json:"newPassword"`
+}
+
+type SetPasswordPayload struct {
+	Password string `json:"password"`
+}
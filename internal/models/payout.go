@@ -0,0 +1,92 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PayoutRunStatus is where a payout run is: generated runs can be exported,
+// and generated or exported runs can be confirmed once the bank has paid them,
+// or cancelled.
+type PayoutRunStatus string
+
+const (
+	GeneratedPayoutRun PayoutRunStatus = "generated"
+	ExportedPayoutRun  PayoutRunStatus = "exported"
+	ConfirmedPayoutRun PayoutRunStatus = "confirmed"
+	CancelledPayoutRun PayoutRunStatus = "cancelled"
+)
+
+type PayoutStatementStatus string
+
+const (
+	PendingPayoutStatement   PayoutStatementStatus = "pending"
+	PaidPayoutStatement      PayoutStatementStatus = "paid"
+	FailedPayoutStatement    PayoutStatementStatus = "failed"
+	CancelledPayoutStatement PayoutStatementStatus = "cancelled"
+)
+
+// PayoutRun pays a business's collectors for the approved collections the
+// business recorded in a period, with one statement per collector.
+type PayoutRun struct {
+	Base
+	BusinessId    uuid.UUID         `json:"businessId" gorm:"type:uuid;not null;index"`
+	Business      Business          `json:"-" gorm:"foreignKey:BusinessId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Reference     string            `json:"reference" gorm:"type:text;not null;uniqueIndex"`
+	From          time.Time         `json:"from" gorm:"not null"`
+	To            time.Time         `json:"to" gorm:"not null"`
+	Status        PayoutRunStatus   `json:"status" gorm:"type:text;not null;default:generated;index"`
+	Currency      string            `json:"currency" gorm:"type:char(3);not null;default:'ZAR'"`
+	TotalAmount   decimal.Decimal   `json:"totalAmount" gorm:"type:decimal(12,2);not null"`
+	Statements    []PayoutStatement `json:"statements,omitempty" gorm:"foreignKey:PayoutRunId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedById   uuid.UUID         `json:"createdById" gorm:"type:uuid;not null"`
+	ExportedAt    *time.Time        `json:"exportedAt"`
+	ConfirmedAt   *time.Time        `json:"confirmedAt"`
+	ConfirmedById *uuid.UUID        `json:"confirmedById" gorm:"type:uuid"`
+	CancelledAt   *time.Time        `json:"cancelledAt"`
+}
+
+// PayoutStatement is what a payout run pays one collector, into the bank
+// account the collector had when the run was generated. Reference is the
+// payment reference the collector sees on their bank statement.
+type PayoutStatement struct {
+	Base
+	PayoutRunId uuid.UUID             `json:"payoutRunId" gorm:"type:uuid;not null;index"`
+	CollectorId uuid.UUID             `json:"collectorId" gorm:"type:uuid;not null;index"`
+	Collector   User                  `json:"-" gorm:"foreignKey:CollectorId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Reference   string                `json:"reference" gorm:"type:text;not null"`
+	BankDetails *BankDetails          `json:"bankDetails" gorm:"type:jsonb;not null"`
+	Weight      float64               `json:"weight" gorm:"type:decimal(12,2);not null"`
+	Amount      decimal.Decimal       `json:"amount" gorm:"type:decimal(12,2);not null"`
+	Status      PayoutStatementStatus `json:"status" gorm:"type:text;not null;default:pending"`
+	PaidAt      *time.Time            `json:"paidAt"`
+	Items       []PayoutItem          `json:"items,omitempty" gorm:"foreignKey:PayoutStatementId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+// PayoutItem is a collection paid by a statement. A collection can only be
+// held by one item that isn't released, which is what keeps it from being
+// paid twice; items are released when their run is cancelled or their
+// payment failed, so that a later run can pay the collection.
+type PayoutItem struct {
+	Base
+	PayoutStatementId uuid.UUID       `json:"payoutStatementId" gorm:"type:uuid;not null;index"`
+	CollectionId      uuid.UUID       `json:"collectionId" gorm:"type:uuid;not null;index:idx_payout_items_collection,unique,where:released = false"`
+	Collection        Collection      `json:"-" gorm:"foreignKey:CollectionId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CollectedAt       time.Time       `json:"collectedAt" gorm:"not null"`
+	Weight            float64         `json:"weight" gorm:"type:decimal(12,2);not null"`
+	Amount            decimal.Decimal `json:"amount" gorm:"type:decimal(12,2);not null"`
+	Released          bool            `json:"released" gorm:"not null;default:false"`
+}
+
+type CreatePayoutRunPayload struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// ConfirmPayoutRunPayload confirms that the bank paid a run. Statements the
+// bank couldn't pay are listed in FailedStatementIds.
+type ConfirmPayoutRunPayload struct {
+	FailedStatementIds []uuid.UUID `json:"failedStatementIds"`
+}
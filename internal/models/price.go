@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// MaterialPrice is one version of what a business pays per kg of a material.
+// A price list can have several tiers per material: a tier applies to lines of
+// at least MinWeight kg and, when Grade is set, only to collectors the
+// business has given that grade. A tier's versions apply from EffectiveFrom
+// until the next version of the same tier, or until EffectiveTo when the tier
+// was retired.
+type MaterialPrice struct {
+	Base
+	BusinessId    uuid.UUID       `json:"businessId" gorm:"type:uuid;not null;index"`
+	Business      Business        `json:"-" gorm:"foreignKey:BusinessId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	MaterialId    uuid.UUID       `json:"materialId" gorm:"type:uuid;not null;index"`
+	Material      Material        `json:"material" gorm:"foreignKey:MaterialId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	PricePerKg    decimal.Decimal `json:"pricePerKg" gorm:"type:decimal(12,4);not null"`
+	MinWeight     float64         `json:"minWeight" gorm:"type:decimal(10,2);not null;default:0"`
+	Grade         *string         `json:"grade" gorm:"type:text"`
+	EffectiveFrom time.Time       `json:"effectiveFrom" gorm:"not null;index"`
+	EffectiveTo   *time.Time      `json:"effectiveTo"`
+	CreatedById   *uuid.UUID      `json:"createdById" gorm:"type:uuid"`
+}
+
+// CollectorGrade is the grade a business gives a collector it buys from,
+// which selects the graded tiers of the business's price list.
+type CollectorGrade struct {
+	Base
+	BusinessId  uuid.UUID `json:"businessId" gorm:"type:uuid;not null;uniqueIndex:idx_collector_grades_business_collector"`
+	Business    Business  `json:"-" gorm:"foreignKey:BusinessId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CollectorId uuid.UUID `json:"collectorId" gorm:"type:uuid;not null;uniqueIndex:idx_collector_grades_business_collector"`
+	Collector   User      `json:"-" gorm:"foreignKey:CollectorId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Grade       string    `json:"grade" gorm:"type:text;not null"`
+}
+
+type CreateMaterialPricePayload struct {
+	MaterialId    uuid.UUID       `json:"materialId"`
+	PricePerKg    decimal.Decimal `json:"pricePerKg"`
+	MinWeight     float64         `json:"minWeight"`
+	Grade         *string         `json:"grade"`
+	EffectiveFrom *time.Time      `json:"effectiveFrom"`
+}
+
+type CollectorGradePayload struct {
+	Grade string `json:"grade"`
+}
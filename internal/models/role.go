@@ -1,11 +1,16 @@
 package models
 
-import "github.com/lib/pq"
+import (
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
 
 type Role struct {
 	Base
-	Name        string         `json:"name" gorm:"type:text;not null;uniqueIndex"`
+	Name        string         `json:"name" gorm:"type:text;not null;uniqueIndex:idx_roles_business_name"`
 	Description *string        `json:"description" gorm:"type:text"`
 	Permissions pq.StringArray `json:"permissions" gorm:"type:text[];default:'{}'"`
 	Default     bool           `json:"default" gorm:"type:boolean;default:false"`
+	BusinessId  *uuid.UUID     `json:"businessId" gorm:"type:uuid;uniqueIndex:idx_roles_business_name"`
+	Business    *Business      `json:"-" gorm:"foreignKey:BusinessId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 }
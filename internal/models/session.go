@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type UserSession struct {
+	Base
+	UserId     uuid.UUID `json:"userId" gorm:"type:uuid;not null;index"`
+	User       User      `json:"-" gorm:"foreignKey:UserId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	SessionId  string    `json:"-" gorm:"type:text;not null;uniqueIndex"`
+	UserAgent  string    `json:"userAgent" gorm:"type:text"`
+	Ip         string    `json:"ip" gorm:"type:text"`
+	LastSeenAt time.Time `json:"lastSeenAt" gorm:"not null"`
+	Current    bool      `json:"current" gorm:"-"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "github.com/google/uuid"
+
+// Status is where a collection or transaction is in its lifecycle.
+type Status string
+
+const (
+	DraftStatus     Status = "draft"
+	SubmittedStatus Status = "submitted"
+	WeighedStatus   Status = "weighed"
+	ApprovedStatus  Status = "approved"
+	PaidStatus      Status = "paid"
+	DisputedStatus  Status = "disputed"
+	VoidedStatus    Status = "voided"
+)
+
+var Statuses = []Status{
+	DraftStatus,
+	SubmittedStatus,
+	WeighedStatus,
+	ApprovedStatus,
+	PaidStatus,
+	DisputedStatus,
+	VoidedStatus,
+}
+
+// StatusChange records one transition of a collection or transaction: the
+// owner is identified by its table name and id, and the change's CreatedAt is
+// when it happened.
+type StatusChange struct {
+	Base
+	OwnerType  PolicyType `json:"ownerType" gorm:"type:text;not null;index:idx_status_changes_owner"`
+	OwnerId    uuid.UUID  `json:"ownerId" gorm:"type:uuid;not null;index:idx_status_changes_owner"`
+	Transition string     `json:"transition" gorm:"type:text;not null"`
+	From       Status     `json:"from" gorm:"type:text;not null"`
+	To         Status     `json:"to" gorm:"type:text;not null"`
+	Reason     *string    `json:"reason" gorm:"type:text"`
+	ActorId    uuid.UUID  `json:"actorId" gorm:"type:uuid;not null"`
+	Actor      User       `json:"-" gorm:"foreignKey:ActorId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+}
+
+type StatusTransitionPayload struct {
+	Reason *string `json:"reason"`
+}
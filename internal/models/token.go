@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type ApiToken struct {
+	Base
+	Name        string         `json:"name" gorm:"type:text;not null"`
+	Prefix      string         `json:"prefix" gorm:"type:text;not null;uniqueIndex"`
+	Hash        string         `json:"-" gorm:"type:text;not null"`
+	Permissions pq.StringArray `json:"permissions" gorm:"type:text[];default:'{}'"`
+	ExpiresAt   *time.Time     `json:"expiresAt"`
+	LastUsedAt  *time.Time     `json:"lastUsedAt"`
+	RevokedAt   *time.Time     `json:"revokedAt"`
+	UserId      uuid.UUID      `json:"userId" gorm:"type:uuid;not null;index"`
+	User        User           `json:"-" gorm:"foreignKey:UserId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CreatedById uuid.UUID      `json:"createdById" gorm:"type:uuid;not null"`
+}
+
+type CreateApiTokenPayload struct {
+	Name        string     `json:"name"`
+	Permissions []string   `json:"permissions"`
+	ExpiresAt   *time.Time `json:"expiresAt"`
+}
+
+type CreateServiceAccountPayload struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions"`
+}
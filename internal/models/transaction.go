@@ -1,21 +1,116 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
 
+// Transaction starts as a draft and only changes status through its lifecycle
+// transitions, which is why Status is never written by updates. It is in the
+// seller's currency and charges the seller's VAT rate as they were when it
+// was created; Net, Vat and Gross are kept in step with its lines.
 type Transaction struct {
 	Base
 	SellerId  uuid.UUID             `json:"sellerId" gorm:"type:uuid;not null"`
 	Seller    Business              `json:"seller" gorm:"foreignKey:SellerId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
 	BuyerId   uuid.UUID             `json:"buyerId" gorm:"type:uuid;not null"`
 	Buyer     Business              `json:"buyer" gorm:"foreignKey:BuyerId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Materials []TransactionMaterial `json:"materials" gorm:"many2many:transactions_materials;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Materials []TransactionMaterial `json:"materials" gorm:"foreignKey:TransactionId;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Status    Status                `json:"status" gorm:"<-:create;type:text;not null;default:draft;index"`
+	Currency  string                `json:"currency" gorm:"<-:create;type:char(3);not null;default:'ZAR'"`
+	VatRate   decimal.Decimal       `json:"vatRate" gorm:"<-:create;type:decimal(5,2);not null;default:0"`
+	Net       decimal.Decimal       `json:"net" gorm:"<-:false;type:decimal(12,2);not null;default:0"`
+	Vat       decimal.Decimal       `json:"vat" gorm:"<-:false;type:decimal(12,2);not null;default:0"`
+	Gross     decimal.Decimal       `json:"gross" gorm:"<-:false;type:decimal(12,2);not null;default:0"`
 }
 
+// BeforeCreate starts the transaction as a draft in the seller's currency,
+// with the seller's VAT rate when the seller is registered for VAT.
+func (t *Transaction) BeforeCreate(tx *gorm.DB) error {
+	t.Status = DraftStatus
+
+	var seller Business
+
+	if err := tx.Session(&gorm.Session{NewDB: true}).
+		Select("currency", "vat_registered", "vat_rate").
+		Where("id = ?", t.SellerId).
+		Take(&seller).Error; err != nil {
+		return err
+	}
+
+	t.Currency = seller.Currency
+	t.VatRate = decimal.Zero
+
+	if seller.VatRegistered {
+		t.VatRate = seller.VatRate
+	}
+
+	return nil
+}
+
+// TransactionMaterial is a line of a transaction, which owns it.
 type TransactionMaterial struct {
 	Base
-	Name         string  `json:"name" gorm:"type:text;not null"`
-	GWCode       string  `json:"gwCode" gorm:"type:text;not null"`
-	CarbonFactor string  `json:"carbonFactor" gorm:"type:text;not null"`
-	Weight       float64 `json:"weight" gorm:"type:decimal(10,2);not null"`
-	Value        float64 `json:"value" gorm:"type:decimal(10,2);not null"`
+	TransactionId uuid.UUID       `json:"transactionId" gorm:"<-:create;type:uuid;not null;index"`
+	MaterialId    *uuid.UUID      `json:"materialId" gorm:"type:uuid;index"`
+	Material      *Material       `json:"material,omitempty" gorm:"foreignKey:MaterialId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	Name          string          `json:"name" gorm:"type:text;not null"`
+	GWCode        string          `json:"gwCode" gorm:"type:text;not null"`
+	CarbonFactor  decimal.Decimal `json:"carbonFactor" gorm:"type:decimal(12,4);not null;default:0"`
+	Weight        float64         `json:"weight" gorm:"type:decimal(10,2);not null"`
+	Value         decimal.Decimal `json:"value" gorm:"type:decimal(10,2);not null"`
+	Vat           decimal.Decimal `json:"vat" gorm:"<-:false;type:decimal(10,2);not null;default:0"`
+}
+
+// BeforeCreate drops any material sent with the line, so creating a line can
+// never write to the catalogue, and snapshots the catalogue material instead.
+func (l *TransactionMaterial) BeforeCreate(tx *gorm.DB) error {
+	l.Material = nil
+
+	return l.SnapshotMaterial(tx)
+}
+
+// SnapshotMaterial copies the line's catalogue material onto the line. Call
+// it when the material of an existing line changes.
+func (l *TransactionMaterial) SnapshotMaterial(tx *gorm.DB) error {
+	return snapshotMaterial(tx, &l.MaterialId, &l.Name, &l.GWCode, &l.CarbonFactor)
+}
+
+// AfterSave refreshes the VAT and totals of the line's transaction.
+func (l *TransactionMaterial) AfterSave(tx *gorm.DB) error {
+	return RefreshTransactionAmounts(tx, l.TransactionId)
+}
+
+// AfterDelete refreshes the totals of the line's transaction.
+func (l *TransactionMaterial) AfterDelete(tx *gorm.DB) error {
+	if l.TransactionId == uuid.Nil {
+		return nil
+	}
+
+	return RefreshTransactionAmounts(tx, l.TransactionId)
+}
+
+type CreateTransactionLinePayload struct {
+	MaterialId uuid.UUID       `json:"materialId"`
+	Weight     float64         `json:"weight"`
+	Value      decimal.Decimal `json:"value"`
+}
+
+// UpdateTransactionLinePayload replaces the material, weight and value of a
+// line.
+type UpdateTransactionLinePayload struct {
+	MaterialId uuid.UUID       `json:"materialId"`
+	Weight     float64         `json:"weight"`
+	Value      decimal.Decimal `json:"value"`
+}
+
+// CreateTransactionPayload creates a transaction together with its lines. The
+// totals are optional and, when given, must match the lines.
+type CreateTransactionPayload struct {
+	SellerId    uuid.UUID                      `json:"sellerId"`
+	BuyerId     uuid.UUID                      `json:"buyerId"`
+	Materials   []CreateTransactionLinePayload `json:"materials"`
+	TotalWeight *float64                       `json:"totalWeight"`
+	TotalValue  *decimal.Decimal               `json:"totalValue"`
 }
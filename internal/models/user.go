@@ -16,25 +16,64 @@ const (
 	SystemUser    UserType = "system"
 	CollectorUser UserType = "collector"
 	BusinessUser  UserType = "business"
+	ServiceUser   UserType = "service"
+)
+
+type ApprovalStatus string
+
+const (
+	PendingApproval  ApprovalStatus = "pending"
+	ApprovedApproval ApprovalStatus = "approved"
+	RejectedApproval ApprovalStatus = "rejected"
 )
 
 type User struct {
 	Base
-	Name          string         `json:"name" gorm:"not null"`
-	Username      string         `json:"username" gorm:"uniqueIndex;not null"`
-	Password      []byte         `json:"-" gorm:"type:bytea"`
-	PasswordReset bool           `json:"passwordReset" gorm:"default:false"`
-	MfaSecret     []byte         `json:"-" gorm:"type:bytea"`
-	MfaEnabled    bool           `json:"mfaEnabled" gorm:"default:false"`
-	MfaVerified   bool           `json:"mfaVerified" gorm:"default:false"`
-	Permissions   pq.StringArray `json:"permissions" gorm:"type:text[];default:'{}'"`
-	Roles         []Role         `json:"roles" gorm:"many2many:users_roles;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
-	Type          UserType       `json:"type" gorm:"type:text;not null;default:'system'"`
-	Address       *Address       `json:"address" gorm:"type:jsonb;"`
-	BankDetails   *BankDetails   `json:"bankDetails" gorm:"type:jsonb;"`
-	IdNumber      *string        `json:"idNumber" gorm:"type:text;"`
-	BusinessId    *uuid.UUID     `json:"businessId" gorm:"type:uuid;"`
-	Businesses    []Business     `json:"businesses" gorm:"many2many:businesses_users;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Name           string         `json:"name" gorm:"not null"`
+	Username       string         `json:"username" gorm:"uniqueIndex;not null"`
+	Password       []byte         `json:"-" gorm:"type:bytea"`
+	PasswordReset  bool           `json:"passwordReset" gorm:"default:false"`
+	MfaSecret      []byte         `json:"-" gorm:"type:bytea"`
+	MfaEnabled     bool           `json:"mfaEnabled" gorm:"default:false"`
+	MfaVerified    bool           `json:"mfaVerified" gorm:"default:false"`
+	Permissions    pq.StringArray `json:"permissions" gorm:"type:text[];default:'{}'"`
+	Roles          []Role         `json:"roles" gorm:"many2many:users_roles;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Type           UserType       `json:"type" gorm:"type:text;not null;default:'system'"`
+	Verified       bool           `json:"verified" gorm:"not null;default:true"`
+	ApprovalStatus ApprovalStatus `json:"approvalStatus" gorm:"type:text;not null;default:'approved'"`
+	Address        *Address       `json:"address" gorm:"type:jsonb;"`
+	BankDetails    *BankDetails   `json:"bankDetails" gorm:"type:jsonb;"`
+	IdNumber       *string        `json:"idNumber" gorm:"type:text;"`
+	BusinessId     *uuid.UUID     `json:"businessId" gorm:"type:uuid;"`
+	Businesses     []Business     `json:"businesses" gorm:"many2many:businesses_users;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	// ActiveBusinessId is the business that business-scoped permissions and
+	// policies are evaluated against for the current request, and
+	// BusinessRoles are the user's roles inside that business.
+	ActiveBusinessId *uuid.UUID `json:"activeBusinessId" gorm:"-"`
+	BusinessRoles    []Role     `json:"businessRoles" gorm:"-"`
+	// TokenPermissions narrows the user's permissions when the request is
+	// authenticated with an api token.
+	TokenPermissions pq.StringArray `json:"-" gorm:"-"`
+}
+
+// MemberOf reports whether the user may act for the business. System users may
+// act for every business.
+func (u *User) MemberOf(businessId uuid.UUID) bool {
+	if u.Type == SystemUser {
+		return true
+	}
+
+	if u.BusinessId != nil && *u.BusinessId == businessId {
+		return true
+	}
+
+	for _, business := range u.Businesses {
+		if business.Id == businessId {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (u *User) BeforeCreate(tx *gorm.DB) error {
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Verification struct {
+	Base
+	UserId     uuid.UUID  `json:"userId" gorm:"type:uuid;not null;index"`
+	User       User       `json:"-" gorm:"foreignKey:UserId;references:Id;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	Recipient  string     `json:"recipient" gorm:"type:text;not null"`
+	CodeHash   string     `json:"-" gorm:"type:text;not null"`
+	Attempts   int        `json:"attempts" gorm:"not null;default:0"`
+	ExpiresAt  time.Time  `json:"expiresAt" gorm:"not null"`
+	ConsumedAt *time.Time `json:"consumedAt"`
+}
+
+type VerifyAccountPayload struct {
+	Username string `json:"username"`
+	Code     string `json:"code"`
+}
+
+type ResendVerificationPayload struct {
+	Username string `json:"username"`
+}
+
+type RejectRegistrationPayload struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,108 @@
+package notifications
+
+import (
+	"errors"
+	"fmt"
+	"net/smtp"
+	"strings"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/gofiber/fiber/v2/log"
+)
+
+var (
+	ErrNotConfigured        = errors.New("no email sender is configured")
+	ErrUnsupportedRecipient = errors.New("messages can only be sent to email addresses, phone numbers aren't supported yet")
+)
+
+type MessageType string
+
+const (
+	InvitationMessage   MessageType = "invitation"
+	VerificationMessage MessageType = "verification"
+	ApprovalMessage     MessageType = "approval"
+	RejectionMessage    MessageType = "rejection"
+)
+
+type Message struct {
+	Type    MessageType
+	To      string
+	Subject string
+	Body    string
+}
+
+type Notifications interface {
+	Configured() bool
+	Check(recipient string) error
+	Send(message Message) error
+}
+
+type notifications struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// New sends email through SMTP when APP_SMTP_HOST is set. There is no SMS
+// sender, so phone numbers are rejected, and nothing is sent without SMTP.
+// Message bodies carry tokens and codes, so they are never logged.
+func New() Notifications {
+	return &notifications{
+		host:     common.EnvString("APP_SMTP_HOST", ""),
+		port:     common.EnvString("APP_SMTP_PORT", "587"),
+		username: common.EnvString("APP_SMTP_USERNAME", ""),
+		password: common.EnvString("APP_SMTP_PASSWORD", ""),
+		from:     common.EnvString("APP_SMTP_FROM", "no-reply@3reco.co.za"),
+	}
+}
+
+// Configured reports whether there is a sender for email at all.
+func (n *notifications) Configured() bool {
+	return n.host != ""
+}
+
+// Check reports whether messages can be delivered to the recipient, so
+// callers can refuse a request before storing anything that needs a message.
+func (n *notifications) Check(recipient string) error {
+	if !strings.Contains(recipient, "@") {
+		return ErrUnsupportedRecipient
+	}
+
+	if !n.Configured() {
+		return ErrNotConfigured
+	}
+
+	return nil
+}
+
+func (n *notifications) Send(message Message) error {
+	if err := n.Check(message.To); err != nil {
+		log.Warnf("⚠️ Not sending %s message to %s: %s", message.Type, message.To, err.Error())
+
+		return err
+	}
+
+	var auth smtp.Auth
+
+	if n.username != "" {
+		auth = smtp.PlainAuth("", n.username, n.password, n.host)
+	}
+
+	body := fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		n.from,
+		message.To,
+		message.Subject,
+		message.Body,
+	)
+
+	if err := smtp.SendMail(fmt.Sprintf("%s:%s", n.host, n.port), auth, n.from, []string{message.To}, []byte(body)); err != nil {
+		return err
+	}
+
+	log.Infof("✅ Sent %s message to %s", message.Type, message.To)
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package notifications
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name      string
+		host      string
+		recipient string
+		want      error
+	}{
+		{name: "email with smtp", host: "smtp.example.com", recipient: "someone@example.com", want: nil},
+		{name: "email without smtp", host: "", recipient: "someone@example.com", want: ErrNotConfigured},
+		{name: "phone number", host: "smtp.example.com", recipient: "+27821234567", want: ErrUnsupportedRecipient},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			n := &notifications{host: test.host}
+
+			if err := n.Check(test.recipient); !errors.Is(err, test.want) {
+				t.Errorf("got %v, want %v", err, test.want)
+			}
+
+			if test.want != nil {
+				if err := n.Send(Message{Type: VerificationMessage, To: test.recipient, Body: "123456"}); !errors.Is(err, test.want) {
+					t.Errorf("got %v from send, want %v", err, test.want)
+				}
+			}
+		})
+	}
+}
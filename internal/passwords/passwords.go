@@ -0,0 +1,284 @@
+package passwords
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type Passwords interface {
+	Validate(username string, password string) []models.PasswordViolation
+	Reused(userId uuid.UUID, password string) (bool, error)
+	Remember(userId uuid.UUID, hashedPassword []byte) error
+}
+
+type Policy struct {
+	MinLength        int
+	RequireUpper     bool
+	RequireLower     bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	DisallowUsername bool
+	History          int
+}
+
+type passwords struct {
+	storage  storage.Storage
+	policy   Policy
+	breached map[string]map[string]struct{}
+}
+
+// New reads the password policy from the environment. The breached-password
+// check is off unless APP_BREACHED_PASSWORDS_FILE is set; when it is, a list
+// that can't be loaded is an error rather than a silently disabled check.
+func New(storage storage.Storage) (Passwords, error) {
+	policy := Policy{
+		MinLength:        common.EnvInt("APP_PASSWORD_MIN_LENGTH", 8),
+		RequireUpper:     common.EnvBool("APP_PASSWORD_REQUIRE_UPPER", true),
+		RequireLower:     common.EnvBool("APP_PASSWORD_REQUIRE_LOWER", true),
+		RequireDigit:     common.EnvBool("APP_PASSWORD_REQUIRE_DIGIT", true),
+		RequireSymbol:    common.EnvBool("APP_PASSWORD_REQUIRE_SYMBOL", false),
+		DisallowUsername: common.EnvBool("APP_PASSWORD_DISALLOW_USERNAME", true),
+		History:          common.EnvInt("APP_PASSWORD_HISTORY", 0),
+	}
+
+	breached, err := loadBreached(common.EnvString("APP_BREACHED_PASSWORDS_FILE", ""))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the breached passwords list: %w", err)
+	}
+
+	return &passwords{
+		storage:  storage,
+		policy:   policy,
+		breached: breached,
+	}, nil
+}
+
+// loadBreached reads a list of SHA-1 password hashes, one per line, in the
+// "HASH" or "HASH:COUNT" format used by the Have I Been Pwned downloads. The
+// hashes are bucketed by their first five characters so that lookups mirror the
+// k-anonymity range API without needing network access.
+func loadBreached(path string) (map[string]map[string]struct{}, error) {
+	breached := map[string]map[string]struct{}{}
+
+	if path == "" {
+		return breached, nil
+	}
+
+	file, err := os.Open(path)
+
+	if err != nil {
+		return breached, err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		hash, _, _ := strings.Cut(line, ":")
+		hash = strings.ToUpper(strings.TrimSpace(hash))
+
+		if len(hash) != 40 {
+			continue
+		}
+
+		prefix, suffix := hash[:5], hash[5:]
+
+		if _, ok := breached[prefix]; !ok {
+			breached[prefix] = map[string]struct{}{}
+		}
+
+		breached[prefix][suffix] = struct{}{}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return breached, err
+	}
+
+	log.Infof("✅ Loaded breached passwords list from %s", path)
+
+	return breached, nil
+}
+
+func (p *passwords) Validate(username string, password string) []models.PasswordViolation {
+	violations := []models.PasswordViolation{}
+
+	if len([]rune(password)) < p.policy.MinLength {
+		violations = append(violations, models.PasswordViolation{
+			Code:    "min_length",
+			Message: fmt.Sprintf("The password must be at least %d characters long.", p.policy.MinLength),
+		})
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+
+	for _, character := range password {
+		switch {
+		case unicode.IsUpper(character):
+			hasUpper = true
+		case unicode.IsLower(character):
+			hasLower = true
+		case unicode.IsDigit(character):
+			hasDigit = true
+		case unicode.IsPunct(character) || unicode.IsSymbol(character) || unicode.IsSpace(character):
+			hasSymbol = true
+		}
+	}
+
+	if p.policy.RequireUpper && !hasUpper {
+		violations = append(violations, models.PasswordViolation{
+			Code:    "require_upper",
+			Message: "The password must contain at least one uppercase letter.",
+		})
+	}
+
+	if p.policy.RequireLower && !hasLower {
+		violations = append(violations, models.PasswordViolation{
+			Code:    "require_lower",
+			Message: "The password must contain at least one lowercase letter.",
+		})
+	}
+
+	if p.policy.RequireDigit && !hasDigit {
+		violations = append(violations, models.PasswordViolation{
+			Code:    "require_digit",
+			Message: "The password must contain at least one digit.",
+		})
+	}
+
+	if p.policy.RequireSymbol && !hasSymbol {
+		violations = append(violations, models.PasswordViolation{
+			Code:    "require_symbol",
+			Message: "The password must contain at least one symbol.",
+		})
+	}
+
+	if p.policy.DisallowUsername && containsUsername(username, password) {
+		violations = append(violations, models.PasswordViolation{
+			Code:    "contains_username",
+			Message: "The password must not contain your username.",
+		})
+	}
+
+	if p.isBreached(password) {
+		violations = append(violations, models.PasswordViolation{
+			Code:    "breached",
+			Message: "The password has appeared in a known data breach. Please choose a different password.",
+		})
+	}
+
+	return violations
+}
+
+func containsUsername(username string, password string) bool {
+	username = strings.ToLower(strings.TrimSpace(username))
+	password = strings.ToLower(password)
+
+	if username == "" {
+		return false
+	}
+
+	if strings.Contains(password, username) {
+		return true
+	}
+
+	localPart, _, found := strings.Cut(username, "@")
+
+	if found && len(localPart) >= 3 && strings.Contains(password, localPart) {
+		return true
+	}
+
+	return false
+}
+
+func (p *passwords) isBreached(password string) bool {
+	if len(p.breached) == 0 {
+		return false
+	}
+
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, ok := p.breached[hash[:5]]
+
+	if !ok {
+		return false
+	}
+
+	_, ok = suffixes[hash[5:]]
+
+	return ok
+}
+
+func (p *passwords) Reused(userId uuid.UUID, password string) (bool, error) {
+	if p.policy.History <= 0 {
+		return false, nil
+	}
+
+	var history []models.PasswordHistory
+
+	if err := p.storage.Database().
+		Where("user_id = ?", userId).
+		Order("created_at DESC").
+		Limit(p.policy.History).
+		Find(&history).Error; err != nil {
+		return false, err
+	}
+
+	for _, entry := range history {
+		if bcrypt.CompareHashAndPassword(entry.Password, []byte(password)) == nil {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (p *passwords) Remember(userId uuid.UUID, hashedPassword []byte) error {
+	if p.policy.History <= 0 {
+		return nil
+	}
+
+	if err := p.storage.Database().Create(&models.PasswordHistory{
+		UserId:   userId,
+		Password: hashedPassword,
+	}).Error; err != nil {
+		return err
+	}
+
+	var stale []uuid.UUID
+
+	if err := p.storage.Database().
+		Model(&models.PasswordHistory{}).
+		Where("user_id = ?", userId).
+		Order("created_at DESC").
+		Offset(p.policy.History).
+		Pluck("id", &stale).Error; err != nil {
+		return err
+	}
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	return p.storage.Database().Where("id IN ?", stale).Delete(&models.PasswordHistory{}).Error
+}
@@ -0,0 +1,38 @@
+package passwords
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewBreachedList(t *testing.T) {
+	list := filepath.Join(t.TempDir(), "breached.txt")
+
+	// SHA-1 of "password".
+	if err := os.WriteFile(list, []byte("5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:3861493\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		fail bool
+	}{
+		{name: "unset disables the check", path: ""},
+		{name: "a readable list", path: list},
+		{name: "a missing list", path: filepath.Join(t.TempDir(), "missing.txt"), fail: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("APP_BREACHED_PASSWORDS_FILE", test.path)
+
+			_, err := New(nil)
+
+			if (err != nil) != test.fail {
+				t.Errorf("got error %v, want failure %t", err, test.fail)
+			}
+		})
+	}
+}
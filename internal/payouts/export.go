@@ -0,0 +1,217 @@
+package payouts
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/shopspring/decimal"
+)
+
+// Format is a bank batch file format.
+type Format string
+
+const (
+	CsvFormat Format = "csv"
+	EftFormat Format = "eft"
+)
+
+var formats = map[Format]func(run *models.PayoutRun, statements []models.PayoutStatement) ([]byte, error){
+	CsvFormat: renderCsv,
+	EftFormat: renderEft,
+}
+
+var (
+	errNoBankDetails     = errors.New("the collector has no bank details")
+	errNoAccountHolder   = errors.New("the collector's bank details have no account holder")
+	errInvalidBranchCode = errors.New("the collector's branch code must be up to 6 digits")
+	errInvalidAccount    = errors.New("the collector's account number must be up to 16 digits")
+)
+
+// eftWidth is the length of every EFT record, without the line ending.
+const eftWidth = 120
+
+// normalize copies bank details with the spaces and dashes people type into
+// account numbers and branch codes removed.
+func normalize(bankDetails *models.BankDetails) *models.BankDetails {
+	if bankDetails == nil {
+		return nil
+	}
+
+	strip := strings.NewReplacer(" ", "", "-", "")
+
+	return &models.BankDetails{
+		AccountHolder: strings.TrimSpace(bankDetails.AccountHolder),
+		AccountNumber: strip.Replace(bankDetails.AccountNumber),
+		BankName:      strings.TrimSpace(bankDetails.BankName),
+		BranchCode:    strip.Replace(bankDetails.BranchCode),
+	}
+}
+
+// validate checks that normalized bank details fit the EFT format.
+func validate(bankDetails *models.BankDetails) error {
+	if bankDetails == nil {
+		return errNoBankDetails
+	}
+
+	if bankDetails.AccountHolder == "" {
+		return errNoAccountHolder
+	}
+
+	if !digits(bankDetails.BranchCode, 6) {
+		return errInvalidBranchCode
+	}
+
+	if !digits(bankDetails.AccountNumber, 16) {
+		return errInvalidAccount
+	}
+
+	return nil
+}
+
+func digits(value string, max int) bool {
+	if value == "" || len(value) > max {
+		return false
+	}
+
+	for _, character := range value {
+		if character < '0' || character > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// renderCsv writes one row per statement after a header row.
+func renderCsv(run *models.PayoutRun, statements []models.PayoutStatement) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	writer := csv.NewWriter(&buffer)
+
+	if err := writer.Write([]string{"reference", "statement_id", "collector_id", "account_holder", "bank_name", "branch_code", "account_number", "currency", "amount"}); err != nil {
+		return nil, err
+	}
+
+	for _, statement := range statements {
+		if err := writer.Write([]string{
+			statement.Reference,
+			statement.Id.String(),
+			statement.CollectorId.String(),
+			statement.BankDetails.AccountHolder,
+			statement.BankDetails.BankName,
+			statement.BankDetails.BranchCode,
+			statement.BankDetails.AccountNumber,
+			run.Currency,
+			statement.Amount.StringFixed(2),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+// renderEft writes a fixed-width batch of 120 character records separated by
+// CRLF. Amounts are in cents, numbers are zero padded on the left and text is
+// upper case and space padded on the right:
+//
+//	Header   H, run reference (20), date YYYYMMDD (8), record count (6), total (15)
+//	Detail   D, branch code (6), account number (16), account holder (30), amount (15), reference (20)
+//	Trailer  T, record count (6), total (15), hash total of the account numbers (15)
+func renderEft(run *models.PayoutRun, statements []models.PayoutStatement) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	total := int64(0)
+	hash := int64(0)
+
+	for _, statement := range statements {
+		account, err := strconv.ParseInt(statement.BankDetails.AccountNumber, 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("statement %s: %w", statement.Reference, errInvalidAccount)
+		}
+
+		total += cents(statement.Amount)
+		hash = (hash + account%1e15) % 1e15
+	}
+
+	record := func(fields ...string) {
+		line := strings.Join(fields, "")
+
+		buffer.WriteString(line)
+		buffer.WriteString(strings.Repeat(" ", eftWidth-len(line)))
+		buffer.WriteString("\r\n")
+	}
+
+	record(
+		"H",
+		text(run.Reference, 20),
+		time.Now().Format("20060102"),
+		number(int64(len(statements)), 6),
+		number(total, 15),
+	)
+
+	for _, statement := range statements {
+		record(
+			"D",
+			leftPad(statement.BankDetails.BranchCode, 6),
+			leftPad(statement.BankDetails.AccountNumber, 16),
+			text(statement.BankDetails.AccountHolder, 30),
+			number(cents(statement.Amount), 15),
+			text(statement.Reference, 20),
+		)
+	}
+
+	record(
+		"T",
+		number(int64(len(statements)), 6),
+		number(total, 15),
+		number(hash, 15),
+	)
+
+	return buffer.Bytes(), nil
+}
+
+func cents(amount decimal.Decimal) int64 {
+	return amount.Shift(2).IntPart()
+}
+
+func number(value int64, width int) string {
+	return fmt.Sprintf("%0*d", width, value)
+}
+
+// leftPad pads a string of digits with zeros on the left to width.
+func leftPad(value string, width int) string {
+	return strings.Repeat("0", width-len(value)) + value
+}
+
+// text upper cases value, replaces everything but ASCII letters, digits,
+// spaces and dashes with spaces, and pads or cuts it to width.
+func text(value string, width int) string {
+	cleaned := strings.Map(func(character rune) rune {
+		if character > unicode.MaxASCII || !(unicode.IsLetter(character) || unicode.IsDigit(character) || character == ' ' || character == '-') {
+			return ' '
+		}
+
+		return unicode.ToUpper(character)
+	}, value)
+
+	if len(cleaned) > width {
+		return cleaned[:width]
+	}
+
+	return cleaned + strings.Repeat(" ", width-len(cleaned))
+}
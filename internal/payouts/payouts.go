@@ -0,0 +1,629 @@
+package payouts
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/lifecycle"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInvalidPeriod    = errors.New("the period must end after it starts")
+	ErrNothingToPay     = errors.New("there are no approved unpaid collections to pay in the period")
+	ErrRunClosed        = errors.New("the payout run has already been confirmed or cancelled")
+	ErrUnknownStatement = errors.New("the statement is not part of the payout run")
+	ErrUnknownFormat    = errors.New("the export format must be csv or eft")
+)
+
+// Skipped is a collector a payout run couldn't pay, and why.
+type Skipped struct {
+	CollectorId uuid.UUID `json:"collectorId"`
+	Name        string    `json:"name"`
+	Reason      string    `json:"reason"`
+}
+
+// Discrepancy is something about a payout run that doesn't add up.
+type Discrepancy struct {
+	StatementId  *uuid.UUID `json:"statementId"`
+	CollectionId *uuid.UUID `json:"collectionId"`
+	Problem      string     `json:"problem"`
+}
+
+// Reconciliation compares what a payout run was meant to pay with what its
+// statements and collections say was paid.
+type Reconciliation struct {
+	RunId         uuid.UUID              `json:"runId"`
+	Status        models.PayoutRunStatus `json:"status"`
+	Currency      string                 `json:"currency"`
+	Expected      decimal.Decimal        `json:"expected"`
+	Paid          decimal.Decimal        `json:"paid"`
+	Failed        decimal.Decimal        `json:"failed"`
+	Pending       decimal.Decimal        `json:"pending"`
+	Balanced      bool                   `json:"balanced"`
+	Discrepancies []Discrepancy          `json:"discrepancies"`
+}
+
+type Payouts interface {
+	// Create generates a payout run for the approved collections the business
+	// recorded from from until to that no other run holds, with a statement
+	// per collector. A run pays in the business's currency, so collections
+	// recorded in another currency are left out. Collectors without usable bank details, or with nothing
+	// to be paid, are skipped and their collections left for a later run.
+	Create(businessId uuid.UUID, from time.Time, to time.Time, actorId uuid.UUID) (*models.PayoutRun, []Skipped, error)
+	// Runs returns the business's payout runs, newest first, without their
+	// statements.
+	Runs(businessId uuid.UUID) ([]models.PayoutRun, error)
+	// Run returns a payout run of the business with its statements and
+	// items. It returns gorm.ErrRecordNotFound when the business has no such
+	// run.
+	Run(businessId uuid.UUID, runId uuid.UUID) (*models.PayoutRun, error)
+	// Export renders the run's pending and paid statements as a bank batch
+	// file in the given format, and marks a generated run exported. It
+	// returns the file and its name.
+	Export(businessId uuid.UUID, runId uuid.UUID, format Format) ([]byte, string, error)
+	// Confirm records that the bank paid the run: its statements are paid,
+	// apart from the failed ones, and their collections are marked paid.
+	// The collections of failed statements are released for a later run.
+	Confirm(businessId uuid.UUID, runId uuid.UUID, failedStatementIds []uuid.UUID, actorId uuid.UUID) (*models.PayoutRun, error)
+	// Cancel drops a run that hasn't been confirmed and releases its
+	// collections.
+	Cancel(businessId uuid.UUID, runId uuid.UUID) (*models.PayoutRun, error)
+	Reconcile(businessId uuid.UUID, runId uuid.UUID) (*Reconciliation, error)
+}
+
+type payouts struct {
+	storage   storage.Storage
+	lifecycle lifecycle.Lifecycle
+}
+
+func New(storage storage.Storage, lifecycle lifecycle.Lifecycle) Payouts {
+	return &payouts{
+		storage:   storage,
+		lifecycle: lifecycle,
+	}
+}
+
+// openStatuses are the statuses of runs that can still be confirmed or
+// cancelled.
+var openStatuses = []models.PayoutRunStatus{
+	models.GeneratedPayoutRun,
+	models.ExportedPayoutRun,
+}
+
+// payable is an approved collection that no run holds, with its value.
+type payable struct {
+	Id        uuid.UUID
+	SellerId  uuid.UUID
+	CreatedAt time.Time
+	Weight    float64
+	Value     decimal.Decimal
+}
+
+func (p *payouts) Create(businessId uuid.UUID, from time.Time, to time.Time, actorId uuid.UUID) (*models.PayoutRun, []Skipped, error) {
+	if !to.After(from) {
+		return nil, nil, ErrInvalidPeriod
+	}
+
+	run := models.PayoutRun{
+		Base: models.Base{
+			Id: uuid.New(),
+		},
+		BusinessId:  businessId,
+		From:        from,
+		To:          to,
+		Status:      models.GeneratedPayoutRun,
+		CreatedById: actorId,
+	}
+
+	run.Reference = fmt.Sprintf("PR%s%s", time.Now().Format("060102"), strings.ToUpper(strings.ReplaceAll(run.Id.String(), "-", "")[:6]))
+
+	skipped := []Skipped{}
+
+	if err := p.storage.Database().Transaction(func(tx *gorm.DB) error {
+		var business models.Business
+
+		if err := tx.Select("currency").Where("id = ?", businessId).Take(&business).Error; err != nil {
+			return err
+		}
+
+		run.Currency = business.Currency
+
+		// Locking the collections keeps concurrent runs, and transitions,
+		// from taking them while this run is generated.
+		collectionIds := []uuid.UUID{}
+
+		if err := tx.Model(&models.Collection{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("buyer_id = ? AND status = ? AND currency = ? AND created_at >= ? AND created_at < ?", businessId, models.ApprovedStatus, run.Currency, from, to).
+			Where("NOT EXISTS (SELECT 1 FROM payout_items WHERE payout_items.collection_id = collections.id AND payout_items.released = false)").
+			Pluck("id", &collectionIds).Error; err != nil {
+			return err
+		}
+
+		if len(collectionIds) == 0 {
+			return ErrNothingToPay
+		}
+
+		payables := []payable{}
+
+		if err := tx.Model(&models.Collection{}).
+			Select("collections.id, collections.seller_id, collections.created_at, COALESCE(SUM(collection_materials.weight), 0) AS weight, COALESCE(SUM(collection_materials.value), 0) AS value").
+			Joins("LEFT JOIN collection_materials ON collection_materials.collection_id = collections.id").
+			Where("collections.id IN ?", collectionIds).
+			Group("collections.id").
+			Order("collections.created_at ASC").
+			Scan(&payables).Error; err != nil {
+			return err
+		}
+
+		collectorIds := []uuid.UUID{}
+		byCollector := map[uuid.UUID][]payable{}
+
+		for _, collection := range payables {
+			if _, ok := byCollector[collection.SellerId]; !ok {
+				collectorIds = append(collectorIds, collection.SellerId)
+			}
+
+			byCollector[collection.SellerId] = append(byCollector[collection.SellerId], collection)
+		}
+
+		collectors := []models.User{}
+
+		if err := tx.Where("id IN ?", collectorIds).Order("name ASC").Find(&collectors).Error; err != nil {
+			return err
+		}
+
+		for _, collector := range collectors {
+			collections := byCollector[collector.Id]
+
+			statement := models.PayoutStatement{
+				Base: models.Base{
+					Id: uuid.New(),
+				},
+				PayoutRunId: run.Id,
+				CollectorId: collector.Id,
+				Reference:   fmt.Sprintf("%s-%03d", run.Reference, len(run.Statements)+1),
+				BankDetails: normalize(collector.BankDetails),
+				Status:      models.PendingPayoutStatement,
+			}
+
+			for _, collection := range collections {
+				statement.Items = append(statement.Items, models.PayoutItem{
+					PayoutStatementId: statement.Id,
+					CollectionId:      collection.Id,
+					CollectedAt:       collection.CreatedAt,
+					Weight:            round(collection.Weight),
+					Amount:            collection.Value,
+				})
+
+				statement.Weight += collection.Weight
+				statement.Amount = statement.Amount.Add(collection.Value)
+			}
+
+			statement.Weight = round(statement.Weight)
+
+			if err := validate(statement.BankDetails); err != nil {
+				skipped = append(skipped, Skipped{CollectorId: collector.Id, Name: collector.Name, Reason: err.Error()})
+
+				continue
+			}
+
+			if !statement.Amount.IsPositive() {
+				skipped = append(skipped, Skipped{CollectorId: collector.Id, Name: collector.Name, Reason: "the collector's collections have no value"})
+
+				continue
+			}
+
+			run.Statements = append(run.Statements, statement)
+			run.TotalAmount = run.TotalAmount.Add(statement.Amount)
+		}
+
+		if len(run.Statements) == 0 {
+			return ErrNothingToPay
+		}
+
+		if err := tx.Omit(clause.Associations).Create(&run).Error; err != nil {
+			return err
+		}
+
+		for _, statement := range run.Statements {
+			if err := tx.Omit(clause.Associations).Create(&statement).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Omit(clause.Associations).Create(&statement.Items).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, skipped, err
+	}
+
+	return &run, skipped, nil
+}
+
+func (p *payouts) Runs(businessId uuid.UUID) ([]models.PayoutRun, error) {
+	runs := []models.PayoutRun{}
+
+	if err := p.storage.Database().
+		Where("business_id = ?", businessId).
+		Order("created_at DESC").
+		Find(&runs).Error; err != nil {
+		return nil, err
+	}
+
+	return runs, nil
+}
+
+func (p *payouts) Run(businessId uuid.UUID, runId uuid.UUID) (*models.PayoutRun, error) {
+	return p.run(p.storage.Database(), businessId, runId)
+}
+
+func (p *payouts) Export(businessId uuid.UUID, runId uuid.UUID, format Format) ([]byte, string, error) {
+	render, ok := formats[format]
+
+	if !ok {
+		return nil, "", ErrUnknownFormat
+	}
+
+	var file []byte
+	var name string
+
+	if err := p.storage.Database().Transaction(func(tx *gorm.DB) error {
+		run, err := p.lock(tx, businessId, runId)
+
+		if err != nil {
+			return err
+		}
+
+		if run.Status == models.CancelledPayoutRun {
+			return ErrRunClosed
+		}
+
+		statements := []models.PayoutStatement{}
+
+		for _, statement := range run.Statements {
+			if statement.Status == models.PendingPayoutStatement || statement.Status == models.PaidPayoutStatement {
+				statements = append(statements, statement)
+			}
+		}
+
+		file, err = render(run, statements)
+
+		if err != nil {
+			return err
+		}
+
+		name = fmt.Sprintf("%s.%s", run.Reference, format)
+
+		if run.Status != models.GeneratedPayoutRun {
+			return nil
+		}
+
+		return tx.Model(&models.PayoutRun{}).
+			Where("id = ?", run.Id).
+			Updates(map[string]any{
+				"status":      models.ExportedPayoutRun,
+				"exported_at": time.Now(),
+			}).Error
+	}); err != nil {
+		return nil, "", err
+	}
+
+	return file, name, nil
+}
+
+func (p *payouts) Confirm(businessId uuid.UUID, runId uuid.UUID, failedStatementIds []uuid.UUID, actorId uuid.UUID) (*models.PayoutRun, error) {
+	var run *models.PayoutRun
+
+	if err := p.storage.Database().Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		run, err = p.lock(tx, businessId, runId)
+
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(openStatuses, run.Status) {
+			return ErrRunClosed
+		}
+
+		for _, statementId := range failedStatementIds {
+			if !slices.ContainsFunc(run.Statements, func(statement models.PayoutStatement) bool {
+				return statement.Id == statementId
+			}) {
+				return ErrUnknownStatement
+			}
+		}
+
+		now := time.Now()
+
+		for index := range run.Statements {
+			statement := &run.Statements[index]
+
+			if slices.Contains(failedStatementIds, statement.Id) {
+				statement.Status = models.FailedPayoutStatement
+
+				if err := p.release(tx, statement); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			statement.Status = models.PaidPayoutStatement
+			statement.PaidAt = &now
+
+			for _, item := range statement.Items {
+				if _, err := p.lifecycle.TransitionWith(tx, models.CollectionsPolicy, item.CollectionId, nil, "pay", nil, actorId); err != nil {
+					return fmt.Errorf("collection %s: %w", item.CollectionId, err)
+				}
+			}
+
+			if err := tx.Model(&models.PayoutStatement{}).
+				Where("id = ?", statement.Id).
+				Updates(map[string]any{
+					"status":  statement.Status,
+					"paid_at": now,
+				}).Error; err != nil {
+				return err
+			}
+		}
+
+		run.Status = models.ConfirmedPayoutRun
+		run.ConfirmedAt = &now
+		run.ConfirmedById = &actorId
+
+		return tx.Model(&models.PayoutRun{}).
+			Where("id = ?", run.Id).
+			Updates(map[string]any{
+				"status":          run.Status,
+				"confirmed_at":    now,
+				"confirmed_by_id": actorId,
+			}).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (p *payouts) Cancel(businessId uuid.UUID, runId uuid.UUID) (*models.PayoutRun, error) {
+	var run *models.PayoutRun
+
+	if err := p.storage.Database().Transaction(func(tx *gorm.DB) error {
+		var err error
+
+		run, err = p.lock(tx, businessId, runId)
+
+		if err != nil {
+			return err
+		}
+
+		if !slices.Contains(openStatuses, run.Status) {
+			return ErrRunClosed
+		}
+
+		for index := range run.Statements {
+			statement := &run.Statements[index]
+			statement.Status = models.CancelledPayoutStatement
+
+			if err := p.release(tx, statement); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+
+		run.Status = models.CancelledPayoutRun
+		run.CancelledAt = &now
+
+		return tx.Model(&models.PayoutRun{}).
+			Where("id = ?", run.Id).
+			Updates(map[string]any{
+				"status":       run.Status,
+				"cancelled_at": now,
+			}).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (p *payouts) Reconcile(businessId uuid.UUID, runId uuid.UUID) (*Reconciliation, error) {
+	run, err := p.Run(businessId, runId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	reconciliation := Reconciliation{
+		RunId:         run.Id,
+		Status:        run.Status,
+		Currency:      run.Currency,
+		Expected:      run.TotalAmount,
+		Discrepancies: []Discrepancy{},
+	}
+
+	collectionIds := []uuid.UUID{}
+
+	for _, statement := range run.Statements {
+		for _, item := range statement.Items {
+			collectionIds = append(collectionIds, item.CollectionId)
+		}
+	}
+
+	current := []struct {
+		Id     uuid.UUID
+		Status models.Status
+		Value  decimal.Decimal
+	}{}
+
+	if err := p.storage.Database().
+		Model(&models.Collection{}).
+		Select("collections.id, collections.status, COALESCE(SUM(collection_materials.value), 0) AS value").
+		Joins("LEFT JOIN collection_materials ON collection_materials.collection_id = collections.id").
+		Where("collections.id IN ?", collectionIds).
+		Group("collections.id").
+		Scan(&current).Error; err != nil {
+		return nil, err
+	}
+
+	statuses := map[uuid.UUID]models.Status{}
+	values := map[uuid.UUID]decimal.Decimal{}
+
+	for _, collection := range current {
+		statuses[collection.Id] = collection.Status
+		values[collection.Id] = collection.Value
+	}
+
+	// Items of other runs that paid, or still hold, the same collections.
+	others := []models.PayoutItem{}
+
+	if err := p.storage.Database().
+		Joins("JOIN payout_statements ON payout_statements.id = payout_items.payout_statement_id").
+		Where("payout_items.collection_id IN ? AND payout_statements.payout_run_id <> ?", collectionIds, run.Id).
+		Where("payout_items.released = false OR payout_statements.status = ?", models.PaidPayoutStatement).
+		Find(&others).Error; err != nil {
+		return nil, err
+	}
+
+	elsewhere := map[uuid.UUID]bool{}
+
+	for _, item := range others {
+		elsewhere[item.CollectionId] = true
+	}
+
+	total := decimal.Zero
+
+	for _, statement := range run.Statements {
+		statementId := statement.Id
+		items := decimal.Zero
+
+		switch statement.Status {
+		case models.PaidPayoutStatement:
+			reconciliation.Paid = reconciliation.Paid.Add(statement.Amount)
+		case models.FailedPayoutStatement:
+			reconciliation.Failed = reconciliation.Failed.Add(statement.Amount)
+		case models.PendingPayoutStatement:
+			reconciliation.Pending = reconciliation.Pending.Add(statement.Amount)
+		}
+
+		total = total.Add(statement.Amount)
+
+		for _, item := range statement.Items {
+			collectionId := item.CollectionId
+			items = items.Add(item.Amount)
+
+			problem := ""
+
+			switch {
+			case statuses[collectionId] == "":
+				problem = "the collection no longer exists"
+			case !values[collectionId].Equal(item.Amount):
+				problem = fmt.Sprintf("the collection is now worth %s instead of %s", values[collectionId].StringFixed(2), item.Amount.StringFixed(2))
+			case statement.Status == models.PaidPayoutStatement && statuses[collectionId] != models.PaidStatus:
+				problem = fmt.Sprintf("the statement was paid but the collection is %s", statuses[collectionId])
+			case statement.Status == models.PendingPayoutStatement && statuses[collectionId] != models.ApprovedStatus:
+				problem = fmt.Sprintf("the statement is waiting to be paid but the collection is %s", statuses[collectionId])
+			case elsewhere[collectionId] && statement.Status != models.FailedPayoutStatement && statement.Status != models.CancelledPayoutStatement:
+				problem = "the collection is also paid by another payout run"
+			}
+
+			if problem != "" {
+				reconciliation.Discrepancies = append(reconciliation.Discrepancies, Discrepancy{
+					StatementId:  &statementId,
+					CollectionId: &collectionId,
+					Problem:      problem,
+				})
+			}
+		}
+
+		if !items.Equal(statement.Amount) {
+			reconciliation.Discrepancies = append(reconciliation.Discrepancies, Discrepancy{
+				StatementId: &statementId,
+				Problem:     fmt.Sprintf("the statement pays %s but its collections add up to %s", statement.Amount.StringFixed(2), items.StringFixed(2)),
+			})
+		}
+	}
+
+	if !total.Equal(run.TotalAmount) {
+		reconciliation.Discrepancies = append(reconciliation.Discrepancies, Discrepancy{
+			Problem: fmt.Sprintf("the run pays %s but its statements add up to %s", run.TotalAmount.StringFixed(2), total.StringFixed(2)),
+		})
+	}
+
+	reconciliation.Balanced = len(reconciliation.Discrepancies) == 0
+
+	return &reconciliation, nil
+}
+
+func (p *payouts) run(query *gorm.DB, businessId uuid.UUID, runId uuid.UUID) (*models.PayoutRun, error) {
+	var run models.PayoutRun
+
+	if err := query.
+		Preload("Statements", func(db *gorm.DB) *gorm.DB {
+			return db.Order("payout_statements.reference ASC")
+		}).
+		Preload("Statements.Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("payout_items.collected_at ASC")
+		}).
+		Where("id = ? AND business_id = ?", runId, businessId).
+		First(&run).Error; err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
+
+// lock locks the run against other exports, confirmations and cancellations
+// until tx ends, and then loads it.
+func (p *payouts) lock(tx *gorm.DB, businessId uuid.UUID, runId uuid.UUID) (*models.PayoutRun, error) {
+	var locked models.PayoutRun
+
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ? AND business_id = ?", runId, businessId).
+		Take(&locked).Error; err != nil {
+		return nil, err
+	}
+
+	return p.run(tx, businessId, runId)
+}
+
+// release frees the statement's collections for a later run and stores its
+// new status.
+func (p *payouts) release(tx *gorm.DB, statement *models.PayoutStatement) error {
+	if err := tx.Model(&models.PayoutItem{}).
+		Where("payout_statement_id = ?", statement.Id).
+		Update("released", true).Error; err != nil {
+		return err
+	}
+
+	for index := range statement.Items {
+		statement.Items[index].Released = true
+	}
+
+	return tx.Model(&models.PayoutStatement{}).
+		Where("id = ?", statement.Id).
+		Update("status", statement.Status).Error
+}
+
+// round rounds a weight to the two decimals it is stored with.
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
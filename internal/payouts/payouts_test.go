@@ -0,0 +1,114 @@
+package payouts
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/inventory"
+	"github.com/connor-davis/threereco-nextgen/internal/lifecycle"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/testdb"
+	"github.com/shopspring/decimal"
+)
+
+func TestHeldCollections(t *testing.T) {
+	store := testdb.Open(t)
+	records := lifecycle.New(store, inventory.New(store))
+	service := New(store, records)
+
+	owner := testdb.User(t, store, models.BusinessUser)
+	collector := testdb.User(t, store, models.CollectorUser)
+	material := testdb.Material(t, store)
+
+	if err := store.Database().Model(&collector).Update("bank_details", &models.BankDetails{
+		AccountHolder: "Test User",
+		AccountNumber: "1234567890",
+		BankName:      "Test Bank",
+		BranchCode:    "250655",
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	reason := "The weight is wrong."
+
+	// run approves a collection of a new buyer and generates a payout run
+	// that holds it.
+	run := func(t *testing.T) (models.Collection, *models.PayoutRun) {
+		t.Helper()
+
+		buyer := testdb.Business(t, store, owner)
+		collection := testdb.Collection(t, store, collector, buyer)
+
+		if err := store.Database().Create(&models.CollectionMaterial{
+			CollectionId: collection.Id,
+			MaterialId:   &material.Id,
+			Weight:       10,
+			Value:        decimal.NewFromInt(50),
+		}).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		for _, name := range []string{"submit", "weigh", "approve"} {
+			if _, err := records.Transition(models.CollectionsPolicy, collection.Id, nil, name, nil, owner.Id); err != nil {
+				t.Fatalf("%s: %s", name, err.Error())
+			}
+		}
+
+		payoutRun, _, err := service.Create(buyer.Id, collection.CreatedAt.Add(-time.Minute), time.Now().Add(time.Minute), owner.Id)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return collection, payoutRun
+	}
+
+	status := func(t *testing.T, collection models.Collection) models.Status {
+		t.Helper()
+
+		if err := store.Database().Select("status").Where("id = ?", collection.Id).Take(&collection).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		return collection.Status
+	}
+
+	t.Run("a collection disputed mid-run is rejected and the run still pays it", func(t *testing.T) {
+		collection, payoutRun := run(t)
+
+		for _, name := range []string{"dispute", "void", "pay"} {
+			if _, err := records.Transition(models.CollectionsPolicy, collection.Id, nil, name, &reason, owner.Id); !errors.Is(err, lifecycle.ErrHeldByPayout) {
+				t.Errorf("%s: got %v, want %v", name, err, lifecycle.ErrHeldByPayout)
+			}
+		}
+
+		if got := status(t, collection); got != models.ApprovedStatus {
+			t.Fatalf("got status %s, want %s", got, models.ApprovedStatus)
+		}
+
+		if _, err := service.Confirm(payoutRun.BusinessId, payoutRun.Id, nil, owner.Id); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := status(t, collection); got != models.PaidStatus {
+			t.Errorf("got status %s, want %s", got, models.PaidStatus)
+		}
+	})
+
+	t.Run("a collection released by a cancelled run can be disputed", func(t *testing.T) {
+		collection, payoutRun := run(t)
+
+		if _, err := service.Cancel(payoutRun.BusinessId, payoutRun.Id); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := records.Transition(models.CollectionsPolicy, collection.Id, nil, "dispute", &reason, owner.Id); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := status(t, collection); got != models.DisputedStatus {
+			t.Errorf("got status %s, want %s", got, models.DisputedStatus)
+		}
+	})
+}
@@ -0,0 +1,72 @@
+package permissions
+
+import "strings"
+
+const wildcard = "*"
+
+// pattern is a permission split into its dot separated segments. A "*"
+// segment matches exactly one segment, except in last position where it
+// matches one or more, so "collections.*" covers "collections.materials.view"
+// while "*.view" only covers two segment permissions such as "users.view".
+type pattern []string
+
+func parse(permission string) pattern {
+	permission = strings.TrimSpace(permission)
+
+	if permission == "" {
+		return nil
+	}
+
+	segments := strings.Split(permission, ".")
+
+	for _, segment := range segments {
+		if segment == "" {
+			return nil
+		}
+	}
+
+	return segments
+}
+
+func trailing(p pattern) bool {
+	return len(p) == 1 && p[0] == wildcard
+}
+
+// covers reports whether every permission matched by required is also
+// matched by grant.
+func covers(grant pattern, required pattern) bool {
+	if len(grant) == 0 {
+		return len(required) == 0
+	}
+
+	if trailing(grant) {
+		return len(required) > 0
+	}
+
+	if len(required) == 0 || trailing(required) {
+		return false
+	}
+
+	if grant[0] != wildcard && grant[0] != required[0] {
+		return false
+	}
+
+	return covers(grant[1:], required[1:])
+}
+
+// overlaps reports whether at least one permission is matched by both a and b.
+func overlaps(a pattern, b pattern) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == 0 && len(b) == 0
+	}
+
+	if trailing(a) || trailing(b) {
+		return true
+	}
+
+	if a[0] != wildcard && b[0] != wildcard && a[0] != b[0] {
+		return false
+	}
+
+	return overlaps(a[1:], b[1:])
+}
@@ -0,0 +1,88 @@
+package permissions
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission string
+		want       int
+	}{
+		{name: "single segment", permission: "users", want: 1},
+		{name: "nested", permission: "users.view.self", want: 3},
+		{name: "surrounding space", permission: " users.view ", want: 2},
+		{name: "empty", permission: "", want: 0},
+		{name: "empty segment", permission: "users..view", want: 0},
+		{name: "trailing dot", permission: "users.", want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := len(parse(test.permission)); got != test.want {
+				t.Errorf("got %d segments, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCovers(t *testing.T) {
+	tests := []struct {
+		name     string
+		grant    string
+		required string
+		want     bool
+	}{
+		{name: "exact", grant: "users.view", required: "users.view", want: true},
+		{name: "exact mismatch", grant: "users.view", required: "users.create", want: false},
+		{name: "exact doesn't cover children", grant: "users.view", required: "users.view.self", want: false},
+		{name: "everything", grant: "*", required: "collections.materials.update", want: true},
+		{name: "trailing wildcard covers one segment", grant: "users.*", required: "users.view", want: true},
+		{name: "trailing wildcard covers nested segments", grant: "users.*", required: "users.roles.assign", want: true},
+		{name: "trailing wildcard needs a segment", grant: "users.*", required: "users", want: false},
+		{name: "trailing wildcard of another module", grant: "users.*", required: "businesses.view", want: false},
+		{name: "inner wildcard covers one segment", grant: "*.view", required: "users.view", want: true},
+		{name: "inner wildcard doesn't cover more", grant: "*.view", required: "users.view.self", want: false},
+		{name: "middle wildcard", grant: "users.*.assign", required: "users.roles.assign", want: true},
+		{name: "pattern covered by everything", grant: "*", required: "users.*", want: true},
+		{name: "pattern covered by the same pattern", grant: "users.*", required: "users.*", want: true},
+		{name: "pattern not covered by a permission", grant: "users.view", required: "users.*", want: false},
+		{name: "wider pattern not covered", grant: "users.roles.*", required: "users.*", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := covers(parse(test.grant), parse(test.required)); got != test.want {
+				t.Errorf("covers(%q, %q) = %t, want %t", test.grant, test.required, got, test.want)
+			}
+		})
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want bool
+	}{
+		{name: "equal", a: "users.delete.any", b: "users.delete.any", want: true},
+		{name: "different", a: "users.delete.any", b: "users.view", want: false},
+		{name: "trailing wildcard", a: "users.*", b: "users.delete.any", want: true},
+		{name: "both patterns", a: "users.*", b: "*.view", want: true},
+		{name: "inner wildcard length differs", a: "*.delete", b: "users.delete.any", want: false},
+		{name: "other module", a: "users.*", b: "businesses.*", want: false},
+		{name: "everything", a: "*", b: "businesses.*", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := overlaps(parse(test.a), parse(test.b)); got != test.want {
+				t.Errorf("overlaps(%q, %q) = %t, want %t", test.a, test.b, got, test.want)
+			}
+
+			if got := overlaps(parse(test.b), parse(test.a)); got != test.want {
+				t.Errorf("overlaps(%q, %q) = %t, want %t", test.b, test.a, got, test.want)
+			}
+		})
+	}
+}
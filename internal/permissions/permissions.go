@@ -0,0 +1,128 @@
+package permissions
+
+import (
+	"strings"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+)
+
+// Deny marks a permission as an explicit deny rule, e.g. "!users.delete.any".
+// Deny rules win over every grant, including "*".
+const Deny = "!"
+
+// Effective returns the user's global permissions together with the
+// permissions of their roles in the active business. Requests authenticated
+// with an api token only keep the token's permissions that the user still
+// holds.
+func Effective(user *models.User) []string {
+	combinedPermissions := []string{}
+
+	for _, permission := range user.Permissions {
+		combinedPermissions = append(combinedPermissions, permission)
+	}
+
+	for _, role := range user.Roles {
+		for _, permission := range role.Permissions {
+			combinedPermissions = append(combinedPermissions, permission)
+		}
+	}
+
+	for _, role := range user.BusinessRoles {
+		for _, permission := range role.Permissions {
+			combinedPermissions = append(combinedPermissions, permission)
+		}
+	}
+
+	if user.TokenPermissions == nil {
+		return combinedPermissions
+	}
+
+	grants, _ := split(combinedPermissions)
+	tokenPermissions := []string{}
+
+	for _, permission := range user.TokenPermissions {
+		if strings.HasPrefix(strings.TrimSpace(permission), Deny) {
+			tokenPermissions = append(tokenPermissions, permission)
+
+			continue
+		}
+
+		for _, grant := range grants {
+			if covers(grant, parse(permission)) {
+				tokenPermissions = append(tokenPermissions, permission)
+
+				break
+			}
+		}
+	}
+
+	// The user's deny rules keep applying to everything the token grants.
+	for _, permission := range combinedPermissions {
+		if strings.HasPrefix(strings.TrimSpace(permission), Deny) {
+			tokenPermissions = append(tokenPermissions, permission)
+		}
+	}
+
+	return tokenPermissions
+}
+
+// Allows reports whether any of the required permissions is granted and not
+// denied. Required permissions may be patterns themselves, which is how
+// delegation is checked: a grant must cover the whole pattern and no deny rule
+// may overlap any part of it.
+func Allows(granted []string, required ...string) bool {
+	grants, denies := split(granted)
+
+	for _, requiredPermission := range required {
+		requiredPattern := parse(requiredPermission)
+
+		if len(requiredPattern) == 0 {
+			continue
+		}
+
+		if denied(denies, requiredPattern) {
+			continue
+		}
+
+		for _, grant := range grants {
+			if covers(grant, requiredPattern) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func denied(denies []pattern, required pattern) bool {
+	for _, deny := range denies {
+		if overlaps(deny, required) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func split(permissions []string) ([]pattern, []pattern) {
+	grants := []pattern{}
+	denies := []pattern{}
+
+	for _, permission := range permissions {
+		permission = strings.TrimSpace(permission)
+
+		if rule, ok := strings.CutPrefix(permission, Deny); ok {
+			if deny := parse(rule); len(deny) > 0 {
+				denies = append(denies, deny)
+			}
+
+			continue
+		}
+
+		if grant := parse(permission); len(grant) > 0 {
+			grants = append(grants, grant)
+		}
+	}
+
+	return grants, denies
+}
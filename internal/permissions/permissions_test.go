@@ -0,0 +1,100 @@
+package permissions
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+)
+
+func TestAllows(t *testing.T) {
+	tests := []struct {
+		name     string
+		granted  []string
+		required []string
+		want     bool
+	}{
+		{name: "exact", granted: []string{"users.view"}, required: []string{"users.view"}, want: true},
+		{name: "not granted", granted: []string{"users.view"}, required: []string{"users.create"}, want: false},
+		{name: "nothing granted", granted: nil, required: []string{"users.view"}, want: false},
+		{name: "everything", granted: []string{"*"}, required: []string{"payouts.runs.confirm"}, want: true},
+		{name: "trailing wildcard", granted: []string{"collections.*"}, required: []string{"collections.materials.update"}, want: true},
+		{name: "any of the required", granted: []string{"users.view.self"}, required: []string{"users.view", "users.view.self"}, want: true},
+		{name: "deny shadows everything", granted: []string{"*", "!users.delete.any"}, required: []string{"users.delete.any"}, want: false},
+		{name: "deny leaves the rest", granted: []string{"*", "!users.delete.any"}, required: []string{"users.delete.self"}, want: true},
+		{name: "deny pattern shadows its module", granted: []string{"users.*", "!users.*"}, required: []string{"users.view"}, want: false},
+		{name: "deny order doesn't matter", granted: []string{"!users.view", "users.view"}, required: []string{"users.view"}, want: false},
+		{name: "deny on one of the required", granted: []string{"users.*", "!users.view"}, required: []string{"users.view", "users.view.self"}, want: true},
+		{name: "delegating a covered pattern", granted: []string{"users.*"}, required: []string{"users.*"}, want: true},
+		{name: "delegating past a deny", granted: []string{"users.*", "!users.delete.any"}, required: []string{"users.*"}, want: false},
+		{name: "delegating a wider pattern", granted: []string{"users.view"}, required: []string{"users.*"}, want: false},
+		{name: "unknown key", granted: []string{"users.*"}, required: []string{"unknown.key"}, want: false},
+		{name: "empty requirement", granted: []string{"*"}, required: []string{""}, want: false},
+		{name: "malformed requirement", granted: []string{"*"}, required: []string{"users..view"}, want: false},
+		{name: "malformed grant", granted: []string{"users..view"}, required: []string{"users.view"}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Allows(test.granted, test.required...); got != test.want {
+				t.Errorf("Allows(%q, %q) = %t, want %t", test.granted, test.required, got, test.want)
+			}
+		})
+	}
+}
+
+func TestEffective(t *testing.T) {
+	user := func(tokenPermissions []string) *models.User {
+		return &models.User{
+			Permissions: []string{"users.view.self", "!users.delete.any"},
+			Roles: []models.Role{
+				{Permissions: []string{"users.*"}},
+			},
+			BusinessRoles: []models.Role{
+				{Permissions: []string{"collections.view"}},
+			},
+			TokenPermissions: tokenPermissions,
+		}
+	}
+
+	tests := []struct {
+		name             string
+		tokenPermissions []string
+		want             []string
+	}{
+		{
+			name: "session",
+			want: []string{"users.view.self", "!users.delete.any", "users.*", "collections.view"},
+		},
+		{
+			name:             "token keeps what the user holds",
+			tokenPermissions: []string{"users.view", "collections.view", "payouts.runs.view"},
+			want:             []string{"users.view", "collections.view", "!users.delete.any"},
+		},
+		{
+			name:             "token can't widen a grant",
+			tokenPermissions: []string{"collections.*"},
+			want:             []string{"!users.delete.any"},
+		},
+		{
+			name:             "token deny rules are kept",
+			tokenPermissions: []string{"users.*", "!users.create"},
+			want:             []string{"users.*", "!users.create", "!users.delete.any"},
+		},
+		{
+			name:             "empty token",
+			tokenPermissions: []string{},
+			want:             []string{"!users.delete.any"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Effective(user(test.tokenPermissions))
+
+			if !slices.Equal(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package permissions
+
+import (
+	"strings"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+)
+
+// Unknown returns the permissions that don't refer to anything in the
+// common.Permissions registry. Wildcards and deny rules are accepted as long
+// as they match at least one concrete registered permission, so a typo such
+// as "colections.*" is reported instead of silently granting nothing.
+func Unknown(permissions []string) []string {
+	registered := registry()
+	unknown := []string{}
+
+	for _, permission := range permissions {
+		rule := strings.TrimPrefix(strings.TrimSpace(permission), Deny)
+		rulePattern := parse(rule)
+
+		if len(rulePattern) == 0 {
+			unknown = append(unknown, permission)
+
+			continue
+		}
+
+		if trailing(rulePattern) {
+			continue
+		}
+
+		known := false
+
+		for _, registeredPattern := range registered {
+			if overlaps(rulePattern, registeredPattern) {
+				known = true
+
+				break
+			}
+		}
+
+		if !known {
+			unknown = append(unknown, permission)
+		}
+	}
+
+	return unknown
+}
+
+func registry() []pattern {
+	patterns := []pattern{}
+
+	var walk func(groups []models.PermissionGroup)
+
+	walk = func(groups []models.PermissionGroup) {
+		for _, group := range groups {
+			for _, permission := range group.Permissions {
+				if strings.Contains(permission.Value, wildcard) {
+					continue
+				}
+
+				if registeredPattern := parse(permission.Value); len(registeredPattern) > 0 {
+					patterns = append(patterns, registeredPattern)
+				}
+			}
+
+			walk(group.SubGroups)
+		}
+	}
+
+	walk(common.Permissions)
+
+	return patterns
+}
@@ -0,0 +1,32 @@
+package permissions
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestUnknown(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		want        []string
+	}{
+		{name: "registered", permissions: []string{"users.view", "users.roles.assign"}, want: []string{}},
+		{name: "everything", permissions: []string{"*"}, want: []string{}},
+		{name: "registered wildcard", permissions: []string{"collections.*", "*.view"}, want: []string{}},
+		{name: "registered deny", permissions: []string{"!users.delete.any", "!users.*"}, want: []string{}},
+		{name: "typo", permissions: []string{"colections.*"}, want: []string{"colections.*"}},
+		{name: "unknown key", permissions: []string{"users.view", "users.fly"}, want: []string{"users.fly"}},
+		{name: "unknown deny", permissions: []string{"!users.fly"}, want: []string{"!users.fly"}},
+		{name: "double wildcard segment", permissions: []string{"users.**"}, want: []string{"users.**"}},
+		{name: "malformed", permissions: []string{"", "users..view"}, want: []string{"", "users..view"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Unknown(test.permissions); !slices.Equal(got, test.want) {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package policies
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var ErrOutOfScope = errors.New("the record is outside the policy's rows")
+
+type Action string
+
+const (
+	ViewAction   Action = "view"
+	CreateAction Action = "create"
+	UpdateAction Action = "update"
+	DeleteAction Action = "delete"
+)
+
+var Actions = []Action{ViewAction, CreateAction, UpdateAction, DeleteAction}
+
+// TransitionAction is the action of taking the named lifecycle transition,
+// such as "status.dispute". Policies that allow UpdateAction allow every
+// transition; list a transition action to allow only that one.
+func TransitionAction(name string) Action {
+	return Action(fmt.Sprintf("status.%s", name))
+}
+
+// allows reports whether actions covers action.
+func allows(actions []Action, action Action) bool {
+	if slices.Contains(actions, action) {
+		return true
+	}
+
+	return strings.HasPrefix(string(action), "status.") && slices.Contains(actions, UpdateAction)
+}
+
+// Value names what a condition compares a column against. Values are resolved
+// from the user at evaluation time.
+type Value string
+
+const (
+	// UserValue is the id of the current user.
+	UserValue Value = "user"
+	// ActiveBusinessValue is the business the user is currently acting for.
+	ActiveBusinessValue Value = "activeBusiness"
+)
+
+// Condition matches rows whose column equals the resolved value.
+type Condition struct {
+	Column string `json:"column"`
+	Value  Value  `json:"value"`
+}
+
+// Policy allows the listed actions on an entity to the listed user types. Rows
+// limits the allowed records to those matching any of the conditions, no
+// conditions means every record.
+type Policy struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Entity      models.PolicyType `json:"entity"`
+	UserTypes   []models.UserType `json:"userTypes"`
+	Actions     []Action          `json:"actions"`
+	Rows        []Condition       `json:"rows"`
+}
+
+// Decision is the outcome of evaluating every policy for a user, entity and
+// action, along with the reasoning behind it.
+type Decision struct {
+	Allowed  bool              `json:"allowed"`
+	Entity   models.PolicyType `json:"entity"`
+	Action   Action            `json:"action"`
+	UserType models.UserType   `json:"userType"`
+	Policies []string          `json:"policies"`
+	Filter   *string           `json:"filter"`
+	Reason   string            `json:"reason"`
+
+	expression clause.Expression
+}
+
+// Expression returns the row filter to apply to queries, or nil when every
+// row is allowed.
+func (d Decision) Expression() clause.Expression {
+	return d.expression
+}
+
+// Evaluate composes every policy that applies to the user's type, the entity
+// and the action. Matching policies are combined with OR: a policy without
+// row conditions allows every row, otherwise a row is allowed when it matches
+// any condition of any matching policy. Conditions that can't be resolved,
+// such as the active business of a user without one, never match. With no
+// matching policy, or no resolvable condition, the request is denied.
+func Evaluate(registry []Policy, user *models.User, entity models.PolicyType, action Action) Decision {
+	decision := Decision{
+		Entity:   entity,
+		Action:   action,
+		UserType: user.Type,
+		Policies: []string{},
+	}
+
+	conditions := []clause.Expression{}
+	descriptions := []string{}
+	unrestricted := false
+	unresolved := false
+
+	for _, policy := range registry {
+		if policy.Entity != entity ||
+			!slices.Contains(policy.UserTypes, user.Type) ||
+			!allows(policy.Actions, action) {
+			continue
+		}
+
+		decision.Policies = append(decision.Policies, policy.Name)
+
+		if len(policy.Rows) == 0 {
+			unrestricted = true
+
+			continue
+		}
+
+		for _, condition := range policy.Rows {
+			value, ok := resolve(user, condition.Value)
+
+			if !ok {
+				unresolved = true
+
+				continue
+			}
+
+			conditions = append(conditions, clause.Eq{
+				Column: clause.Column{
+					Name: condition.Column,
+				},
+				Value: value,
+			})
+			descriptions = append(descriptions, fmt.Sprintf("%s = %s", condition.Column, value))
+		}
+	}
+
+	switch {
+	case len(decision.Policies) == 0:
+		decision.Reason = fmt.Sprintf("No policy allows %s users to %s %s.", user.Type, action, entity)
+	case unrestricted:
+		decision.Allowed = true
+		decision.Reason = fmt.Sprintf("Allowed on every record by %s.", strings.Join(decision.Policies, ", "))
+	case len(conditions) == 0 && unresolved:
+		decision.Reason = "The matching policies are limited to the active business, but there is no active business."
+	case len(conditions) == 0:
+		decision.Reason = "The matching policies don't allow any records."
+	default:
+		filter := strings.Join(descriptions, " OR ")
+
+		decision.Allowed = true
+		decision.Filter = &filter
+		decision.Reason = fmt.Sprintf("Allowed on records where %s by %s.", filter, strings.Join(decision.Policies, ", "))
+		decision.expression = clause.Or(conditions...)
+	}
+
+	return decision
+}
+
+// InScope checks that the row filter covers the record with the given id. Run
+// it in the transaction that created the record, so that records created
+// outside the user's rows, which they couldn't see afterwards, are rolled
+// back with ErrOutOfScope.
+func InScope(tx *gorm.DB, model any, id uuid.UUID, scope clause.Expression) error {
+	if scope == nil {
+		return nil
+	}
+
+	var count int64
+
+	if err := tx.Model(model).Clauses(scope).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return ErrOutOfScope
+	}
+
+	return nil
+}
+
+// Lines turns the row filter of collections or transactions into one for
+// their lines, which have no row conditions of their own: a line is in scope
+// when its record is. A nil scope stays nil.
+func Lines(entity models.PolicyType, scope clause.Expression) clause.Expression {
+	if scope == nil {
+		return nil
+	}
+
+	// The lines of <record>s have a <record>_id column.
+	record := strings.TrimSuffix(string(entity), "s")
+
+	return clause.Expr{
+		SQL:  fmt.Sprintf("%s_id IN (SELECT id FROM %s WHERE ?)", record, entity),
+		Vars: []any{scope},
+	}
+}
+
+func resolve(user *models.User, value Value) (string, bool) {
+	switch value {
+	case UserValue:
+		return user.Id.String(), true
+	case ActiveBusinessValue:
+		if user.ActiveBusinessId == nil {
+			return "", false
+		}
+
+		return user.ActiveBusinessId.String(), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,334 @@
+package policies
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/testdb"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	userId     = uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	businessId = uuid.MustParse("00000000-0000-0000-0000-0000000000b1")
+)
+
+func user(userType models.UserType, activeBusinessId *uuid.UUID) *models.User {
+	return &models.User{
+		Base:             models.Base{Id: userId},
+		Type:             userType,
+		ActiveBusinessId: activeBusinessId,
+	}
+}
+
+// filter renders the decision's row filter as the query InScope runs, without
+// a database.
+func filter(t *testing.T, expression clause.Expression) string {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	query := db.Table("records")
+
+	if expression != nil {
+		query = query.Clauses(expression)
+	}
+
+	var count int64
+
+	statement := query.Count(&count).Statement
+
+	return db.Dialector.Explain(statement.SQL.String(), statement.Vars...)
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		user     *models.User
+		entity   models.PolicyType
+		action   Action
+		allowed  bool
+		policies []string
+		sql      string
+	}{
+		{
+			name:     "system users see every collection",
+			user:     user(models.SystemUser, nil),
+			entity:   models.CollectionsPolicy,
+			action:   DeleteAction,
+			allowed:  true,
+			policies: []string{"collections.system"},
+			sql:      `SELECT count(*) FROM "records"`,
+		},
+		{
+			name:     "collectors see the collections they sold",
+			user:     user(models.CollectorUser, nil),
+			entity:   models.CollectionsPolicy,
+			action:   ViewAction,
+			allowed:  true,
+			policies: []string{"collections.collector"},
+			sql:      `SELECT count(*) FROM "records" WHERE "seller_id" = '00000000-0000-0000-0000-000000000001'`,
+		},
+		{
+			name:     "collectors can't update collections",
+			user:     user(models.CollectorUser, nil),
+			entity:   models.CollectionsPolicy,
+			action:   UpdateAction,
+			allowed:  false,
+			policies: []string{},
+		},
+		{
+			name:     "collectors can dispute the collections they sold",
+			user:     user(models.CollectorUser, nil),
+			entity:   models.CollectionsPolicy,
+			action:   TransitionAction("dispute"),
+			allowed:  true,
+			policies: []string{"collections.collector"},
+			sql:      `SELECT count(*) FROM "records" WHERE "seller_id" = '00000000-0000-0000-0000-000000000001'`,
+		},
+		{
+			name:     "collectors can't approve collections",
+			user:     user(models.CollectorUser, nil),
+			entity:   models.CollectionsPolicy,
+			action:   TransitionAction("approve"),
+			allowed:  false,
+			policies: []string{},
+		},
+		{
+			name:     "updating covers every transition",
+			user:     user(models.BusinessUser, &businessId),
+			entity:   models.CollectionsPolicy,
+			action:   TransitionAction("approve"),
+			allowed:  true,
+			policies: []string{"collections.business"},
+			sql:      `SELECT count(*) FROM "records" WHERE "buyer_id" = '00000000-0000-0000-0000-0000000000b1'`,
+		},
+		{
+			name:     "business users manage what their active business bought",
+			user:     user(models.BusinessUser, &businessId),
+			entity:   models.CollectionsPolicy,
+			action:   UpdateAction,
+			allowed:  true,
+			policies: []string{"collections.business"},
+			sql:      `SELECT count(*) FROM "records" WHERE "buyer_id" = '00000000-0000-0000-0000-0000000000b1'`,
+		},
+		{
+			name:     "service users follow the business policy",
+			user:     user(models.ServiceUser, &businessId),
+			entity:   models.CollectionsPolicy,
+			action:   CreateAction,
+			allowed:  true,
+			policies: []string{"collections.business"},
+			sql:      `SELECT count(*) FROM "records" WHERE "buyer_id" = '00000000-0000-0000-0000-0000000000b1'`,
+		},
+		{
+			name:     "business users without an active business see nothing",
+			user:     user(models.BusinessUser, nil),
+			entity:   models.CollectionsPolicy,
+			action:   ViewAction,
+			allowed:  false,
+			policies: []string{"collections.business"},
+		},
+		{
+			name:     "system users see every transaction",
+			user:     user(models.SystemUser, nil),
+			entity:   models.TransactionsPolicy,
+			action:   ViewAction,
+			allowed:  true,
+			policies: []string{"transactions.system"},
+			sql:      `SELECT count(*) FROM "records"`,
+		},
+		{
+			name:     "business users see what their active business sold or bought",
+			user:     user(models.BusinessUser, &businessId),
+			entity:   models.TransactionsPolicy,
+			action:   DeleteAction,
+			allowed:  true,
+			policies: []string{"transactions.business"},
+			sql:      `SELECT count(*) FROM "records" WHERE ("seller_id" = '00000000-0000-0000-0000-0000000000b1' OR "buyer_id" = '00000000-0000-0000-0000-0000000000b1')`,
+		},
+		{
+			name:     "collectors have no transaction policy",
+			user:     user(models.CollectorUser, nil),
+			entity:   models.TransactionsPolicy,
+			action:   ViewAction,
+			allowed:  false,
+			policies: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decision := Evaluate(Registry, test.user, test.entity, test.action)
+
+			if decision.Allowed != test.allowed {
+				t.Fatalf("got allowed %t, want %t: %s", decision.Allowed, test.allowed, decision.Reason)
+			}
+
+			if !slices.Equal(decision.Policies, test.policies) {
+				t.Errorf("got policies %q, want %q", decision.Policies, test.policies)
+			}
+
+			if !test.allowed {
+				if decision.Expression() != nil {
+					t.Error("a denied decision has a row filter")
+				}
+
+				return
+			}
+
+			if sql := filter(t, decision.Expression()); sql != test.sql {
+				t.Errorf("got row filter\n%s\nwant\n%s", sql, test.sql)
+			}
+		})
+	}
+}
+
+func TestEvaluateCombinesPolicies(t *testing.T) {
+	registry := []Policy{
+		{
+			Name:      "sold",
+			Entity:    models.CollectionsPolicy,
+			UserTypes: []models.UserType{models.BusinessUser},
+			Actions:   []Action{ViewAction},
+			Rows:      []Condition{{Column: "seller_id", Value: UserValue}},
+		},
+		{
+			Name:      "bought",
+			Entity:    models.CollectionsPolicy,
+			UserTypes: []models.UserType{models.BusinessUser},
+			Actions:   []Action{ViewAction},
+			Rows:      []Condition{{Column: "buyer_id", Value: ActiveBusinessValue}},
+		},
+		{
+			Name:      "everything",
+			Entity:    models.CollectionsPolicy,
+			UserTypes: []models.UserType{models.SystemUser},
+			Actions:   []Action{ViewAction},
+		},
+	}
+
+	tests := []struct {
+		name string
+		user *models.User
+		sql  string
+	}{
+		{
+			name: "conditions of every matching policy are combined",
+			user: user(models.BusinessUser, &businessId),
+			sql:  `SELECT count(*) FROM "records" WHERE ("seller_id" = '00000000-0000-0000-0000-000000000001' OR "buyer_id" = '00000000-0000-0000-0000-0000000000b1')`,
+		},
+		{
+			name: "unresolvable conditions are dropped",
+			user: user(models.BusinessUser, nil),
+			sql:  `SELECT count(*) FROM "records" WHERE "seller_id" = '00000000-0000-0000-0000-000000000001'`,
+		},
+		{
+			name: "a policy without rows allows everything",
+			user: user(models.SystemUser, nil),
+			sql:  `SELECT count(*) FROM "records"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decision := Evaluate(registry, test.user, models.CollectionsPolicy, ViewAction)
+
+			if !decision.Allowed {
+				t.Fatalf("denied: %s", decision.Reason)
+			}
+
+			if sql := filter(t, decision.Expression()); sql != test.sql {
+				t.Errorf("got row filter\n%s\nwant\n%s", sql, test.sql)
+			}
+		})
+	}
+}
+
+func TestLines(t *testing.T) {
+	tests := []struct {
+		name   string
+		entity models.PolicyType
+		user   *models.User
+		sql    string
+	}{
+		{
+			name:   "collection lines are limited to the collections in scope",
+			entity: models.CollectionsPolicy,
+			user:   user(models.CollectorUser, nil),
+			sql:    `SELECT count(*) FROM "records" WHERE collection_id IN (SELECT id FROM collections WHERE "seller_id" = '00000000-0000-0000-0000-000000000001')`,
+		},
+		{
+			name:   "transaction lines are limited to the transactions in scope",
+			entity: models.TransactionsPolicy,
+			user:   user(models.BusinessUser, &businessId),
+			sql:    `SELECT count(*) FROM "records" WHERE transaction_id IN (SELECT id FROM transactions WHERE ("seller_id" = '00000000-0000-0000-0000-0000000000b1' OR "buyer_id" = '00000000-0000-0000-0000-0000000000b1'))`,
+		},
+		{
+			name:   "unrestricted lines stay unrestricted",
+			entity: models.CollectionsPolicy,
+			user:   user(models.SystemUser, nil),
+			sql:    `SELECT count(*) FROM "records"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decision := Evaluate(Registry, test.user, test.entity, ViewAction)
+
+			if !decision.Allowed {
+				t.Fatalf("denied: %s", decision.Reason)
+			}
+
+			if sql := filter(t, Lines(test.entity, decision.Expression())); sql != test.sql {
+				t.Errorf("got row filter\n%s\nwant\n%s", sql, test.sql)
+			}
+		})
+	}
+}
+
+func TestInScope(t *testing.T) {
+	store := testdb.Open(t)
+
+	collector := testdb.User(t, store, models.CollectorUser)
+	owner := testdb.User(t, store, models.BusinessUser)
+	buyer := testdb.Business(t, store, owner)
+	other := testdb.Business(t, store, owner)
+	collection := testdb.Collection(t, store, collector, buyer)
+
+	tests := []struct {
+		name string
+		user *models.User
+		err  error
+	}{
+		{name: "unrestricted", user: user(models.SystemUser, nil)},
+		{name: "the collector who sold it", user: &models.User{Base: models.Base{Id: collector.Id}, Type: models.CollectorUser}},
+		{name: "another collector", user: user(models.CollectorUser, nil), err: ErrOutOfScope},
+		{name: "the buying business", user: user(models.BusinessUser, &buyer.Id)},
+		{name: "another business", user: user(models.BusinessUser, &other.Id), err: ErrOutOfScope},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			decision := Evaluate(Registry, test.user, models.CollectionsPolicy, ViewAction)
+
+			err := InScope(store.Database(), &models.Collection{}, collection.Id, decision.Expression())
+
+			if !errors.Is(err, test.err) {
+				t.Errorf("got %v, want %v", err, test.err)
+			}
+		})
+	}
+}
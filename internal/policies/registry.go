@@ -0,0 +1,53 @@
+package policies
+
+import "github.com/connor-davis/threereco-nextgen/internal/models"
+
+// Registry holds every data policy. Add a row here to give a user type access
+// to an entity instead of changing the middleware.
+var Registry = []Policy{
+	{
+		Name:        "collections.system",
+		Description: "System users can manage every collection.",
+		Entity:      models.CollectionsPolicy,
+		UserTypes:   []models.UserType{models.SystemUser},
+		Actions:     Actions,
+	},
+	{
+		Name:        "collections.collector",
+		Description: "Collectors can view and dispute the collections they sold.",
+		Entity:      models.CollectionsPolicy,
+		UserTypes:   []models.UserType{models.CollectorUser},
+		Actions:     []Action{ViewAction, TransitionAction("dispute")},
+		Rows: []Condition{
+			{Column: "seller_id", Value: UserValue},
+		},
+	},
+	{
+		Name:        "collections.business",
+		Description: "Business and service users can manage the collections bought by their active business.",
+		Entity:      models.CollectionsPolicy,
+		UserTypes:   []models.UserType{models.BusinessUser, models.ServiceUser},
+		Actions:     Actions,
+		Rows: []Condition{
+			{Column: "buyer_id", Value: ActiveBusinessValue},
+		},
+	},
+	{
+		Name:        "transactions.system",
+		Description: "System users can manage every transaction.",
+		Entity:      models.TransactionsPolicy,
+		UserTypes:   []models.UserType{models.SystemUser},
+		Actions:     Actions,
+	},
+	{
+		Name:        "transactions.business",
+		Description: "Business and service users can manage the transactions their active business sold or bought.",
+		Entity:      models.TransactionsPolicy,
+		UserTypes:   []models.UserType{models.BusinessUser, models.ServiceUser},
+		Actions:     Actions,
+		Rows: []Condition{
+			{Column: "seller_id", Value: ActiveBusinessValue},
+			{Column: "buyer_id", Value: ActiveBusinessValue},
+		},
+	},
+}
@@ -0,0 +1,245 @@
+package pricing
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNoPrice      = errors.New("the business has no price for this material")
+	ErrInvalidPrice = errors.New("the price per kg must be an amount of at least zero with at most four decimals")
+	ErrInvalidTier  = errors.New("the minimum weight must be a number of at least zero")
+)
+
+// maxPrice is the largest price that fits the decimal(12,4) column.
+var maxPrice = decimal.RequireFromString("99999999.9999")
+
+type Pricing interface {
+	Validate(price *models.MaterialPrice) error
+	// Prices returns the business's price list as it stood at the given time:
+	// the version of every tier that applied then.
+	Prices(businessId uuid.UUID, at time.Time) ([]models.MaterialPrice, error)
+	// History returns every price version of the business, optionally for one
+	// material, newest first.
+	History(businessId uuid.UUID, materialId *uuid.UUID) ([]models.MaterialPrice, error)
+	// Resolve picks the tier that prices a line: graded tiers for the
+	// collector's grade win over ungraded ones, and of those the tier with
+	// the highest minimum weight the line reaches. It returns ErrNoPrice when
+	// no tier applies.
+	Resolve(businessId uuid.UUID, materialId uuid.UUID, grade *string, weight float64, at time.Time) (*models.MaterialPrice, error)
+	// Grade returns the grade the business gave the collector, or nil.
+	Grade(businessId uuid.UUID, collectorId uuid.UUID) (*string, error)
+	// Value is the weight at the price, rounded to cents.
+	Value(weight float64, pricePerKg decimal.Decimal) decimal.Decimal
+	// ValueLine values a collection line from the buyer's price list as it
+	// stood when the collection was recorded. A value given by hand is kept
+	// and recorded as an override by userId next to the computed value. It
+	// returns ErrNoPrice for lines without a value that no price applies to.
+	ValueLine(collection *models.Collection, line *models.CollectionMaterial, materialId uuid.UUID, value *decimal.Decimal, userId uuid.UUID) error
+}
+
+type pricing struct {
+	storage storage.Storage
+}
+
+func New(storage storage.Storage) Pricing {
+	return &pricing{
+		storage: storage,
+	}
+}
+
+func (p *pricing) Validate(price *models.MaterialPrice) error {
+	if price.PricePerKg.IsNegative() || price.PricePerKg.GreaterThan(maxPrice) || !price.PricePerKg.Equal(price.PricePerKg.Round(4)) {
+		return ErrInvalidPrice
+	}
+
+	if math.IsNaN(price.MinWeight) || price.MinWeight < 0 {
+		return ErrInvalidTier
+	}
+
+	return nil
+}
+
+func (p *pricing) Prices(businessId uuid.UUID, at time.Time) ([]models.MaterialPrice, error) {
+	return p.prices(p.storage.Database().Where("business_id = ?", businessId), at)
+}
+
+func (p *pricing) History(businessId uuid.UUID, materialId *uuid.UUID) ([]models.MaterialPrice, error) {
+	query := p.storage.Database().Where("business_id = ?", businessId)
+
+	if materialId != nil {
+		query = query.Where("material_id = ?", *materialId)
+	}
+
+	prices := []models.MaterialPrice{}
+
+	if err := query.
+		Preload("Material").
+		Order("effective_from DESC, created_at DESC").
+		Find(&prices).Error; err != nil {
+		return nil, err
+	}
+
+	return prices, nil
+}
+
+func (p *pricing) Resolve(businessId uuid.UUID, materialId uuid.UUID, grade *string, weight float64, at time.Time) (*models.MaterialPrice, error) {
+	prices, err := p.prices(p.storage.Database().Where("business_id = ? AND material_id = ?", businessId, materialId), at)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var resolved *models.MaterialPrice
+
+	for index := range prices {
+		price := &prices[index]
+
+		if price.MinWeight > weight {
+			continue
+		}
+
+		if price.Grade != nil && (grade == nil || *price.Grade != *grade) {
+			continue
+		}
+
+		if resolved == nil {
+			resolved = price
+
+			continue
+		}
+
+		graded := price.Grade != nil
+		resolvedGraded := resolved.Grade != nil
+
+		if graded != resolvedGraded {
+			if graded {
+				resolved = price
+			}
+
+			continue
+		}
+
+		if price.MinWeight > resolved.MinWeight {
+			resolved = price
+		}
+	}
+
+	if resolved == nil {
+		return nil, ErrNoPrice
+	}
+
+	return resolved, nil
+}
+
+func (p *pricing) Grade(businessId uuid.UUID, collectorId uuid.UUID) (*string, error) {
+	var collectorGrade models.CollectorGrade
+
+	if err := p.storage.Database().
+		Where("business_id = ? AND collector_id = ?", businessId, collectorId).
+		First(&collectorGrade).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &collectorGrade.Grade, nil
+}
+
+func (p *pricing) Value(weight float64, pricePerKg decimal.Decimal) decimal.Decimal {
+	return models.Cents(decimal.NewFromFloat(weight).Mul(pricePerKg))
+}
+
+func (p *pricing) ValueLine(collection *models.Collection, line *models.CollectionMaterial, materialId uuid.UUID, value *decimal.Decimal, userId uuid.UUID) error {
+	grade, err := p.Grade(collection.BuyerId, collection.SellerId)
+
+	if err != nil {
+		return err
+	}
+
+	price, err := p.Resolve(collection.BuyerId, materialId, grade, line.Weight, collection.CreatedAt)
+
+	if err != nil && !errors.Is(err, ErrNoPrice) {
+		return err
+	}
+
+	if price == nil && value == nil {
+		return ErrNoPrice
+	}
+
+	if price != nil {
+		computed := p.Value(line.Weight, price.PricePerKg)
+
+		line.PriceId = &price.Id
+		line.PricePerKg = &price.PricePerKg
+		line.ComputedValue = &computed
+		line.Value = computed
+	}
+
+	if value != nil && (line.ComputedValue == nil || !value.Equal(*line.ComputedValue)) {
+		line.Value = *value
+		line.ValueOverridden = true
+		line.OverriddenById = &userId
+	}
+
+	return nil
+}
+
+// prices keeps, for every tier in query, the latest version that took effect
+// at or before at, and drops tiers that were retired by then.
+func (p *pricing) prices(query *gorm.DB, at time.Time) ([]models.MaterialPrice, error) {
+	versions := []models.MaterialPrice{}
+
+	if err := query.
+		Preload("Material").
+		Where("effective_from <= ?", at).
+		Order("effective_from DESC, created_at DESC").
+		Find(&versions).Error; err != nil {
+		return nil, err
+	}
+
+	type tier struct {
+		materialId uuid.UUID
+		minWeight  float64
+		grade      string
+		graded     bool
+	}
+
+	seen := map[tier]bool{}
+	prices := []models.MaterialPrice{}
+
+	for _, version := range versions {
+		key := tier{
+			materialId: version.MaterialId,
+			minWeight:  version.MinWeight,
+			graded:     version.Grade != nil,
+		}
+
+		if version.Grade != nil {
+			key.grade = *version.Grade
+		}
+
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		if version.EffectiveTo != nil && !version.EffectiveTo.After(at) {
+			continue
+		}
+
+		prices = append(prices, version)
+	}
+
+	return prices, nil
+}
@@ -0,0 +1,285 @@
+package principals
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Principal is everything authentication needs to know about a user: the user
+// with their global roles and businesses, and their roles in each business.
+type Principal struct {
+	User          models.User
+	BusinessRoles map[uuid.UUID][]models.Role
+}
+
+// invalidatingTables are the tables whose writes can change what a principal
+// may do.
+var invalidatingTables = []string{
+	"users",
+	"roles",
+	"users_roles",
+	"businesses",
+	"businesses_users",
+	"businesses_users_roles",
+}
+
+type Principals interface {
+	// Resolve returns a copy of the user's principal that the caller is free to
+	// modify. It returns gorm.ErrRecordNotFound when the user doesn't exist.
+	Resolve(userId uuid.UUID) (*Principal, error)
+	Invalidate(userIds ...uuid.UUID)
+	InvalidateAll()
+}
+
+type principals struct {
+	storage storage.Storage
+	store   Store
+	ttl     time.Duration
+}
+
+// New caches principals in store for APP_PRINCIPAL_CACHE_TTL. Entries are
+// invalidated as soon as a write to a user, role, business or membership
+// through gorm commits, including Exec statements naming those tables. Writes
+// gorm doesn't see, from another instance sharing the database or straight to
+// postgres, are only picked up once the ttl expires. A ttl of zero disables
+// caching.
+func New(storage storage.Storage, store Store) Principals {
+	p := &principals{
+		storage: storage,
+		store:   store,
+		ttl:     common.EnvDuration("APP_PRINCIPAL_CACHE_TTL", 30*time.Second),
+	}
+
+	p.registerCallbacks()
+
+	return p
+}
+
+// NewMemory caches up to APP_PRINCIPAL_CACHE_SIZE principals in process.
+func NewMemory(storage storage.Storage) Principals {
+	return New(storage, NewMemoryStore(common.EnvInt("APP_PRINCIPAL_CACHE_SIZE", 10000)))
+}
+
+func (p *principals) Resolve(userId uuid.UUID) (*Principal, error) {
+	if p.ttl > 0 {
+		if principal, ok := p.store.Get(userId); ok {
+			return principal.clone(), nil
+		}
+	}
+
+	principal, err := p.load(userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if p.ttl > 0 {
+		p.store.Set(userId, principal, p.ttl)
+	}
+
+	return principal.clone(), nil
+}
+
+func (p *principals) Invalidate(userIds ...uuid.UUID) {
+	p.store.Delete(userIds...)
+}
+
+func (p *principals) InvalidateAll() {
+	p.store.Clear()
+}
+
+func (p *principals) load(userId uuid.UUID) (*Principal, error) {
+	var user models.User
+
+	if err := p.storage.Database().
+		Where("id = ?", userId).
+		Preload("Roles").
+		Preload("Businesses").
+		First(&user).Error; err != nil {
+		return nil, err
+	}
+
+	var membershipRoles []models.MembershipRole
+
+	if err := p.storage.Database().
+		Preload("Role").
+		Where("user_id = ?", userId).
+		Find(&membershipRoles).Error; err != nil {
+		return nil, err
+	}
+
+	principal := Principal{
+		User:          user,
+		BusinessRoles: map[uuid.UUID][]models.Role{},
+	}
+
+	for _, membershipRole := range membershipRoles {
+		principal.BusinessRoles[membershipRole.BusinessId] = append(principal.BusinessRoles[membershipRole.BusinessId], membershipRole.Role)
+	}
+
+	return &principal, nil
+}
+
+func (p *Principal) clone() *Principal {
+	clone := Principal{
+		User:          p.User,
+		BusinessRoles: map[uuid.UUID][]models.Role{},
+	}
+
+	clone.User.Roles = slices.Clone(p.User.Roles)
+	clone.User.Businesses = slices.Clone(p.User.Businesses)
+	clone.User.Permissions = slices.Clone(p.User.Permissions)
+
+	for businessId, roles := range p.BusinessRoles {
+		clone.BusinessRoles[businessId] = slices.Clone(roles)
+	}
+
+	return &clone
+}
+
+// registerCallbacks invalidates principals after every successful write to a
+// table that affects them. Writes that identify the users involved only drop
+// those users, anything else, such as a role change, drops every principal.
+// Writes in a transaction are only invalidated once it commits, so a request
+// resolving the principal in between can't cache what is about to change.
+func (p *principals) registerCallbacks() {
+	if p.storage.Database() == nil {
+		return
+	}
+
+	invalidate := func(tx *gorm.DB) {
+		if tx.Error != nil || tx.Statement == nil || !slices.Contains(invalidatingTables, tx.Statement.Table) {
+			return
+		}
+
+		field := "UserId"
+
+		if tx.Statement.Table == "users" {
+			field = "Id"
+		}
+
+		if userIds := affectedUsers(tx.Statement.ReflectValue, field); len(userIds) > 0 {
+			storage.AfterCommit(tx, func() {
+				p.Invalidate(userIds...)
+			})
+
+			return
+		}
+
+		storage.AfterCommit(tx, p.InvalidateAll)
+	}
+
+	callbacks := p.storage.Database().Callback()
+
+	if err := callbacks.Create().After("gorm:create").Register("principals:invalidate", invalidate); err != nil {
+		log.Errorf("🔥 Failed to register principal cache invalidation: %s", err.Error())
+	}
+
+	if err := callbacks.Update().After("gorm:update").Register("principals:invalidate", invalidate); err != nil {
+		log.Errorf("🔥 Failed to register principal cache invalidation: %s", err.Error())
+	}
+
+	if err := callbacks.Delete().After("gorm:delete").Register("principals:invalidate", invalidate); err != nil {
+		log.Errorf("🔥 Failed to register principal cache invalidation: %s", err.Error())
+	}
+
+	// Exec statements carry no model, so any statement that names one of the
+	// tables drops every principal.
+	invalidateRaw := func(tx *gorm.DB) {
+		if tx.Error != nil || tx.Statement == nil || !mentionsTables(tx.Statement.SQL.String()) {
+			return
+		}
+
+		storage.AfterCommit(tx, p.InvalidateAll)
+	}
+
+	if err := callbacks.Raw().After("gorm:raw").Register("principals:invalidate", invalidateRaw); err != nil {
+		log.Errorf("🔥 Failed to register principal cache invalidation: %s", err.Error())
+	}
+}
+
+// mentionsTables reports whether the sql names any of the invalidating tables
+// as a whole identifier, so "users" doesn't match "users_roles".
+func mentionsTables(sql string) bool {
+	identifiers := strings.FieldsFunc(strings.ToLower(sql), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	})
+
+	for _, identifier := range identifiers {
+		if slices.Contains(invalidatingTables, identifier) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// affectedUsers collects the user ids from the written records. It returns
+// nothing when any record doesn't carry a user id, for example deletes by
+// condition, so that the caller falls back to invalidating everything.
+func affectedUsers(value reflect.Value, field string) []uuid.UUID {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	records := []reflect.Value{}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		records = append(records, value)
+	case reflect.Slice, reflect.Array:
+		for index := 0; index < value.Len(); index++ {
+			record := value.Index(index)
+
+			for record.Kind() == reflect.Pointer || record.Kind() == reflect.Interface {
+				if record.IsNil() {
+					return nil
+				}
+
+				record = record.Elem()
+			}
+
+			if record.Kind() != reflect.Struct {
+				return nil
+			}
+
+			records = append(records, record)
+		}
+	default:
+		return nil
+	}
+
+	userIds := []uuid.UUID{}
+
+	for _, record := range records {
+		fieldValue := record.FieldByName(field)
+
+		if !fieldValue.IsValid() {
+			return nil
+		}
+
+		userId, ok := fieldValue.Interface().(uuid.UUID)
+
+		if !ok || userId == uuid.Nil {
+			return nil
+		}
+
+		userIds = append(userIds, userId)
+	}
+
+	return userIds
+}
@@ -0,0 +1,187 @@
+package principals
+
+import (
+	"testing"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/connor-davis/threereco-nextgen/internal/testdb"
+	"github.com/google/uuid"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryStorage runs gorm without a database: statements are built and the
+// callbacks run, but nothing is sent to postgres.
+type dryStorage struct {
+	db *gorm.DB
+}
+
+func (s *dryStorage) Database() *gorm.DB         { return s.db }
+func (s *dryStorage) Migrate() error             { return nil }
+func (s *dryStorage) SeedAdmin() error           { return nil }
+func (s *dryStorage) SeedDefaultBusiness() error { return nil }
+
+func newDryStorage(t *testing.T) storage.Storage {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &dryStorage{db: db}
+}
+
+func TestInvalidation(t *testing.T) {
+	first := uuid.New()
+	second := uuid.New()
+
+	tests := []struct {
+		name   string
+		write  func(db *gorm.DB) error
+		cached []uuid.UUID
+	}{
+		{
+			name: "membership role of one user",
+			write: func(db *gorm.DB) error {
+				return db.Create(&models.MembershipRole{UserId: first, BusinessId: uuid.New(), RoleId: uuid.New()}).Error
+			},
+			cached: []uuid.UUID{second},
+		},
+		{
+			name: "user update",
+			write: func(db *gorm.DB) error {
+				return db.Model(&models.User{Base: models.Base{Id: second}}).Update("name", "Renamed").Error
+			},
+			cached: []uuid.UUID{first},
+		},
+		{
+			name: "delete by condition",
+			write: func(db *gorm.DB) error {
+				return db.Where("role_id = ?", uuid.New()).Delete(&models.MembershipRole{}).Error
+			},
+			cached: []uuid.UUID{},
+		},
+		{
+			name: "role update",
+			write: func(db *gorm.DB) error {
+				return db.Model(&models.Role{Base: models.Base{Id: uuid.New()}}).Update("name", "Renamed").Error
+			},
+			cached: []uuid.UUID{},
+		},
+		{
+			name: "unrelated table",
+			write: func(db *gorm.DB) error {
+				return db.Model(&models.Material{Base: models.Base{Id: uuid.New()}}).Update("name", "Renamed").Error
+			},
+			cached: []uuid.UUID{first, second},
+		},
+		{
+			name: "exec on a membership table",
+			write: func(db *gorm.DB) error {
+				return db.Exec("DELETE FROM businesses_users_roles WHERE role_id = ?", uuid.New()).Error
+			},
+			cached: []uuid.UUID{},
+		},
+		{
+			name: "exec on a quoted users table",
+			write: func(db *gorm.DB) error {
+				return db.Exec(`UPDATE "users" SET name = ? WHERE id = ?`, "Renamed", first).Error
+			},
+			cached: []uuid.UUID{},
+		},
+		{
+			name: "exec on an unrelated table",
+			write: func(db *gorm.DB) error {
+				return db.Exec("UPDATE user_sessions SET last_seen_at = now()").Error
+			},
+			cached: []uuid.UUID{first, second},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			store := NewMemoryStore(10)
+			storage := newDryStorage(t)
+
+			New(storage, store)
+
+			for _, userId := range []uuid.UUID{first, second} {
+				store.Set(userId, &Principal{User: models.User{Base: models.Base{Id: userId}}}, time.Minute)
+			}
+
+			if err := test.write(storage.Database()); err != nil {
+				t.Fatal(err)
+			}
+
+			for _, userId := range []uuid.UUID{first, second} {
+				_, ok := store.Get(userId)
+				want := false
+
+				for _, cached := range test.cached {
+					want = want || cached == userId
+				}
+
+				if ok != want {
+					t.Errorf("user %s cached %t, want %t", userId, ok, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMentionsTables(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want bool
+	}{
+		{sql: "UPDATE users SET name = 'x'", want: true},
+		{sql: `INSERT INTO "users_roles" (user_id, role_id) VALUES ($1, $2)`, want: true},
+		{sql: "DELETE FROM businesses_users WHERE user_id = $1", want: true},
+		{sql: "UPDATE user_sessions SET last_seen_at = now()", want: false},
+		{sql: "UPDATE users_archive SET name = 'x'", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.sql, func(t *testing.T) {
+			if got := mentionsTables(test.sql); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func benchmarkResolve(b *testing.B, ttl time.Duration) {
+	store := testdb.Open(b)
+	user := testdb.User(b, store, models.BusinessUser)
+	testdb.Business(b, store, user)
+
+	p := &principals{
+		storage: store,
+		store:   NewMemoryStore(10),
+		ttl:     ttl,
+	}
+
+	b.ResetTimer()
+
+	for range b.N {
+		if _, err := p.Resolve(user.Id); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkResolveCached(b *testing.B) {
+	benchmarkResolve(b, time.Minute)
+}
+
+func BenchmarkResolveUncached(b *testing.B) {
+	benchmarkResolve(b, 0)
+}
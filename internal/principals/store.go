@@ -0,0 +1,105 @@
+package principals
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Store keeps resolved principals between requests. The in-process store is
+// the default; a shared store can implement the same interface so that every
+// instance sees the same entries and invalidations.
+type Store interface {
+	Get(userId uuid.UUID) (*Principal, bool)
+	Set(userId uuid.UUID, principal *Principal, ttl time.Duration)
+	Delete(userIds ...uuid.UUID)
+	Clear()
+}
+
+type memoryEntry struct {
+	principal *Principal
+	expiresAt time.Time
+}
+
+type memoryStore struct {
+	mutex   sync.RWMutex
+	entries map[uuid.UUID]memoryEntry
+	size    int
+}
+
+// NewMemoryStore keeps up to size principals in process memory.
+func NewMemoryStore(size int) Store {
+	return &memoryStore{
+		entries: map[uuid.UUID]memoryEntry{},
+		size:    size,
+	}
+}
+
+func (s *memoryStore) Get(userId uuid.UUID) (*Principal, bool) {
+	s.mutex.RLock()
+	entry, ok := s.entries[userId]
+	s.mutex.RUnlock()
+
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, false
+	}
+
+	return entry.principal, true
+}
+
+func (s *memoryStore) Set(userId uuid.UUID, principal *Principal, ttl time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.entries[userId]; !ok && s.size > 0 && len(s.entries) >= s.size {
+		s.evict()
+	}
+
+	s.entries[userId] = memoryEntry{
+		principal: principal,
+		expiresAt: time.Now().Add(ttl),
+	}
+}
+
+func (s *memoryStore) Delete(userIds ...uuid.UUID) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, userId := range userIds {
+		delete(s.entries, userId)
+	}
+}
+
+func (s *memoryStore) Clear() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.entries = map[uuid.UUID]memoryEntry{}
+}
+
+// evict drops expired entries, or the entry closest to expiring when none
+// have expired yet.
+func (s *memoryStore) evict() {
+	now := time.Now()
+
+	var oldestId uuid.UUID
+	var oldest time.Time
+
+	for userId, entry := range s.entries {
+		if now.After(entry.expiresAt) {
+			delete(s.entries, userId)
+
+			continue
+		}
+
+		if oldest.IsZero() || entry.expiresAt.Before(oldest) {
+			oldestId = userId
+			oldest = entry.expiresAt
+		}
+	}
+
+	if len(s.entries) >= s.size {
+		delete(s.entries, oldestId)
+	}
+}
@@ -0,0 +1,495 @@
+package registrations
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/notifications"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/go-openapi/inflect"
+	"github.com/gofiber/fiber/v2/log"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidUserType  = errors.New("only collector and business accounts can be registered")
+	ErrInvalidCode      = errors.New("the verification code is invalid or has expired")
+	ErrUnverified       = errors.New("the account has not been verified")
+	ErrPendingApproval  = errors.New("the account is waiting for approval")
+	ErrRejected         = errors.New("the account registration was rejected")
+	ErrNotPending       = errors.New("the registration is not pending approval")
+	ErrAlreadyVerified  = errors.New("the account is already verified")
+	ErrResendTooSoon    = errors.New("a verification code was sent recently")
+	ErrNoSender         = errors.New("registration verification is enabled but APP_SMTP_HOST isn't set, so verification codes can't be sent; configure SMTP or set APP_REGISTRATION_VERIFICATION=false")
+	maxAttempts         = 5
+	resendInterval      = 1 * time.Minute
+	verificationCodeLen = 6
+)
+
+type Registrations interface {
+	Register(user *models.User) error
+	Verify(username string, code string) (*models.User, error)
+	ResendVerification(username string) error
+	Approve(user *models.User) error
+	Reject(user *models.User, reason string) error
+	Active(user *models.User) error
+}
+
+type registrations struct {
+	storage       storage.Storage
+	notifications notifications.Notifications
+	approval      string
+	verification  bool
+	codeLifetime  time.Duration
+}
+
+// New fails when verification is enabled without a way to send the codes,
+// rather than accepting registrations nobody can verify.
+func New(storage storage.Storage, notifications notifications.Notifications) (Registrations, error) {
+	r := &registrations{
+		storage:       storage,
+		notifications: notifications,
+		approval:      common.EnvString("APP_REGISTRATION_APPROVAL", "business"),
+		verification:  common.EnvBool("APP_REGISTRATION_VERIFICATION", true),
+		codeLifetime:  common.EnvDuration("APP_REGISTRATION_CODE_LIFETIME", 15*time.Minute),
+	}
+
+	if r.verification && !notifications.Configured() {
+		return nil, ErrNoSender
+	}
+
+	return r, nil
+}
+
+func (r *registrations) requiresApproval(userType models.UserType) bool {
+	switch r.approval {
+	case "all":
+		return true
+	case "business":
+		return userType == models.BusinessUser
+	default:
+		return false
+	}
+}
+
+// Register creates a self-registered account. Accounts start unverified when
+// verification is enabled and wait in the approval queue when their type
+// requires approval. Businesses are only provisioned once the account is
+// approved.
+func (r *registrations) Register(user *models.User) error {
+	if user.Type == "" {
+		user.Type = models.CollectorUser
+	}
+
+	if user.Type != models.CollectorUser && user.Type != models.BusinessUser {
+		return ErrInvalidUserType
+	}
+
+	// Verification codes can only be sent by email, so phone numbers can only
+	// register when verification is off.
+	if r.verification {
+		if err := r.notifications.Check(user.Username); err != nil {
+			return err
+		}
+	}
+
+	approvalStatus := models.ApprovedApproval
+
+	if r.requiresApproval(user.Type) {
+		approvalStatus = models.PendingApproval
+	}
+
+	err := r.storage.Database().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+
+		user.Verified = !r.verification
+		user.ApprovalStatus = approvalStatus
+
+		if err := tx.Model(user).Updates(map[string]any{
+			"verified":        user.Verified,
+			"approval_status": user.ApprovalStatus,
+		}).Error; err != nil {
+			return err
+		}
+
+		if user.ApprovalStatus == models.ApprovedApproval && user.Type == models.BusinessUser {
+			return provisionBusiness(tx, user)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if !user.Verified {
+		return r.sendCode(user)
+	}
+
+	return nil
+}
+
+func hashCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func newCode() (string, error) {
+	max := big.NewInt(1)
+
+	for range verificationCodeLen {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	number, err := rand.Int(rand.Reader, max)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", verificationCodeLen, number.Int64()), nil
+}
+
+func (r *registrations) sendCode(user *models.User) error {
+	code, err := newCode()
+
+	if err != nil {
+		return err
+	}
+
+	if err := r.storage.Database().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND consumed_at IS NULL", user.Id).
+			Delete(&models.Verification{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Create(&models.Verification{
+			UserId:    user.Id,
+			Recipient: user.Username,
+			CodeHash:  hashCode(code),
+			ExpiresAt: time.Now().Add(r.codeLifetime),
+		}).Error
+	}); err != nil {
+		return err
+	}
+
+	return r.notifications.Send(notifications.Message{
+		Type:    notifications.VerificationMessage,
+		To:      user.Username,
+		Subject: "Verify your 3rEco account",
+		Body: fmt.Sprintf(
+			"Your verification code is %s. It expires in %d minutes.",
+			code,
+			int(r.codeLifetime.Minutes()),
+		),
+	})
+}
+
+func (r *registrations) Verify(username string, code string) (*models.User, error) {
+	var user models.User
+
+	if err := r.storage.Database().Where("username = ?", username).First(&user).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrInvalidCode
+		}
+
+		return nil, err
+	}
+
+	if user.Verified {
+		return nil, ErrAlreadyVerified
+	}
+
+	var verification models.Verification
+
+	if err := r.storage.Database().
+		Where("user_id = ? AND consumed_at IS NULL", user.Id).
+		Order("created_at DESC").
+		First(&verification).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrInvalidCode
+		}
+
+		return nil, err
+	}
+
+	if verification.Attempts >= maxAttempts || !verification.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidCode
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashCode(code)), []byte(verification.CodeHash)) != 1 {
+		if err := r.storage.Database().
+			Model(&verification).
+			Update("attempts", gorm.Expr("attempts + 1")).Error; err != nil {
+			return nil, err
+		}
+
+		return nil, ErrInvalidCode
+	}
+
+	if err := r.storage.Database().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&verification).Update("consumed_at", time.Now()).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&user).Update("verified", true).Error
+	}); err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *registrations) ResendVerification(username string) error {
+	if err := r.notifications.Check(username); err != nil {
+		return err
+	}
+
+	var user models.User
+
+	if err := r.storage.Database().Where("username = ?", username).First(&user).Error; err != nil {
+		return err
+	}
+
+	if user.Verified {
+		return ErrAlreadyVerified
+	}
+
+	var latest models.Verification
+
+	err := r.storage.Database().
+		Where("user_id = ?", user.Id).
+		Order("created_at DESC").
+		First(&latest).Error
+
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return err
+	}
+
+	if err == nil && time.Since(latest.CreatedAt) < resendInterval {
+		return ErrResendTooSoon
+	}
+
+	return r.sendCode(&user)
+}
+
+func (r *registrations) Approve(user *models.User) error {
+	if user.ApprovalStatus != models.PendingApproval {
+		return ErrNotPending
+	}
+
+	if err := r.storage.Database().Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(user).Update("approval_status", models.ApprovedApproval).Error; err != nil {
+			return err
+		}
+
+		if user.Type == models.BusinessUser && user.BusinessId == nil {
+			return provisionBusiness(tx, user)
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := r.notifications.Send(notifications.Message{
+		Type:    notifications.ApprovalMessage,
+		To:      user.Username,
+		Subject: "Your 3rEco account has been approved",
+		Body:    "Your account has been approved. You can now log in.",
+	}); err != nil {
+		log.Warnf("⚠️ Failed to notify %s about their registration: %s", user.Username, err.Error())
+	}
+
+	return nil
+}
+
+func (r *registrations) Reject(user *models.User, reason string) error {
+	if user.ApprovalStatus != models.PendingApproval {
+		return ErrNotPending
+	}
+
+	if err := r.storage.Database().
+		Model(user).
+		Update("approval_status", models.RejectedApproval).Error; err != nil {
+		return err
+	}
+
+	body := "Your account registration has been rejected."
+
+	if reason != "" {
+		body = fmt.Sprintf("%s\n\nReason: %s", body, reason)
+	}
+
+	if err := r.notifications.Send(notifications.Message{
+		Type:    notifications.RejectionMessage,
+		To:      user.Username,
+		Subject: "Your 3rEco account registration",
+		Body:    body,
+	}); err != nil {
+		log.Warnf("⚠️ Failed to notify %s about their registration: %s", user.Username, err.Error())
+	}
+
+	return nil
+}
+
+func (r *registrations) Active(user *models.User) error {
+	if !user.Verified {
+		return ErrUnverified
+	}
+
+	switch user.ApprovalStatus {
+	case models.PendingApproval:
+		return ErrPendingApproval
+	case models.RejectedApproval:
+		return ErrRejected
+	}
+
+	return nil
+}
+
+// provisionBusiness creates a business owned by the user and makes them its
+// owner.
+func provisionBusiness(tx *gorm.DB, user *models.User) error {
+	businessOwnerRoleName := "Business Owner"
+	businessOwnerRoleDescription := "Owner of the business with full access to business resources."
+
+	businessStaffRoleName := "Business Staff"
+	businessStaffRoleDescription := "Staff member of the business with limited access to business resources."
+
+	businessUserRoleName := "Business User"
+	businessUserRoleDescription := "User of the business with minimal access to business resources."
+
+	businessOwnerRole := models.Role{
+		Name:        businessOwnerRoleName,
+		Description: &businessOwnerRoleDescription,
+		Permissions: []string{
+			"materials.view",
+			"carbon.view",
+			"collections.*",
+			"transactions.*",
+			"users.view.self",
+			"users.update.self",
+			"users.delete.self",
+			"businesses.view",
+			"businesses.update.self",
+			"businesses.delete.self",
+			"businesses.roles.assign",
+			"businesses.roles.unassign",
+			"businesses.roles.view",
+			"businesses.roles.create",
+			"businesses.roles.update",
+			"businesses.roles.delete",
+			"businesses.prices.*",
+			"businesses.stock.*",
+			"businesses.payouts.*",
+			"businesses.vat.*",
+			"businesses.users.assign",
+			"businesses.users.unassign",
+			"businesses.users.view",
+			"businesses.serviceaccounts.*",
+			"businesses.invitations.*",
+			"tokens.*",
+		},
+		Default: false,
+	}
+
+	businessStaffRole := models.Role{
+		Name:        businessStaffRoleName,
+		Description: &businessStaffRoleDescription,
+		Permissions: []string{
+			"materials.view",
+			"carbon.view",
+			"collections.view",
+			"collections.receipts.view",
+			"collections.create",
+			"collections.update",
+			"collections.status.submit",
+			"collections.status.weigh",
+			"collections.status.dispute",
+			"transactions.view",
+			"transactions.create",
+			"transactions.update",
+			"transactions.status.submit",
+			"transactions.status.weigh",
+			"transactions.status.dispute",
+			"users.view.self",
+			"users.update.self",
+			"users.delete.self",
+			"businesses.view",
+			"businesses.users.view",
+			"businesses.prices.view",
+			"businesses.stock.view",
+		},
+		Default: false,
+	}
+
+	businessUserRole := models.Role{
+		Name:        businessUserRoleName,
+		Description: &businessUserRoleDescription,
+		Permissions: []string{
+			"materials.view",
+			"carbon.view",
+			"collections.view",
+			"collections.receipts.view",
+			"collections.status.dispute",
+			"transactions.view",
+			"users.view.self",
+			"users.update.self",
+			"users.delete.self",
+			"businesses.view",
+		},
+		Default: false,
+	}
+
+	if err := tx.Where("name = ? AND business_id IS NULL", businessOwnerRoleName).FirstOrCreate(&businessOwnerRole).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("name = ? AND business_id IS NULL", businessStaffRoleName).FirstOrCreate(&businessStaffRole).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("name = ? AND business_id IS NULL", businessUserRoleName).FirstOrCreate(&businessUserRole).Error; err != nil {
+		return err
+	}
+
+	business := models.Business{
+		Name:    fmt.Sprintf("%s Business", inflect.Pluralize(user.Name)),
+		OwnerId: user.Id,
+	}
+
+	if err := tx.Create(&business).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Model(user).Association("Businesses").Append(&business); err != nil {
+		return err
+	}
+
+	membershipRole := models.MembershipRole{
+		BusinessId: business.Id,
+		UserId:     user.Id,
+		RoleId:     businessOwnerRole.Id,
+	}
+
+	if err := tx.Create(&membershipRole).Error; err != nil {
+		return err
+	}
+
+	user.BusinessId = &business.Id
+
+	return tx.Model(user).Update("business_id", business.Id).Error
+}
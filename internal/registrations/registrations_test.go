@@ -0,0 +1,56 @@
+package registrations
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/notifications"
+)
+
+type stubNotifications struct {
+	configured bool
+}
+
+func (n *stubNotifications) Configured() bool {
+	return n.configured
+}
+
+func (n *stubNotifications) Check(recipient string) error {
+	if !n.configured {
+		return notifications.ErrNotConfigured
+	}
+
+	return nil
+}
+
+func (n *stubNotifications) Send(message notifications.Message) error {
+	return n.Check(message.To)
+}
+
+func TestNewRequiresSender(t *testing.T) {
+	t.Setenv("APP_REGISTRATION_VERIFICATION", "true")
+
+	if _, err := New(nil, &stubNotifications{}); !errors.Is(err, ErrNoSender) {
+		t.Errorf("got %v, want %v", err, ErrNoSender)
+	}
+
+	t.Setenv("APP_REGISTRATION_VERIFICATION", "false")
+
+	if _, err := New(nil, &stubNotifications{}); err != nil {
+		t.Errorf("expected registrations without verification to start, got %v", err)
+	}
+}
+
+func TestRegisterRejectsUndeliverableUsernames(t *testing.T) {
+	r := &registrations{
+		notifications: notifications.New(),
+		verification:  true,
+	}
+
+	user := models.User{Username: "+27821234567", Type: models.CollectorUser}
+
+	if err := r.Register(&user); !errors.Is(err, notifications.ErrUnsupportedRecipient) {
+		t.Errorf("got %v, want %v", err, notifications.ErrUnsupportedRecipient)
+	}
+}
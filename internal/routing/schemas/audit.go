@@ -0,0 +1,130 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var AuditLogSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"action": {
+				Value: openapi3.NewStringSchema().WithEnum("impersonation.started", "impersonation.stopped", "impersonation.request", "impersonation.blocked"),
+			},
+			"actorId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"userId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"impersonationId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"method": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"path": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"status": {
+				Value: openapi3.NewIntegerSchema(),
+			},
+			"ip": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"details": {
+				Value: openapi3.NewStringSchema().WithNullable(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"action",
+			"actorId",
+			"userId",
+			"impersonationId",
+			"method",
+			"path",
+			"status",
+			"ip",
+			"details",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var AuditLogsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/AuditLog",
+		},
+	},
+}
+
+var ImpersonationSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"actorId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"userId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"reason": {
+				Value: openapi3.NewStringSchema().WithNullable(),
+			},
+			"expiresAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"endedAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"actorId",
+			"userId",
+			"reason",
+			"expiresAt",
+			"endedAt",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var StartImpersonationPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Start impersonation payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"reason": {
+							Value: openapi3.NewStringSchema(),
+						},
+					},
+				}),
+		},
+		Required: false,
+	},
+}
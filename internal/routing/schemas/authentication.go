@@ -51,14 +51,13 @@ var RegisterPayloadSchema = &openapi3.RequestBodyRef{
 								WithMinLength(8),
 						},
 						"type": {
-							Value: openapi3.NewStringSchema().WithEnum("business", "collector"),
+							Value: openapi3.NewStringSchema().WithEnum("business", "collector").WithDefault("collector"),
 						},
 					},
 					Required: []string{
 						"name",
 						"username",
 						"password",
-						"type",
 					},
 				}),
 		},
@@ -86,3 +85,115 @@ var VerifyMfaPayloadSchema = &openapi3.RequestBodyRef{
 		Required: true,
 	},
 }
+
+var ChangePasswordPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Change password payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"currentPassword": {
+							Value: openapi3.NewStringSchema(),
+						},
+						"newPassword": {
+							Value: openapi3.NewStringSchema().
+								WithMinLength(8),
+						},
+					},
+					Required: []string{
+						"currentPassword",
+						"newPassword",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var SetPasswordPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Set password payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"password": {
+							Value: openapi3.NewStringSchema().
+								WithMinLength(8),
+						},
+					},
+					Required: []string{
+						"password",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var VerifyAccountPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Verify account payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"username": {
+							Value: openapi3.NewStringSchema(),
+						},
+						"code": {
+							Value: openapi3.NewStringSchema().WithMinLength(6).WithMaxLength(6),
+						},
+					},
+					Required: []string{
+						"username",
+						"code",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var ResendVerificationPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Resend verification payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"username": {
+							Value: openapi3.NewStringSchema(),
+						},
+					},
+					Required: []string{
+						"username",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var RejectRegistrationPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Reject registration payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"reason": {
+							Value: openapi3.NewStringSchema(),
+						},
+					},
+				}),
+		},
+		Required: false,
+	},
+}
@@ -21,6 +21,18 @@ var BusinessSchema = &openapi3.SchemaRef{
 			"bankDetails": {
 				Ref: "#/components/schemas/BankDetails",
 			},
+			"currency": {
+				Value: openapi3.NewStringSchema().WithMinLength(3).WithMaxLength(3),
+			},
+			"vatRegistered": {
+				Value: openapi3.NewBoolSchema(),
+			},
+			"vatNumber": {
+				Value: openapi3.NewStringSchema().WithNullable(),
+			},
+			"vatRate": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
 			"roles": {
 				Ref: "#/components/schemas/Roles",
 			},
@@ -136,3 +148,62 @@ var UpdateBusinessSchema = &openapi3.SchemaRef{
 		},
 	},
 }
+
+var VatSettingsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"currency": {
+				Value: openapi3.NewStringSchema().WithMinLength(3).WithMaxLength(3),
+			},
+			"vatRegistered": {
+				Value: openapi3.NewBoolSchema(),
+			},
+			"vatNumber": {
+				Value: openapi3.NewStringSchema().WithNullable(),
+			},
+			"vatRate": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+		},
+		Description: "The business's currency and VAT settings. Transactions the business sells in charge VAT at vatRate while it is registered for VAT, and nothing otherwise.",
+		Required: []string{
+			"currency",
+			"vatRegistered",
+			"vatRate",
+		},
+	},
+}
+
+var VatSettingsPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "VAT settings payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"currency": {
+							Value: openapi3.NewStringSchema().WithMinLength(3).WithMaxLength(3),
+						},
+						"vatRegistered": {
+							Value: openapi3.NewBoolSchema(),
+						},
+						"vatNumber": {
+							Value: openapi3.NewStringSchema().WithNullable(),
+						},
+						"vatRate": {
+							Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+						},
+					},
+					Description: "A business that is registered for VAT needs a VAT number. The rate is a percentage with at most two decimals.",
+					Required: []string{
+						"currency",
+						"vatRegistered",
+						"vatRate",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
@@ -0,0 +1,83 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var CarbonSavingsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"weight": {
+				Value: openapi3.NewFloat64Schema(),
+			},
+			"co2e": {
+				Value: openapi3.NewFloat64Schema(),
+			},
+			"unit": {
+				Value: openapi3.NewStringSchema().WithEnum("kgCO2e"),
+			},
+			"count": {
+				Value: openapi3.NewIntegerSchema(),
+			},
+			"materials": {
+				Value: openapi3.NewArraySchema().WithItems(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"materialId": {
+							Value: openapi3.NewUUIDSchema().WithNullable(),
+						},
+						"name": {
+							Value: openapi3.NewStringSchema(),
+						},
+						"gwCode": {
+							Value: openapi3.NewStringSchema(),
+						},
+						"weight": {
+							Value: openapi3.NewFloat64Schema(),
+						},
+						"co2e": {
+							Value: openapi3.NewFloat64Schema(),
+						},
+					},
+					Required: []string{
+						"name",
+						"gwCode",
+						"weight",
+						"co2e",
+					},
+				}),
+			},
+			"periods": {
+				Value: openapi3.NewArraySchema().WithItems(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"start": {
+							Value: openapi3.NewDateTimeSchema(),
+						},
+						"weight": {
+							Value: openapi3.NewFloat64Schema(),
+						},
+						"co2e": {
+							Value: openapi3.NewFloat64Schema(),
+						},
+						"count": {
+							Value: openapi3.NewIntegerSchema(),
+						},
+					},
+					Required: []string{
+						"start",
+						"weight",
+						"co2e",
+						"count",
+					},
+				}),
+			},
+		},
+		Required: []string{
+			"weight",
+			"co2e",
+			"unit",
+			"count",
+			"materials",
+		},
+	},
+}
@@ -12,6 +12,12 @@ var CollectionSchema = &openapi3.SchemaRef{
 			"materials": {
 				Ref: "#/components/schemas/CollectionMaterials",
 			},
+			"status": {
+				Ref: "#/components/schemas/Status",
+			},
+			"currency": {
+				Value: openapi3.NewStringSchema().WithMinLength(3).WithMaxLength(3),
+			},
 			"seller": {
 				Ref: "#/components/schemas/User",
 			},
@@ -100,6 +106,15 @@ var CollectionMaterialSchema = &openapi3.SchemaRef{
 			"id": {
 				Value: openapi3.NewUUIDSchema(),
 			},
+			"collectionId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"materialId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"material": {
+				Ref: "#/components/schemas/Material",
+			},
 			"name": {
 				Value: openapi3.NewStringSchema(),
 			},
@@ -107,13 +122,28 @@ var CollectionMaterialSchema = &openapi3.SchemaRef{
 				Value: openapi3.NewStringSchema(),
 			},
 			"carbonFactor": {
-				Value: openapi3.NewStringSchema(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 			"weight": {
 				Value: openapi3.NewFloat64Schema().WithMin(0),
 			},
 			"value": {
-				Value: openapi3.NewFloat64Schema().WithMin(0),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"priceId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"pricePerKg": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern).WithNullable(),
+			},
+			"computedValue": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern).WithNullable(),
+			},
+			"valueOverridden": {
+				Value: openapi3.NewBoolSchema(),
+			},
+			"overriddenById": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
 			},
 			"createdAt": {
 				Value: openapi3.NewDateTimeSchema(),
@@ -146,6 +176,9 @@ var AssignCollectionMaterialSchema = &openapi3.SchemaRef{
 	Value: &openapi3.Schema{
 		Type: openapi3.NewObjectSchema().Type,
 		Properties: map[string]*openapi3.SchemaRef{
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
 			"name": {
 				Value: openapi3.NewStringSchema(),
 			},
@@ -153,19 +186,17 @@ var AssignCollectionMaterialSchema = &openapi3.SchemaRef{
 				Value: openapi3.NewStringSchema(),
 			},
 			"carbonFactor": {
-				Value: openapi3.NewStringSchema(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 			"weight": {
 				Value: openapi3.NewFloat64Schema().WithMin(0),
 			},
 			"value": {
-				Value: openapi3.NewFloat64Schema().WithMin(0),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 		},
+		Description: "The line's name, GW code and carbon factor are copied from the catalogue material given by materialId, or else by gwCode.",
 		Required: []string{
-			"name",
-			"gwCode",
-			"carbonFactor",
 			"weight",
 			"value",
 		},
@@ -185,6 +216,9 @@ var CreateCollectionMaterialSchema = &openapi3.SchemaRef{
 	Value: &openapi3.Schema{
 		Type: openapi3.NewObjectSchema().Type,
 		Properties: map[string]*openapi3.SchemaRef{
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
 			"name": {
 				Value: openapi3.NewStringSchema(),
 			},
@@ -192,19 +226,17 @@ var CreateCollectionMaterialSchema = &openapi3.SchemaRef{
 				Value: openapi3.NewStringSchema(),
 			},
 			"carbonFactor": {
-				Value: openapi3.NewStringSchema(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 			"weight": {
 				Value: openapi3.NewFloat64Schema().WithMin(0),
 			},
 			"value": {
-				Value: openapi3.NewFloat64Schema().WithMin(0),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 		},
+		Description: "The line's name, GW code and carbon factor are copied from the catalogue material given by materialId, or else by gwCode.",
 		Required: []string{
-			"name",
-			"gwCode",
-			"carbonFactor",
 			"weight",
 			"value",
 		},
@@ -215,28 +247,100 @@ var UpdateCollectionMaterialSchema = &openapi3.SchemaRef{
 	Value: &openapi3.Schema{
 		Type: openapi3.NewObjectSchema().Type,
 		Properties: map[string]*openapi3.SchemaRef{
-			"name": {
-				Value: openapi3.NewStringSchema(),
-			},
-			"gwCode": {
-				Value: openapi3.NewStringSchema(),
-			},
-			"carbonFactor": {
-				Value: openapi3.NewStringSchema(),
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
 			},
 			"weight": {
-				Value: openapi3.NewFloat64Schema().WithMin(0).WithNullable(),
+				Value: openapi3.NewFloat64Schema().WithMin(0),
 			},
 			"value": {
-				Value: openapi3.NewFloat64Schema().WithMin(0).WithNullable(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern).WithNullable(),
 			},
 		},
+		Description: "The line's name, GW code and carbon factor are copied from the catalogue material again. Without a value the line is revalued from the buyer's price list; a value is recorded as a manual override.",
 		Required: []string{
-			"name",
-			"gwCode",
-			"carbonFactor",
+			"materialId",
 			"weight",
-			"value",
 		},
 	},
 }
+
+var CreateCollectionLinePayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Create collection line payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"materialId": {
+							Value: openapi3.NewUUIDSchema(),
+						},
+						"weight": {
+							Value: openapi3.NewFloat64Schema().WithMin(0),
+						},
+						"value": {
+							Value: openapi3.NewStringSchema().WithPattern(decimalPattern).WithNullable(),
+						},
+					},
+					Required: []string{
+						"materialId",
+						"weight",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var CreateCollectionPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Create collection payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"sellerId": {
+							Value: openapi3.NewUUIDSchema(),
+						},
+						"buyerId": {
+							Value: openapi3.NewUUIDSchema(),
+						},
+						"materials": {
+							Value: openapi3.NewArraySchema().WithMinItems(1).WithItems(&openapi3.Schema{
+								Type: openapi3.NewObjectSchema().Type,
+								Properties: map[string]*openapi3.SchemaRef{
+									"materialId": {
+										Value: openapi3.NewUUIDSchema(),
+									},
+									"weight": {
+										Value: openapi3.NewFloat64Schema().WithMin(0),
+									},
+									"value": {
+										Value: openapi3.NewStringSchema().WithPattern(decimalPattern).WithNullable(),
+									},
+								},
+								Required: []string{
+									"materialId",
+									"weight",
+								},
+							}),
+						},
+						"totalWeight": {
+							Value: openapi3.NewFloat64Schema().WithMin(0),
+						},
+						"totalValue": {
+							Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+						},
+					},
+					Required: []string{
+						"sellerId",
+						"buyerId",
+						"materials",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
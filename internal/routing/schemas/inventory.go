@@ -0,0 +1,154 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var StockMovementSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"businessId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"material": {
+				Ref: "#/components/schemas/Material",
+			},
+			"quantity": {
+				Value: openapi3.NewFloat64Schema(),
+			},
+			"type": {
+				Value: openapi3.NewStringSchema().WithEnum("collection", "transaction", "adjustment"),
+			},
+			"sourceId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"lineId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"reason": {
+				Value: openapi3.NewStringSchema().WithNullable(),
+			},
+			"actorId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"businessId",
+			"materialId",
+			"quantity",
+			"type",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var StockMovementsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/StockMovement",
+		},
+	},
+}
+
+var StockLevelSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"name": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"gwCode": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"quantity": {
+				Value: openapi3.NewFloat64Schema(),
+			},
+		},
+		Required: []string{
+			"materialId",
+			"name",
+			"gwCode",
+			"quantity",
+		},
+	},
+}
+
+var StockShortfallSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"name": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"gwCode": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"available": {
+				Value: openapi3.NewFloat64Schema(),
+			},
+			"required": {
+				Value: openapi3.NewFloat64Schema(),
+			},
+			"shortfall": {
+				Value: openapi3.NewFloat64Schema(),
+			},
+		},
+		Required: []string{
+			"materialId",
+			"name",
+			"gwCode",
+			"available",
+			"required",
+			"shortfall",
+		},
+	},
+}
+
+var StockAdjustmentPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Stock adjustment payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"materialId": {
+							Value: openapi3.NewUUIDSchema(),
+						},
+						"quantity": {
+							Value: openapi3.NewFloat64Schema(),
+						},
+						"reason": {
+							Value: openapi3.NewStringSchema().WithMinLength(1),
+						},
+					},
+					Required: []string{
+						"materialId",
+						"quantity",
+						"reason",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
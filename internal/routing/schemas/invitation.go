@@ -0,0 +1,138 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var InvitationSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"businessId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"recipient": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"userType": {
+				Value: openapi3.NewStringSchema().WithEnum("business", "collector"),
+			},
+			"roleId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"role": {
+				Ref: "#/components/schemas/Role",
+			},
+			"status": {
+				Value: openapi3.NewStringSchema().WithEnum("pending", "accepted", "revoked"),
+			},
+			"expiresAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"sentAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"sendCount": {
+				Value: openapi3.NewIntegerSchema().WithMin(0),
+			},
+			"invitedById": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"acceptedById": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"acceptedAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"revokedAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"businessId",
+			"recipient",
+			"userType",
+			"roleId",
+			"status",
+			"expiresAt",
+			"sentAt",
+			"sendCount",
+			"invitedById",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var InvitationsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/Invitation",
+		},
+	},
+}
+
+var CreateInvitationPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Create invitation payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"recipient": {
+							Value: openapi3.NewStringSchema(),
+						},
+						"userType": {
+							Value: openapi3.NewStringSchema().WithEnum("business", "collector"),
+						},
+						"roleId": {
+							Value: openapi3.NewUUIDSchema(),
+						},
+					},
+					Required: []string{
+						"recipient",
+						"roleId",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var AcceptInvitationPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Accept invitation payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"token": {
+							Value: openapi3.NewStringSchema(),
+						},
+						"name": {
+							Value: openapi3.NewStringSchema(),
+						},
+						"password": {
+							Value: openapi3.NewStringSchema().
+								WithMinLength(8),
+						},
+					},
+					Required: []string{
+						"token",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
@@ -0,0 +1,89 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var LineageNodeSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"type": {
+				Value: openapi3.NewStringSchema().WithEnum("transaction", "collection", "collector", "adjustment", "unattributed"),
+			},
+			"businessId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"approvedAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+		},
+		Required: []string{
+			"id",
+			"type",
+		},
+	},
+}
+
+var LineageEdgeSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"from": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"to": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"quantity": {
+				Value: openapi3.NewFloat64Schema().WithMin(0),
+			},
+		},
+		Required: []string{
+			"from",
+			"to",
+			"materialId",
+			"quantity",
+		},
+	},
+}
+
+var LineageSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"rootId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"method": {
+				Value: openapi3.NewStringSchema().WithEnum("fifo", "proportional"),
+			},
+			"nodes": {
+				Value: &openapi3.Schema{
+					Type: openapi3.NewArraySchema().Type,
+					Items: &openapi3.SchemaRef{
+						Ref: "#/components/schemas/LineageNode",
+					},
+				},
+			},
+			"edges": {
+				Value: &openapi3.Schema{
+					Type: openapi3.NewArraySchema().Type,
+					Items: &openapi3.SchemaRef{
+						Ref: "#/components/schemas/LineageEdge",
+					},
+				},
+			},
+		},
+		Required: []string{
+			"rootId",
+			"method",
+			"nodes",
+			"edges",
+		},
+	},
+}
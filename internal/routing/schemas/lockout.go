@@ -0,0 +1,54 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var LockoutSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"scope": {
+				Value: openapi3.NewStringSchema().WithEnum("login.account", "login.ip", "mfa.account", "mfa.ip"),
+			},
+			"key": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"failures": {
+				Value: openapi3.NewIntegerSchema().WithMin(0),
+			},
+			"lastFailureAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"lockedUntil": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"scope",
+			"key",
+			"failures",
+			"lastFailureAt",
+			"lockedUntil",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var LockoutsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/Lockout",
+		},
+	},
+}
@@ -16,7 +16,10 @@ var MaterialSchema = &openapi3.SchemaRef{
 				Value: openapi3.NewStringSchema(),
 			},
 			"carbonFactor": {
-				Value: openapi3.NewStringSchema(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"carbonFactorUnit": {
+				Value: openapi3.NewStringSchema().WithEnum("kgCO2e/kg"),
 			},
 			"createdAt": {
 				Value: openapi3.NewDateTimeSchema(),
@@ -30,6 +33,7 @@ var MaterialSchema = &openapi3.SchemaRef{
 			"name",
 			"gwCode",
 			"carbonFactor",
+			"carbonFactorUnit",
 			"createdAt",
 			"updatedAt",
 		},
@@ -79,7 +83,10 @@ var CreateMaterialSchema = &openapi3.SchemaRef{
 				Value: openapi3.NewStringSchema(),
 			},
 			"carbonFactor": {
-				Value: openapi3.NewStringSchema(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"carbonFactorUnit": {
+				Value: openapi3.NewStringSchema().WithEnum("kgCO2e/kg"),
 			},
 		},
 		Required: []string{
@@ -101,7 +108,10 @@ var UpdateMaterialSchema = &openapi3.SchemaRef{
 				Value: openapi3.NewStringSchema().WithNullable(),
 			},
 			"carbonFactor": {
-				Value: openapi3.NewStringSchema().WithNullable(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern).WithNullable(),
+			},
+			"carbonFactorUnit": {
+				Value: openapi3.NewStringSchema().WithEnum("kgCO2e/kg").WithNullable(),
 			},
 		},
 		Required: []string{
@@ -111,3 +121,118 @@ var UpdateMaterialSchema = &openapi3.SchemaRef{
 		},
 	},
 }
+
+var MaterialCarbonFactorSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"factor": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"unit": {
+				Value: openapi3.NewStringSchema().WithEnum("kgCO2e/kg"),
+			},
+			"effectiveFrom": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"createdById": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"materialId",
+			"factor",
+			"unit",
+			"effectiveFrom",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var MaterialCarbonFactorsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/MaterialCarbonFactor",
+		},
+	},
+}
+
+var CreateCarbonFactorPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Create carbon factor payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"factor": {
+							Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+						},
+						"unit": {
+							Value: openapi3.NewStringSchema().WithEnum("kgCO2e/kg"),
+						},
+						"effectiveFrom": {
+							Value: openapi3.NewDateTimeSchema(),
+						},
+					},
+					Required: []string{
+						"factor",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var UnmatchedLineSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"ownerId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"name": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"gwCode": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"weight": {
+				Value: openapi3.NewFloat64Schema().WithMin(0),
+			},
+			"value": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"ownerId",
+			"name",
+			"gwCode",
+			"weight",
+			"value",
+			"createdAt",
+		},
+	},
+}
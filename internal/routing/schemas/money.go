@@ -0,0 +1,6 @@
+package schemas
+
+// decimalPattern matches the exact decimal strings that amounts, prices and
+// VAT rates are sent as, such as "12.50", so that they aren't rounded through
+// floating point numbers on the way.
+const decimalPattern = `^-?[0-9]+(\.[0-9]+)?$`
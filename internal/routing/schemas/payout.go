@@ -0,0 +1,338 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var PayoutRunSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"businessId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"reference": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"from": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"to": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"status": {
+				Value: openapi3.NewStringSchema().WithEnum("generated", "exported", "confirmed", "cancelled"),
+			},
+			"currency": {
+				Value: openapi3.NewStringSchema().WithMinLength(3).WithMaxLength(3),
+			},
+			"totalAmount": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"statements": {
+				Ref: "#/components/schemas/PayoutStatements",
+			},
+			"createdById": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"exportedAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"confirmedAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"confirmedById": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"cancelledAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"businessId",
+			"reference",
+			"from",
+			"to",
+			"status",
+			"currency",
+			"totalAmount",
+			"createdById",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var PayoutRunsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/PayoutRun",
+		},
+	},
+}
+
+var PayoutStatementSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"payoutRunId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"collectorId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"reference": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"bankDetails": {
+				Ref: "#/components/schemas/BankDetails",
+			},
+			"weight": {
+				Value: openapi3.NewFloat64Schema(),
+			},
+			"amount": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"status": {
+				Value: openapi3.NewStringSchema().WithEnum("pending", "paid", "failed", "cancelled"),
+			},
+			"paidAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"items": {
+				Ref: "#/components/schemas/PayoutItems",
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"payoutRunId",
+			"collectorId",
+			"reference",
+			"bankDetails",
+			"weight",
+			"amount",
+			"status",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var PayoutStatementsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/PayoutStatement",
+		},
+	},
+}
+
+var PayoutItemSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"payoutStatementId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"collectionId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"collectedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"weight": {
+				Value: openapi3.NewFloat64Schema(),
+			},
+			"amount": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"released": {
+				Value: openapi3.NewBoolSchema(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"payoutStatementId",
+			"collectionId",
+			"collectedAt",
+			"weight",
+			"amount",
+			"released",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var PayoutItemsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/PayoutItem",
+		},
+	},
+}
+
+var PayoutSkippedSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"collectorId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"name": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"reason": {
+				Value: openapi3.NewStringSchema(),
+			},
+		},
+		Required: []string{
+			"collectorId",
+			"name",
+			"reason",
+		},
+	},
+}
+
+var PayoutReconciliationSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"runId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"status": {
+				Value: openapi3.NewStringSchema().WithEnum("generated", "exported", "confirmed", "cancelled"),
+			},
+			"currency": {
+				Value: openapi3.NewStringSchema().WithMinLength(3).WithMaxLength(3),
+			},
+			"expected": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"paid": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"failed": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"pending": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"balanced": {
+				Value: openapi3.NewBoolSchema(),
+			},
+			"discrepancies": {
+				Value: &openapi3.Schema{
+					Type: openapi3.NewArraySchema().Type,
+					Items: &openapi3.SchemaRef{
+						Ref: "#/components/schemas/PayoutDiscrepancy",
+					},
+				},
+			},
+		},
+		Required: []string{
+			"runId",
+			"status",
+			"currency",
+			"expected",
+			"paid",
+			"failed",
+			"pending",
+			"balanced",
+			"discrepancies",
+		},
+	},
+}
+
+var PayoutDiscrepancySchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"statementId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"collectionId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"problem": {
+				Value: openapi3.NewStringSchema(),
+			},
+		},
+		Required: []string{
+			"problem",
+		},
+	},
+}
+
+var CreatePayoutRunPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Create payout run payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"from": {
+							Value: openapi3.NewDateTimeSchema(),
+						},
+						"to": {
+							Value: openapi3.NewDateTimeSchema(),
+						},
+					},
+					Required: []string{
+						"from",
+						"to",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var ConfirmPayoutRunPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Confirm payout run payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"failedStatementIds": {
+							Value: openapi3.NewArraySchema().
+								WithItems(openapi3.NewUUIDSchema()),
+						},
+					},
+				}),
+		},
+		Required: false,
+	},
+}
@@ -0,0 +1,104 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var PolicySchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"name": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"description": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"entity": {
+				Value: openapi3.NewStringSchema().WithEnum("collections", "transactions"),
+			},
+			"userTypes": {
+				Value: openapi3.NewArraySchema().WithItems(
+					openapi3.NewStringSchema().WithEnum("system", "collector", "business", "service"),
+				),
+			},
+			"actions": {
+				Value: openapi3.NewArraySchema().WithItems(
+					openapi3.NewStringSchema().WithEnum("view", "create", "update", "delete"),
+				),
+			},
+			"rows": {
+				Value: openapi3.NewArraySchema().WithItems(
+					&openapi3.Schema{
+						Type: openapi3.NewObjectSchema().Type,
+						Properties: map[string]*openapi3.SchemaRef{
+							"column": {
+								Value: openapi3.NewStringSchema(),
+							},
+							"value": {
+								Value: openapi3.NewStringSchema().WithEnum("user", "activeBusiness"),
+							},
+						},
+						Required: []string{
+							"column",
+							"value",
+						},
+					},
+				).WithNullable(),
+			},
+		},
+		Required: []string{
+			"name",
+			"description",
+			"entity",
+			"userTypes",
+			"actions",
+			"rows",
+		},
+	},
+}
+
+var PoliciesSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/Policy",
+		},
+	},
+}
+
+var PolicyDecisionSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"allowed": {
+				Value: openapi3.NewBoolSchema(),
+			},
+			"entity": {
+				Value: openapi3.NewStringSchema().WithEnum("collections", "transactions"),
+			},
+			"action": {
+				Value: openapi3.NewStringSchema().WithEnum("view", "create", "update", "delete"),
+			},
+			"userType": {
+				Value: openapi3.NewStringSchema().WithEnum("system", "collector", "business", "service"),
+			},
+			"policies": {
+				Value: openapi3.NewArraySchema().WithItems(openapi3.NewStringSchema()),
+			},
+			"filter": {
+				Value: openapi3.NewStringSchema().WithNullable(),
+			},
+			"reason": {
+				Value: openapi3.NewStringSchema(),
+			},
+		},
+		Required: []string{
+			"allowed",
+			"entity",
+			"action",
+			"userType",
+			"policies",
+			"filter",
+			"reason",
+		},
+	},
+}
@@ -0,0 +1,164 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var MaterialPriceSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"businessId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"material": {
+				Ref: "#/components/schemas/Material",
+			},
+			"pricePerKg": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"minWeight": {
+				Value: openapi3.NewFloat64Schema().WithMin(0),
+			},
+			"grade": {
+				Value: openapi3.NewStringSchema().WithNullable(),
+			},
+			"effectiveFrom": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"effectiveTo": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"createdById": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"businessId",
+			"materialId",
+			"pricePerKg",
+			"minWeight",
+			"effectiveFrom",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var MaterialPricesSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/MaterialPrice",
+		},
+	},
+}
+
+var CollectorGradeSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"businessId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"collectorId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"grade": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"businessId",
+			"collectorId",
+			"grade",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var CollectorGradesSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/CollectorGrade",
+		},
+	},
+}
+
+var CreateMaterialPricePayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Create material price payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"materialId": {
+							Value: openapi3.NewUUIDSchema(),
+						},
+						"pricePerKg": {
+							Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+						},
+						"minWeight": {
+							Value: openapi3.NewFloat64Schema().WithMin(0),
+						},
+						"grade": {
+							Value: openapi3.NewStringSchema().WithNullable(),
+						},
+						"effectiveFrom": {
+							Value: openapi3.NewDateTimeSchema(),
+						},
+					},
+					Required: []string{
+						"materialId",
+						"pricePerKg",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var CollectorGradePayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Collector grade payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"grade": {
+							Value: openapi3.NewStringSchema().WithMinLength(1),
+						},
+					},
+					Required: []string{
+						"grade",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
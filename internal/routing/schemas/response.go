@@ -14,6 +14,23 @@ var ErrorSchema = &openapi3.SchemaRef{
 			"message": {
 				Value: openapi3.NewStringSchema().WithFormat("text"),
 			},
+			"violations": {
+				Value: openapi3.NewArraySchema().WithItems(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"code": {
+							Value: openapi3.NewStringSchema(),
+						},
+						"message": {
+							Value: openapi3.NewStringSchema().WithFormat("text"),
+						},
+					},
+					Required: []string{
+						"code",
+						"message",
+					},
+				}),
+			},
 		},
 		Required: []string{
 			"error",
@@ -40,6 +57,24 @@ var SuccessSchema = &openapi3.SchemaRef{
 									TransactionSchema,
 									TransactionMaterialSchema,
 									BusinessSchema,
+									LockoutSchema,
+									ApiTokenSchema,
+									UserSessionSchema,
+									InvitationSchema,
+									AuditLogSchema,
+									ImpersonationSchema,
+									MembershipSchema,
+									PolicyDecisionSchema,
+									MaterialCarbonFactorSchema,
+									CarbonSavingsSchema,
+									MaterialPriceSchema,
+									CollectorGradeSchema,
+									StatusChangeSchema,
+									StockMovementSchema,
+									LineageSchema,
+									PayoutRunSchema,
+									PayoutReconciliationSchema,
+									VatSettingsSchema,
 								},
 							},
 						},
@@ -57,7 +92,23 @@ var SuccessSchema = &openapi3.SchemaRef{
 											TransactionSchema,
 											TransactionMaterialSchema,
 											BusinessSchema,
+											LockoutSchema,
+											ApiTokenSchema,
+											UserSessionSchema,
+											InvitationSchema,
+											AuditLogSchema,
 											PermissionGroupSchema,
+											MembershipSchema,
+											PolicySchema,
+											MaterialCarbonFactorSchema,
+											MaterialPriceSchema,
+											CollectorGradeSchema,
+											StatusChangeSchema,
+											UnmatchedLineSchema,
+											StockMovementSchema,
+											StockLevelSchema,
+											StockShortfallSchema,
+											PayoutRunSchema,
 										},
 									},
 								},
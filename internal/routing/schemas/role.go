@@ -18,12 +18,15 @@ var RoleSchema = &openapi3.SchemaRef{
 			"permissions": {
 				Value: openapi3.NewArraySchema().WithItems(
 					openapi3.NewStringSchema().
-						WithPattern(`^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$`),
+						WithPattern(`^!?(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$`),
 				),
 			},
 			"default": {
 				Value: openapi3.NewBoolSchema(),
 			},
+			"businessId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
 			"createdAt": {
 				Value: openapi3.NewDateTimeSchema(),
 			},
@@ -88,7 +91,7 @@ var CreateRoleSchema = &openapi3.SchemaRef{
 			"permissions": {
 				Value: openapi3.NewArraySchema().WithItems(
 					openapi3.NewStringSchema().
-						WithPattern(`^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$`),
+						WithPattern(`^!?(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$`),
 				),
 			},
 		},
@@ -112,7 +115,7 @@ var UpdateRoleSchema = &openapi3.SchemaRef{
 			},
 			"permissions": {
 				Value: openapi3.NewArraySchema().WithItems(
-					openapi3.NewStringSchema().WithPattern(`^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$`),
+					openapi3.NewStringSchema().WithPattern(`^!?(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$`),
 				).WithNullable(),
 			},
 		},
@@ -123,3 +126,72 @@ var UpdateRoleSchema = &openapi3.SchemaRef{
 		},
 	},
 }
+
+var MembershipSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"businessId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"userId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"user": {
+				Ref: "#/components/schemas/User",
+			},
+			"roles": {
+				Ref: "#/components/schemas/Roles",
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"businessId",
+			"userId",
+			"roles",
+			"createdAt",
+		},
+	},
+}
+
+var MembershipsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/Membership",
+		},
+	},
+}
+
+var BusinessRolePayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Business role payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"name": {
+							Value: openapi3.NewStringSchema().WithFormat("text").WithMin(3),
+						},
+						"description": {
+							Value: openapi3.NewStringSchema().WithFormat("text").WithNullable(),
+						},
+						"permissions": {
+							Value: openapi3.NewArraySchema().WithItems(
+								openapi3.NewStringSchema().
+									WithPattern(`^!?(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$`),
+							),
+						},
+					},
+					Required: []string{
+						"name",
+						"permissions",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
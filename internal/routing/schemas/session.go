@@ -0,0 +1,54 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var UserSessionSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"userId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"userAgent": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"ip": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"lastSeenAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"current": {
+				Value: openapi3.NewBoolSchema(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"userId",
+			"userAgent",
+			"ip",
+			"lastSeenAt",
+			"current",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var UserSessionsSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/UserSession",
+		},
+	},
+}
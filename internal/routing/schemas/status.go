@@ -0,0 +1,94 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var StatusSchema = &openapi3.SchemaRef{
+	Value: openapi3.NewStringSchema().WithEnum(
+		"draft",
+		"submitted",
+		"weighed",
+		"approved",
+		"paid",
+		"disputed",
+		"voided",
+	),
+}
+
+var StatusChangeSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"ownerType": {
+				Value: openapi3.NewStringSchema().WithEnum("collections", "transactions"),
+			},
+			"ownerId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"transition": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"from": {
+				Ref: "#/components/schemas/Status",
+			},
+			"to": {
+				Ref: "#/components/schemas/Status",
+			},
+			"reason": {
+				Value: openapi3.NewStringSchema().WithNullable(),
+			},
+			"actorId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"ownerType",
+			"ownerId",
+			"transition",
+			"from",
+			"to",
+			"actorId",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var StatusChangesSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/StatusChange",
+		},
+	},
+}
+
+var StatusTransitionPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Status transition payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"reason": {
+							Value: openapi3.NewStringSchema().WithMinLength(1),
+						},
+					},
+					Required: []string{
+						"reason",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
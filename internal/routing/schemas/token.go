@@ -0,0 +1,128 @@
+package schemas
+
+import "github.com/getkin/kin-openapi/openapi3"
+
+var ApiTokenSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewObjectSchema().Type,
+		Properties: map[string]*openapi3.SchemaRef{
+			"id": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"name": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"prefix": {
+				Value: openapi3.NewStringSchema(),
+			},
+			"permissions": {
+				Value: openapi3.NewArraySchema().WithItems(
+					openapi3.NewStringSchema().
+						WithPattern(`^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$`),
+				),
+			},
+			"expiresAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"lastUsedAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"revokedAt": {
+				Value: openapi3.NewDateTimeSchema().WithNullable(),
+			},
+			"userId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"createdById": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"createdAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+			"updatedAt": {
+				Value: openapi3.NewDateTimeSchema(),
+			},
+		},
+		Required: []string{
+			"id",
+			"name",
+			"prefix",
+			"permissions",
+			"expiresAt",
+			"lastUsedAt",
+			"revokedAt",
+			"userId",
+			"createdById",
+			"createdAt",
+			"updatedAt",
+		},
+	},
+}
+
+var ApiTokensSchema = &openapi3.SchemaRef{
+	Value: &openapi3.Schema{
+		Type: openapi3.NewArraySchema().Type,
+		Items: &openapi3.SchemaRef{
+			Ref: "#/components/schemas/ApiToken",
+		},
+	},
+}
+
+var CreateApiTokenPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Create API token payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"name": {
+							Value: openapi3.NewStringSchema().WithMin(3),
+						},
+						"permissions": {
+							Value: openapi3.NewArraySchema().WithItems(
+								openapi3.NewStringSchema().
+									WithPattern(`^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$`),
+							).WithMinItems(1),
+						},
+						"expiresAt": {
+							Value: openapi3.NewDateTimeSchema().WithNullable(),
+						},
+					},
+					Required: []string{
+						"name",
+						"permissions",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var CreateServiceAccountPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Create service account payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"name": {
+							Value: openapi3.NewStringSchema().WithMin(3),
+						},
+						"permissions": {
+							Value: openapi3.NewArraySchema().WithItems(
+								openapi3.NewStringSchema().
+									WithPattern(`^(\*|[a-zA-Z0-9]+(\.(\*|[a-zA-Z0-9]+))*)$`),
+							),
+						},
+					},
+					Required: []string{
+						"name",
+						"permissions",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
@@ -12,6 +12,24 @@ var TransactionSchema = &openapi3.SchemaRef{
 			"materials": {
 				Ref: "#/components/schemas/TransactionMaterials",
 			},
+			"status": {
+				Ref: "#/components/schemas/Status",
+			},
+			"currency": {
+				Value: openapi3.NewStringSchema().WithMinLength(3).WithMaxLength(3),
+			},
+			"vatRate": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"net": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"vat": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"gross": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
 			"seller": {
 				Ref: "#/components/schemas/User",
 			},
@@ -100,6 +118,15 @@ var TransactionMaterialSchema = &openapi3.SchemaRef{
 			"id": {
 				Value: openapi3.NewUUIDSchema(),
 			},
+			"transactionId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
+			"materialId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"material": {
+				Ref: "#/components/schemas/Material",
+			},
 			"name": {
 				Value: openapi3.NewStringSchema(),
 			},
@@ -107,13 +134,16 @@ var TransactionMaterialSchema = &openapi3.SchemaRef{
 				Value: openapi3.NewStringSchema(),
 			},
 			"carbonFactor": {
-				Value: openapi3.NewStringSchema(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 			"weight": {
 				Value: openapi3.NewFloat64Schema().WithMin(0),
 			},
 			"value": {
-				Value: openapi3.NewFloat64Schema().WithMin(0),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+			},
+			"vat": {
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 			"createdAt": {
 				Value: openapi3.NewDateTimeSchema(),
@@ -149,6 +179,9 @@ var AssignTransactionMaterialSchema = &openapi3.SchemaRef{
 			"id": {
 				Value: openapi3.NewUUIDSchema(),
 			},
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
 			"name": {
 				Value: openapi3.NewStringSchema(),
 			},
@@ -156,19 +189,17 @@ var AssignTransactionMaterialSchema = &openapi3.SchemaRef{
 				Value: openapi3.NewStringSchema(),
 			},
 			"carbonFactor": {
-				Value: openapi3.NewStringSchema(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 			"weight": {
 				Value: openapi3.NewFloat64Schema().WithMin(0),
 			},
 			"value": {
-				Value: openapi3.NewFloat64Schema().WithMin(0),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 		},
+		Description: "The line's name, GW code and carbon factor are copied from the catalogue material given by materialId, or else by gwCode.",
 		Required: []string{
-			"name",
-			"gwCode",
-			"carbonFactor",
 			"weight",
 			"value",
 		},
@@ -188,6 +219,9 @@ var CreateTransactionMaterialSchema = &openapi3.SchemaRef{
 	Value: &openapi3.Schema{
 		Type: openapi3.NewObjectSchema().Type,
 		Properties: map[string]*openapi3.SchemaRef{
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
+			},
 			"name": {
 				Value: openapi3.NewStringSchema(),
 			},
@@ -195,19 +229,17 @@ var CreateTransactionMaterialSchema = &openapi3.SchemaRef{
 				Value: openapi3.NewStringSchema(),
 			},
 			"carbonFactor": {
-				Value: openapi3.NewStringSchema(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 			"weight": {
 				Value: openapi3.NewFloat64Schema().WithMin(0),
 			},
 			"value": {
-				Value: openapi3.NewFloat64Schema().WithMin(0),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 		},
+		Description: "The line's name, GW code and carbon factor are copied from the catalogue material given by materialId, or else by gwCode.",
 		Required: []string{
-			"name",
-			"gwCode",
-			"carbonFactor",
 			"weight",
 			"value",
 		},
@@ -218,28 +250,103 @@ var UpdateTransactionMaterialSchema = &openapi3.SchemaRef{
 	Value: &openapi3.Schema{
 		Type: openapi3.NewObjectSchema().Type,
 		Properties: map[string]*openapi3.SchemaRef{
-			"name": {
-				Value: openapi3.NewStringSchema(),
-			},
-			"gwCode": {
-				Value: openapi3.NewStringSchema(),
-			},
-			"carbonFactor": {
-				Value: openapi3.NewStringSchema(),
+			"materialId": {
+				Value: openapi3.NewUUIDSchema(),
 			},
 			"weight": {
-				Value: openapi3.NewFloat64Schema().WithMin(0).WithNullable(),
+				Value: openapi3.NewFloat64Schema().WithMin(0),
 			},
 			"value": {
-				Value: openapi3.NewFloat64Schema().WithMin(0).WithNullable(),
+				Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
 			},
 		},
+		Description: "The line's name, GW code and carbon factor are copied from the catalogue material again.",
 		Required: []string{
-			"name",
-			"gwCode",
-			"carbonFactor",
+			"materialId",
 			"weight",
 			"value",
 		},
 	},
 }
+
+var CreateTransactionLinePayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Create transaction line payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"materialId": {
+							Value: openapi3.NewUUIDSchema(),
+						},
+						"weight": {
+							Value: openapi3.NewFloat64Schema().WithMin(0),
+						},
+						"value": {
+							Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+						},
+					},
+					Required: []string{
+						"materialId",
+						"weight",
+						"value",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
+
+var CreateTransactionPayloadSchema = &openapi3.RequestBodyRef{
+	Value: &openapi3.RequestBody{
+		Description: "Create transaction payload",
+		Content: openapi3.Content{
+			"application/json": openapi3.NewMediaType().
+				WithSchema(&openapi3.Schema{
+					Type: openapi3.NewObjectSchema().Type,
+					Properties: map[string]*openapi3.SchemaRef{
+						"sellerId": {
+							Value: openapi3.NewUUIDSchema(),
+						},
+						"buyerId": {
+							Value: openapi3.NewUUIDSchema(),
+						},
+						"materials": {
+							Value: openapi3.NewArraySchema().WithMinItems(1).WithItems(&openapi3.Schema{
+								Type: openapi3.NewObjectSchema().Type,
+								Properties: map[string]*openapi3.SchemaRef{
+									"materialId": {
+										Value: openapi3.NewUUIDSchema(),
+									},
+									"weight": {
+										Value: openapi3.NewFloat64Schema().WithMin(0),
+									},
+									"value": {
+										Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+									},
+								},
+								Required: []string{
+									"materialId",
+									"weight",
+									"value",
+								},
+							}),
+						},
+						"totalWeight": {
+							Value: openapi3.NewFloat64Schema().WithMin(0),
+						},
+						"totalValue": {
+							Value: openapi3.NewStringSchema().WithPattern(decimalPattern),
+						},
+					},
+					Required: []string{
+						"sellerId",
+						"buyerId",
+						"materials",
+					},
+				}),
+		},
+		Required: true,
+	},
+}
@@ -29,7 +29,13 @@ var UserSchema = &openapi3.SchemaRef{
 				),
 			},
 			"type": {
-				Value: openapi3.NewStringSchema().WithEnum("system", "collector", "business"),
+				Value: openapi3.NewStringSchema().WithEnum("system", "collector", "business", "service"),
+			},
+			"verified": {
+				Value: openapi3.NewBoolSchema(),
+			},
+			"approvalStatus": {
+				Value: openapi3.NewStringSchema().WithEnum("pending", "approved", "rejected"),
 			},
 			"businessId": {
 				Value: openapi3.NewUUIDSchema().WithNullable(),
@@ -54,6 +60,12 @@ var UserSchema = &openapi3.SchemaRef{
 					},
 				},
 			},
+			"activeBusinessId": {
+				Value: openapi3.NewUUIDSchema().WithNullable(),
+			},
+			"businessRoles": {
+				Ref: "#/components/schemas/Roles",
+			},
 			"createdAt": {
 				Value: openapi3.NewDateTimeSchema(),
 			},
@@ -69,6 +81,8 @@ var UserSchema = &openapi3.SchemaRef{
 			"mfaVerified",
 			"permissions",
 			"type",
+			"verified",
+			"approvalStatus",
 			"businessId",
 			"roles",
 			"businesses",
@@ -131,7 +145,7 @@ var CreateUserSchema = &openapi3.SchemaRef{
 				),
 			},
 			"type": {
-				Value: openapi3.NewStringSchema().WithEnum("system", "collector", "business").WithDefault("collector"),
+				Value: openapi3.NewStringSchema().WithEnum("system", "collector", "business", "service").WithDefault("collector"),
 			},
 			"businessId": {
 				Value: openapi3.NewUUIDSchema(),
@@ -184,7 +198,7 @@ var UpdateUserSchema = &openapi3.SchemaRef{
 				).WithNullable(),
 			},
 			"type": {
-				Value: openapi3.NewStringSchema().WithEnum("system", "collector", "business").WithDefault("collector").WithNullable(),
+				Value: openapi3.NewStringSchema().WithEnum("system", "collector", "business", "service").WithDefault("collector").WithNullable(),
 			},
 			"businessId": {
 				Value: openapi3.NewUUIDSchema().WithNullable(),
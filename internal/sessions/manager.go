@@ -0,0 +1,310 @@
+package sessions
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/session"
+	"github.com/google/uuid"
+)
+
+var ErrSessionExpired = errors.New("the session has expired")
+
+type Manager interface {
+	Start(c *fiber.Ctx, currentSession *session.Session, userId uuid.UUID) error
+	Refresh(c *fiber.Ctx, currentSession *session.Session) error
+	End(c *fiber.Ctx, currentSession *session.Session) error
+	VerifyCsrf(c *fiber.Ctx, currentSession *session.Session) bool
+	ActiveBusiness(currentSession *session.Session) *uuid.UUID
+	SwitchBusiness(currentSession *session.Session, businessId uuid.UUID) error
+	List(userId uuid.UUID) ([]models.UserSession, error)
+	Revoke(userId uuid.UUID, id uuid.UUID) error
+	RevokeAll(userId uuid.UUID, except ...string) error
+}
+
+type manager struct {
+	storage storage.Storage
+	store   *session.Store
+	config  Config
+}
+
+func NewManager(storage storage.Storage, store *session.Store, config Config) Manager {
+	return &manager{
+		storage: storage,
+		store:   store,
+		config:  config,
+	}
+}
+
+// Start signs a user into the current session. The session id is rotated so a
+// pre-login id can't be fixated, and a fresh csrf token is issued alongside it.
+func (m *manager) Start(c *fiber.Ctx, currentSession *session.Session, userId uuid.UUID) error {
+	if !currentSession.Fresh() {
+		if err := m.forget(currentSession.ID()); err != nil {
+			return err
+		}
+
+		if err := currentSession.Regenerate(); err != nil {
+			return err
+		}
+	}
+
+	csrfToken, err := newCsrfToken()
+
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	currentSession.Set("user_id", userId.String())
+	currentSession.Set("created_at", now.Unix())
+	currentSession.Set("refreshed_at", now.Unix())
+	currentSession.Set("csrf_token", csrfToken)
+	currentSession.SetExpiry(m.expiry(now, now))
+
+	if err := currentSession.Save(); err != nil {
+		return err
+	}
+
+	m.setCsrfCookie(c, csrfToken, m.expiry(now, now))
+
+	return m.track(c, currentSession.ID(), userId, now)
+}
+
+// Refresh extends the session in a sliding window. Sessions are only written
+// back once per refresh interval instead of on every request, and are never
+// extended past their absolute lifetime.
+func (m *manager) Refresh(c *fiber.Ctx, currentSession *session.Session) error {
+	now := time.Now()
+
+	createdAt, ok := currentSession.Get("created_at").(int64)
+
+	if !ok {
+		createdAt = now.Unix()
+		currentSession.Set("created_at", createdAt)
+	}
+
+	created := time.Unix(createdAt, 0)
+
+	if m.config.AbsoluteLifetime > 0 && now.Sub(created) >= m.config.AbsoluteLifetime {
+		if err := m.End(c, currentSession); err != nil {
+			return err
+		}
+
+		return ErrSessionExpired
+	}
+
+	refreshedAt, _ := currentSession.Get("refreshed_at").(int64)
+	csrfToken, _ := currentSession.Get("csrf_token").(string)
+
+	if csrfToken != "" && now.Sub(time.Unix(refreshedAt, 0)) < m.config.RefreshInterval {
+		return nil
+	}
+
+	if csrfToken == "" {
+		token, err := newCsrfToken()
+
+		if err != nil {
+			return err
+		}
+
+		csrfToken = token
+		currentSession.Set("csrf_token", csrfToken)
+	}
+
+	expiry := m.expiry(now, created)
+
+	currentSession.Set("refreshed_at", now.Unix())
+	currentSession.SetExpiry(expiry)
+
+	if err := currentSession.Save(); err != nil {
+		return err
+	}
+
+	m.setCsrfCookie(c, csrfToken, expiry)
+
+	return m.touch(currentSession.ID(), now)
+}
+
+func (m *manager) End(c *fiber.Ctx, currentSession *session.Session) error {
+	if err := m.forget(currentSession.ID()); err != nil {
+		return err
+	}
+
+	c.ClearCookie(m.config.CsrfCookieName)
+
+	return currentSession.Destroy()
+}
+
+func (m *manager) VerifyCsrf(c *fiber.Ctx, currentSession *session.Session) bool {
+	csrfToken, ok := currentSession.Get("csrf_token").(string)
+
+	if !ok || csrfToken == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(c.Get(m.config.CsrfHeader)), []byte(csrfToken)) == 1
+}
+
+// ActiveBusiness returns the business the session switched to, or nil when the
+// user's default business applies.
+func (m *manager) ActiveBusiness(currentSession *session.Session) *uuid.UUID {
+	businessId, ok := currentSession.Get("business_id").(string)
+
+	if !ok || businessId == "" {
+		return nil
+	}
+
+	activeBusinessId, err := uuid.Parse(businessId)
+
+	if err != nil {
+		return nil
+	}
+
+	return &activeBusinessId
+}
+
+// SwitchBusiness makes businessId the active business for the rest of the
+// session. Membership must be checked by the caller.
+func (m *manager) SwitchBusiness(currentSession *session.Session, businessId uuid.UUID) error {
+	currentSession.Set("business_id", businessId.String())
+
+	return currentSession.Save()
+}
+
+func (m *manager) expiry(now time.Time, created time.Time) time.Duration {
+	expiry := m.config.IdleTimeout
+
+	if m.config.AbsoluteLifetime > 0 {
+		if remaining := created.Add(m.config.AbsoluteLifetime).Sub(now); remaining < expiry {
+			expiry = remaining
+		}
+	}
+
+	return expiry
+}
+
+// setCsrfCookie exposes the session's csrf token to the frontend, which has to
+// echo it back in the csrf header on state-changing requests. The cookie is
+// readable by scripts on purpose.
+func (m *manager) setCsrfCookie(c *fiber.Ctx, csrfToken string, expiry time.Duration) {
+	c.Cookie(&fiber.Cookie{
+		Name:     m.config.CsrfCookieName,
+		Value:    csrfToken,
+		Domain:   m.config.CookieDomain,
+		Path:     m.config.CookiePath,
+		Secure:   m.config.CookieSecure,
+		HTTPOnly: false,
+		SameSite: m.config.CookieSameSite,
+		Expires:  time.Now().Add(expiry),
+	})
+}
+
+func newCsrfToken() (string, error) {
+	bytes := make([]byte, 32)
+
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func (m *manager) track(c *fiber.Ctx, sessionId string, userId uuid.UUID, now time.Time) error {
+	userSession := models.UserSession{
+		UserId:     userId,
+		SessionId:  sessionId,
+		UserAgent:  string(c.Request().Header.UserAgent()),
+		Ip:         c.IP(),
+		LastSeenAt: now,
+	}
+
+	return m.storage.Database().
+		Where("session_id = ?", sessionId).
+		Assign(models.UserSession{
+			UserId:     userId,
+			UserAgent:  userSession.UserAgent,
+			Ip:         userSession.Ip,
+			LastSeenAt: now,
+		}).
+		FirstOrCreate(&userSession).Error
+}
+
+func (m *manager) touch(sessionId string, now time.Time) error {
+	return m.storage.Database().
+		Model(&models.UserSession{}).
+		Where("session_id = ?", sessionId).
+		Update("last_seen_at", now).Error
+}
+
+func (m *manager) forget(sessionId string) error {
+	return m.storage.Database().
+		Where("session_id = ?", sessionId).
+		Delete(&models.UserSession{}).Error
+}
+
+func (m *manager) List(userId uuid.UUID) ([]models.UserSession, error) {
+	var userSessions []models.UserSession
+
+	if err := m.storage.Database().
+		Where("user_id = ?", userId).
+		Order("last_seen_at DESC").
+		Find(&userSessions).Error; err != nil {
+		return nil, err
+	}
+
+	return userSessions, nil
+}
+
+func (m *manager) Revoke(userId uuid.UUID, id uuid.UUID) error {
+	var userSession models.UserSession
+
+	if err := m.storage.Database().
+		Where("id = ? AND user_id = ?", id, userId).
+		First(&userSession).Error; err != nil {
+		return err
+	}
+
+	if err := m.store.Delete(userSession.SessionId); err != nil {
+		return err
+	}
+
+	return m.storage.Database().Delete(&userSession).Error
+}
+
+func (m *manager) RevokeAll(userId uuid.UUID, except ...string) error {
+	var userSessions []models.UserSession
+
+	query := m.storage.Database().Where("user_id = ?", userId)
+
+	if len(except) > 0 {
+		query = query.Where("session_id NOT IN ?", except)
+	}
+
+	if err := query.Find(&userSessions).Error; err != nil {
+		return err
+	}
+
+	if len(userSessions) == 0 {
+		return nil
+	}
+
+	ids := []uuid.UUID{}
+
+	for _, userSession := range userSessions {
+		if err := m.store.Delete(userSession.SessionId); err != nil {
+			return err
+		}
+
+		ids = append(ids, userSession.Id)
+	}
+
+	return m.storage.Database().Where("id IN ?", ids).Delete(&models.UserSession{}).Error
+}
@@ -8,19 +8,49 @@ import (
 	fiberPg "github.com/gofiber/storage/postgres/v2"
 )
 
-func New() *session.Store {
+type Config struct {
+	KeyLookup        string
+	CookieDomain     string
+	CookiePath       string
+	CookieSecure     bool
+	CookieHTTPOnly   bool
+	CookieSameSite   string
+	IdleTimeout      time.Duration
+	AbsoluteLifetime time.Duration
+	RefreshInterval  time.Duration
+	CsrfCookieName   string
+	CsrfHeader       string
+}
+
+func NewConfig() Config {
+	return Config{
+		KeyLookup:        common.EnvString("APP_SESSION_KEY", "cookie:threereco_session"),
+		CookieDomain:     common.EnvString("APP_SESSION_COOKIE_DOMAIN", common.EnvString("APP_DOMAIN", "localhost")),
+		CookiePath:       common.EnvString("APP_SESSION_COOKIE_PATH", "/"),
+		CookieSecure:     common.EnvBool("APP_SESSION_COOKIE_SECURE", true),
+		CookieHTTPOnly:   common.EnvBool("APP_SESSION_COOKIE_HTTP_ONLY", true),
+		CookieSameSite:   common.EnvString("APP_SESSION_COOKIE_SAME_SITE", "Strict"),
+		IdleTimeout:      common.EnvDuration("APP_SESSION_IDLE_TIMEOUT", 1*time.Hour),
+		AbsoluteLifetime: common.EnvDuration("APP_SESSION_ABSOLUTE_LIFETIME", 12*time.Hour),
+		RefreshInterval:  common.EnvDuration("APP_SESSION_REFRESH_INTERVAL", 5*time.Minute),
+		CsrfCookieName:   common.EnvString("APP_CSRF_COOKIE", "threereco_csrf"),
+		CsrfHeader:       common.EnvString("APP_CSRF_HEADER", "X-CSRF-Token"),
+	}
+}
+
+func New(config Config) *session.Store {
 	return session.New(session.Config{
 		Storage: fiberPg.New(fiberPg.Config{
 			Table:         "sessions",
 			ConnectionURI: common.EnvString("APP_DSN", "host=localhost user=<user> password=<password> dbname=<database> port=5432 sslmode=disable TimeZone=Africa/Johannesburg"),
 		}),
-		KeyLookup:         common.EnvString("APP_SESSION_KEY", "cookie:threereco_session"),
-		CookieDomain:      common.EnvString("APP_DOMAIN", "localhost"),
-		CookiePath:        "/",
-		CookieSecure:      true,
-		CookieSameSite:    "Strict",
+		KeyLookup:         config.KeyLookup,
+		CookieDomain:      config.CookieDomain,
+		CookiePath:        config.CookiePath,
+		CookieSecure:      config.CookieSecure,
+		CookieSameSite:    config.CookieSameSite,
 		CookieSessionOnly: false,
-		CookieHTTPOnly:    false,
-		Expiration:        1 * time.Hour,
+		CookieHTTPOnly:    config.CookieHTTPOnly,
+		Expiration:        config.IdleTimeout,
 	})
 }
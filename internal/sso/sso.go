@@ -0,0 +1,630 @@
+package sso
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/log"
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrUnknownProvider = errors.New("the identity provider is not configured")
+	ErrInvalidNonce    = errors.New("the id token nonce does not match the login request")
+	ErrMissingUsername = errors.New("the id token does not contain a usable username claim")
+	ErrNotProvisioned  = errors.New("no account is linked to this identity and sign up is disabled for the provider")
+	ErrServiceAccount  = errors.New("service accounts can't sign in with single sign-on")
+	ErrInvalidFlow     = errors.New("the single sign-on request is missing, expired or does not match")
+	ErrUsernameTaken   = errors.New("another account already uses the username from the id token")
+)
+
+const flowCookieName = "threereco_oidc"
+
+// ClaimMapping grants roles and business membership to users whose id token
+// claim matches the given value. Array claims such as "groups" match when any
+// element equals the value.
+type ClaimMapping struct {
+	Claim      string     `json:"claim"`
+	Value      string     `json:"value"`
+	Roles      []string   `json:"roles"`
+	BusinessId *uuid.UUID `json:"businessId"`
+}
+
+type ProviderConfig struct {
+	Slug          string          `json:"slug"`
+	Name          string          `json:"name"`
+	Issuer        string          `json:"issuer"`
+	ClientId      string          `json:"clientId"`
+	ClientSecret  string          `json:"clientSecret"`
+	Scopes        []string        `json:"scopes"`
+	UsernameClaim string          `json:"usernameClaim"`
+	NameClaim     string          `json:"nameClaim"`
+	UserType      models.UserType `json:"userType"`
+	AllowSignup   bool            `json:"allowSignup"`
+	LinkByEmail   bool            `json:"linkByEmail"`
+	DefaultRoles  []string        `json:"defaultRoles"`
+	Mappings      []ClaimMapping  `json:"mappings"`
+}
+
+type ProviderSummary struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+type Claims struct {
+	Subject       string
+	Username      string
+	Name          string
+	Email         string
+	EmailVerified bool
+	Raw           map[string]any
+}
+
+// Flow holds the per-login values that have to survive the round trip through
+// the identity provider.
+type Flow struct {
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	Redirect string `json:"redirect"`
+}
+
+type Sso interface {
+	Providers() []ProviderSummary
+	Begin(slug string, redirect string) (*Flow, string, error)
+	Exchange(ctx context.Context, flow *Flow, code string) (*Claims, error)
+	Provision(slug string, claims *Claims) (*models.User, error)
+	SaveFlow(c *fiber.Ctx, flow *Flow) error
+	TakeFlow(c *fiber.Ctx, slug string, state string) (*Flow, error)
+}
+
+type provider struct {
+	config   ProviderConfig
+	oauth2   oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+type sso struct {
+	storage     storage.Storage
+	session     sessions.Config
+	callbackUrl string
+	configs     map[string]ProviderConfig
+	order       []string
+	mutex       sync.Mutex
+	discovered  map[string]*provider
+}
+
+func New(storage storage.Storage, session sessions.Config) Sso {
+	configs, order, err := loadProviders(common.EnvString("APP_OIDC_PROVIDERS_FILE", ""))
+
+	if err != nil {
+		log.Errorf("🔥 Failed to load OIDC providers: %s", err.Error())
+	}
+
+	return &sso{
+		storage: storage,
+		session: session,
+		callbackUrl: strings.TrimSuffix(common.EnvString(
+			"APP_OIDC_CALLBACK_BASE_URL",
+			fmt.Sprintf("http://localhost:%s", common.EnvString("APP_PORT", "6173")),
+		), "/"),
+		configs:    configs,
+		order:      order,
+		discovered: map[string]*provider{},
+	}
+}
+
+func loadProviders(path string) (map[string]ProviderConfig, []string, error) {
+	configs := map[string]ProviderConfig{}
+	order := []string{}
+
+	if path == "" {
+		return configs, order, nil
+	}
+
+	data, err := os.ReadFile(path)
+
+	if err != nil {
+		return configs, order, err
+	}
+
+	var providers []ProviderConfig
+
+	if err := json.Unmarshal(data, &providers); err != nil {
+		return configs, order, err
+	}
+
+	for _, config := range providers {
+		if config.Slug == "" || config.Issuer == "" || config.ClientId == "" {
+			log.Warnf("⚠️ Skipping OIDC provider %q: slug, issuer and clientId are required", config.Slug)
+
+			continue
+		}
+
+		if len(config.Scopes) == 0 {
+			config.Scopes = []string{oidc.ScopeOpenID, "profile", "email"}
+		}
+
+		if !slices.Contains(config.Scopes, oidc.ScopeOpenID) {
+			config.Scopes = append([]string{oidc.ScopeOpenID}, config.Scopes...)
+		}
+
+		if config.UsernameClaim == "" {
+			config.UsernameClaim = "email"
+		}
+
+		if config.NameClaim == "" {
+			config.NameClaim = "name"
+		}
+
+		if config.UserType == "" {
+			config.UserType = models.CollectorUser
+		}
+
+		if config.Name == "" {
+			config.Name = config.Slug
+		}
+
+		configs[config.Slug] = config
+		order = append(order, config.Slug)
+	}
+
+	log.Infof("✅ Loaded %d OIDC provider(s) from %s", len(order), path)
+
+	return configs, order, nil
+}
+
+func (s *sso) Providers() []ProviderSummary {
+	summaries := []ProviderSummary{}
+
+	for _, slug := range s.order {
+		summaries = append(summaries, ProviderSummary{
+			Slug: slug,
+			Name: s.configs[slug].Name,
+		})
+	}
+
+	return summaries
+}
+
+// provider performs discovery the first time a provider is used rather than
+// at startup, so an unreachable identity provider doesn't stop the api from
+// booting. Discovery runs on a background context because the provider keeps
+// it for refreshing its signing keys after the request has finished.
+func (s *sso) provider(slug string) (*provider, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if discovered, ok := s.discovered[slug]; ok {
+		return discovered, nil
+	}
+
+	config, ok := s.configs[slug]
+
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	ctx := oidc.ClientContext(context.Background(), &http.Client{
+		Timeout: 10 * time.Second,
+	})
+
+	oidcProvider, err := oidc.NewProvider(ctx, config.Issuer)
+
+	if err != nil {
+		return nil, err
+	}
+
+	discovered := &provider{
+		config: config,
+		oauth2: oauth2.Config{
+			ClientID:     config.ClientId,
+			ClientSecret: config.ClientSecret,
+			Endpoint:     oidcProvider.Endpoint(),
+			RedirectURL:  fmt.Sprintf("%s/api/authentication/oidc/%s/callback", s.callbackUrl, slug),
+			Scopes:       config.Scopes,
+		},
+		verifier: oidcProvider.Verifier(&oidc.Config{
+			ClientID: config.ClientId,
+		}),
+	}
+
+	s.discovered[slug] = discovered
+
+	return discovered, nil
+}
+
+func random() (string, error) {
+	bytes := make([]byte, 32)
+
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+func (s *sso) Begin(slug string, redirect string) (*Flow, string, error) {
+	provider, err := s.provider(slug)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	state, err := random()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	nonce, err := random()
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	flow := &Flow{
+		Provider: slug,
+		State:    state,
+		Nonce:    nonce,
+		Verifier: oauth2.GenerateVerifier(),
+		Redirect: redirect,
+	}
+
+	authUrl := provider.oauth2.AuthCodeURL(
+		state,
+		oidc.Nonce(nonce),
+		oauth2.S256ChallengeOption(flow.Verifier),
+	)
+
+	return flow, authUrl, nil
+}
+
+// SaveFlow keeps the login flow in a short-lived cookie. The session cookie
+// can't be used for this because SameSite=Strict cookies are not sent on the
+// redirect back from the identity provider.
+func (s *sso) SaveFlow(c *fiber.Ctx, flow *Flow) error {
+	data, err := json.Marshal(flow)
+
+	if err != nil {
+		return err
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     flowCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(data),
+		Domain:   s.session.CookieDomain,
+		Path:     "/api/authentication/oidc",
+		Secure:   s.session.CookieSecure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+		Expires:  time.Now().Add(10 * time.Minute),
+	})
+
+	return nil
+}
+
+func (s *sso) TakeFlow(c *fiber.Ctx, slug string, state string) (*Flow, error) {
+	value := c.Cookies(flowCookieName)
+
+	c.Cookie(&fiber.Cookie{
+		Name:     flowCookieName,
+		Value:    "",
+		Domain:   s.session.CookieDomain,
+		Path:     "/api/authentication/oidc",
+		Secure:   s.session.CookieSecure,
+		HTTPOnly: true,
+		SameSite: fiber.CookieSameSiteLaxMode,
+		Expires:  time.Now().Add(-1 * time.Hour),
+	})
+
+	if value == "" {
+		return nil, ErrInvalidFlow
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		return nil, ErrInvalidFlow
+	}
+
+	var flow Flow
+
+	if err := json.Unmarshal(data, &flow); err != nil {
+		return nil, ErrInvalidFlow
+	}
+
+	if flow.Provider != slug || flow.State == "" || subtle.ConstantTimeCompare([]byte(flow.State), []byte(state)) != 1 {
+		return nil, ErrInvalidFlow
+	}
+
+	return &flow, nil
+}
+
+func (s *sso) Exchange(ctx context.Context, flow *Flow, code string) (*Claims, error) {
+	provider, err := s.provider(flow.Provider)
+
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := provider.oauth2.Exchange(ctx, code, oauth2.VerifierOption(flow.Verifier))
+
+	if err != nil {
+		return nil, err
+	}
+
+	rawIdToken, ok := token.Extra("id_token").(string)
+
+	if !ok || rawIdToken == "" {
+		return nil, errors.New("the token response did not contain an id token")
+	}
+
+	idToken, err := provider.verifier.Verify(ctx, rawIdToken)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if idToken.Nonce != flow.Nonce {
+		return nil, ErrInvalidNonce
+	}
+
+	raw := map[string]any{}
+
+	if err := idToken.Claims(&raw); err != nil {
+		return nil, err
+	}
+
+	claims := &Claims{
+		Subject:  idToken.Subject,
+		Username: strings.ToLower(strings.TrimSpace(stringClaim(raw, provider.config.UsernameClaim))),
+		Name:     stringClaim(raw, provider.config.NameClaim),
+		Email:    strings.ToLower(strings.TrimSpace(stringClaim(raw, "email"))),
+		Raw:      raw,
+	}
+
+	if verified, ok := raw["email_verified"].(bool); ok {
+		claims.EmailVerified = verified
+	}
+
+	if claims.Username == "" {
+		claims.Username = strings.ToLower(strings.TrimSpace(stringClaim(raw, "preferred_username")))
+	}
+
+	if claims.Username == "" {
+		return nil, ErrMissingUsername
+	}
+
+	if claims.Name == "" {
+		claims.Name = claims.Username
+	}
+
+	return claims, nil
+}
+
+func stringClaim(raw map[string]any, claim string) string {
+	value, _ := raw[claim].(string)
+
+	return value
+}
+
+// matches reports whether a claim equals the mapping value. Array claims match
+// when any of their elements equals the value.
+func matches(raw map[string]any, mapping ClaimMapping) bool {
+	switch value := raw[mapping.Claim].(type) {
+	case string:
+		return value == mapping.Value
+	case bool:
+		return fmt.Sprint(value) == mapping.Value
+	case []any:
+		for _, element := range value {
+			if element, ok := element.(string); ok && element == mapping.Value {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *sso) Provision(slug string, claims *Claims) (*models.User, error) {
+	config, ok := s.configs[slug]
+
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+
+	var user models.User
+
+	err := s.storage.Database().Transaction(func(tx *gorm.DB) error {
+		var identity models.UserIdentity
+
+		err := tx.Where("provider = ? AND subject = ?", slug, claims.Subject).First(&identity).Error
+
+		switch {
+		case err == nil:
+			if err := tx.Where("id = ?", identity.UserId).First(&user).Error; err != nil {
+				return err
+			}
+		case err != gorm.ErrRecordNotFound:
+			return err
+		default:
+			linked := false
+
+			if config.LinkByEmail && claims.Email != "" && claims.EmailVerified {
+				if err := tx.Where("LOWER(username) = ?", claims.Email).First(&user).Error; err == nil {
+					linked = true
+				} else if err != gorm.ErrRecordNotFound {
+					return err
+				}
+			}
+
+			if !linked {
+				if !config.AllowSignup {
+					return ErrNotProvisioned
+				}
+
+				user = models.User{
+					Name:     claims.Name,
+					Username: claims.Username,
+					Type:     config.UserType,
+				}
+
+				// A username that already belongs to a local account is never
+				// linked implicitly, only through verified email linking.
+				result := tx.Clauses(clause.OnConflict{
+					Columns:   []clause.Column{{Name: "username"}},
+					DoNothing: true,
+				}).Create(&user)
+
+				if result.Error != nil {
+					return result.Error
+				}
+
+				if result.RowsAffected == 0 {
+					return ErrUsernameTaken
+				}
+
+				if err := tx.Model(&user).Update("password_reset", false).Error; err != nil {
+					return err
+				}
+			}
+
+			var email *string
+
+			if claims.Email != "" {
+				email = &claims.Email
+			}
+
+			if err := tx.Create(&models.UserIdentity{
+				UserId:   user.Id,
+				Provider: slug,
+				Subject:  claims.Subject,
+				Email:    email,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		if user.Type == models.ServiceUser {
+			return ErrServiceAccount
+		}
+
+		return s.applyMappings(tx, &user, config, claims)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// applyMappings adds the roles and businesses granted by the provider's claim
+// mappings. Roles of a mapping with a business are granted inside that
+// business only. Grants are additive, anything assigned inside the platform
+// is left untouched.
+func (s *sso) applyMappings(tx *gorm.DB, user *models.User, config ProviderConfig, claims *Claims) error {
+	roleNames := slices.Clone(config.DefaultRoles)
+	businessIds := []uuid.UUID{}
+	businessRoleNames := map[uuid.UUID][]string{}
+
+	for _, mapping := range config.Mappings {
+		if !matches(claims.Raw, mapping) {
+			continue
+		}
+
+		if mapping.BusinessId == nil {
+			roleNames = append(roleNames, mapping.Roles...)
+
+			continue
+		}
+
+		businessIds = append(businessIds, *mapping.BusinessId)
+		businessRoleNames[*mapping.BusinessId] = append(businessRoleNames[*mapping.BusinessId], mapping.Roles...)
+	}
+
+	if len(roleNames) > 0 {
+		var roles []models.Role
+
+		if err := tx.Where("name IN ? AND business_id IS NULL", roleNames).Find(&roles).Error; err != nil {
+			return err
+		}
+
+		if len(roles) > 0 {
+			if err := tx.Model(user).Association("Roles").Append(roles); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(businessIds) > 0 {
+		var businesses []models.Business
+
+		if err := tx.Where("id IN ?", businessIds).Find(&businesses).Error; err != nil {
+			return err
+		}
+
+		if len(businesses) > 0 {
+			if err := tx.Model(user).Association("Businesses").Append(businesses); err != nil {
+				return err
+			}
+
+			if user.BusinessId == nil {
+				if err := tx.Model(user).Update("business_id", businesses[0].Id).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		for _, business := range businesses {
+			names := businessRoleNames[business.Id]
+
+			if len(names) == 0 {
+				continue
+			}
+
+			var roles []models.Role
+
+			if err := tx.Where("name IN ? AND (business_id IS NULL OR business_id = ?)", names, business.Id).Find(&roles).Error; err != nil {
+				return err
+			}
+
+			for _, role := range roles {
+				membershipRole := models.MembershipRole{
+					BusinessId: business.Id,
+					UserId:     user.Id,
+					RoleId:     role.Id,
+				}
+
+				if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&membershipRole).Error; err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
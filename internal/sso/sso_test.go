@@ -0,0 +1,589 @@
+package sso
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/sessions"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/connor-davis/threereco-nextgen/internal/testdb"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	mockSlug     = "mock"
+	mockClientId = "threereco"
+	mockSecret   = "secret"
+)
+
+type mockGrant struct {
+	challenge string
+	nonce     string
+}
+
+// mockIdp is an OpenID Connect provider serving discovery, keys, the
+// authorization endpoint and a token endpoint that enforces PKCE.
+type mockIdp struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mutex  sync.Mutex
+	grants map[string]mockGrant
+
+	// claims are added to every id token, nonce replaces the nonce of the
+	// login request when set.
+	claims map[string]any
+	nonce  string
+}
+
+func newMockIdp(t *testing.T) *mockIdp {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idp := &mockIdp{
+		key:    key,
+		grants: map[string]mockGrant{},
+		claims: map[string]any{},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/jwks", idp.jwks)
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+
+	idp.server = httptest.NewServer(mux)
+
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (idp *mockIdp) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJson(w, http.StatusOK, map[string]any{
+		"issuer":                                idp.server.URL,
+		"authorization_endpoint":                idp.server.URL + "/authorize",
+		"token_endpoint":                        idp.server.URL + "/token",
+		"jwks_uri":                              idp.server.URL + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (idp *mockIdp) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJson(w, http.StatusOK, map[string]any{
+		"keys": []map[string]any{{
+			"kty": "RSA",
+			"alg": "RS256",
+			"use": "sig",
+			"kid": "test",
+			"n":   base64.RawURLEncoding.EncodeToString(idp.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(idp.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (idp *mockIdp) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if query.Get("response_type") != "code" ||
+		query.Get("client_id") != mockClientId ||
+		query.Get("state") == "" ||
+		query.Get("nonce") == "" ||
+		query.Get("code_challenge") == "" ||
+		query.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+
+		return
+	}
+
+	code := uuid.NewString()
+
+	idp.mutex.Lock()
+	idp.grants[code] = mockGrant{
+		challenge: query.Get("code_challenge"),
+		nonce:     query.Get("nonce"),
+	}
+	idp.mutex.Unlock()
+
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+
+	if err != nil {
+		http.Error(w, "invalid redirect uri", http.StatusBadRequest)
+
+		return
+	}
+
+	values := redirect.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirect.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (idp *mockIdp) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJson(w, http.StatusBadRequest, map[string]any{"error": "invalid_request"})
+
+		return
+	}
+
+	clientId, clientSecret, ok := r.BasicAuth()
+
+	if !ok {
+		clientId, clientSecret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+
+	if clientId != mockClientId || clientSecret != mockSecret {
+		writeJson(w, http.StatusUnauthorized, map[string]any{"error": "invalid_client"})
+
+		return
+	}
+
+	idp.mutex.Lock()
+	grant, ok := idp.grants[r.PostForm.Get("code")]
+	delete(idp.grants, r.PostForm.Get("code"))
+	idp.mutex.Unlock()
+
+	challenge := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" ||
+		base64.RawURLEncoding.EncodeToString(challenge[:]) != grant.challenge {
+		writeJson(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant"})
+
+		return
+	}
+
+	nonce := grant.nonce
+
+	if idp.nonce != "" {
+		nonce = idp.nonce
+	}
+
+	claims := map[string]any{
+		"iss":   idp.server.URL,
+		"aud":   mockClientId,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"nonce": nonce,
+	}
+
+	for name, value := range idp.claims {
+		claims[name] = value
+	}
+
+	idToken, err := idp.sign(claims)
+
+	if err != nil {
+		writeJson(w, http.StatusInternalServerError, map[string]any{"error": "server_error"})
+
+		return
+	}
+
+	writeJson(w, http.StatusOK, map[string]any{
+		"access_token": uuid.NewString(),
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     idToken,
+	})
+}
+
+func (idp *mockIdp) sign(claims map[string]any) (string, error) {
+	header, err := json.Marshal(map[string]any{"alg": "RS256", "kid": "test", "typ": "JWT"})
+
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(claims)
+
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, digest[:])
+
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func writeJson(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	json.NewEncoder(w).Encode(body)
+}
+
+// newTestSso configures the mock provider through a providers file, like the
+// api does.
+func newTestSso(t *testing.T, idp *mockIdp, store storage.Storage, config ProviderConfig) Sso {
+	t.Helper()
+
+	config.Slug = mockSlug
+	config.Issuer = idp.server.URL
+	config.ClientId = mockClientId
+	config.ClientSecret = mockSecret
+
+	data, err := json.Marshal([]ProviderConfig{config})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), "providers.json")
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("APP_OIDC_PROVIDERS_FILE", path)
+	t.Setenv("APP_OIDC_CALLBACK_BASE_URL", "http://api.test")
+
+	return New(store, sessions.Config{})
+}
+
+// newTestApp mirrors the begin and callback routes. The callback provisions the
+// user when the sso has storage.
+func newTestApp(s Sso, provision bool) *fiber.App {
+	app := fiber.New()
+
+	app.Get("/api/authentication/oidc/:provider/begin", func(c *fiber.Ctx) error {
+		flow, authUrl, err := s.Begin(c.Params("provider"), "/dashboard")
+
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		}
+
+		if err := s.SaveFlow(c, flow); err != nil {
+			return c.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		}
+
+		return c.Redirect(authUrl, fiber.StatusFound)
+	})
+
+	app.Get("/api/authentication/oidc/:provider/callback", func(c *fiber.Ctx) error {
+		flow, err := s.TakeFlow(c, c.Params("provider"), c.Query("state"))
+
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).SendString(err.Error())
+		}
+
+		claims, err := s.Exchange(c.UserContext(), flow, c.Query("code"))
+
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).SendString(err.Error())
+		}
+
+		if !provision {
+			return c.Status(fiber.StatusOK).SendString(claims.Username)
+		}
+
+		user, err := s.Provision(c.Params("provider"), claims)
+
+		switch {
+		case errors.Is(err, ErrUsernameTaken):
+			return c.Status(fiber.StatusConflict).SendString(err.Error())
+		case errors.Is(err, ErrNotProvisioned):
+			return c.Status(fiber.StatusForbidden).SendString(err.Error())
+		case err != nil:
+			return c.Status(fiber.StatusInternalServerError).SendString(err.Error())
+		}
+
+		return c.Status(fiber.StatusOK).SendString(user.Id.String())
+	})
+
+	return app
+}
+
+type signIn struct {
+	// state replaces the state returned by the provider, flow edits the flow
+	// cookie before the callback.
+	state string
+	flow  func(flow *Flow)
+}
+
+// signInWith runs the whole login: begin, the provider's authorization
+// endpoint and the callback. It returns the callback's status and body.
+func signInWith(t *testing.T, app *fiber.App, options signIn) (int, string) {
+	t.Helper()
+
+	response, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/api/authentication/oidc/mock/begin", nil))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if response.StatusCode != fiber.StatusFound {
+		t.Fatalf("begin returned %d", response.StatusCode)
+	}
+
+	cookies := response.Cookies()
+
+	client := &http.Client{
+		CheckRedirect: func(request *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	authorization, err := client.Get(response.Header.Get("Location"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorization.Body.Close()
+
+	if authorization.StatusCode != http.StatusFound {
+		t.Fatalf("the provider rejected the authorization request with %d", authorization.StatusCode)
+	}
+
+	callback, err := url.Parse(authorization.Header.Get("Location"))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if callback.Host != "api.test" || callback.Path != "/api/authentication/oidc/mock/callback" {
+		t.Fatalf("the provider redirected to %s", callback.String())
+	}
+
+	query := callback.Query()
+
+	if options.state != "" {
+		query.Set("state", options.state)
+	}
+
+	request := httptest.NewRequest(fiber.MethodGet, callback.Path+"?"+query.Encode(), nil)
+
+	for _, cookie := range cookies {
+		value := cookie.Value
+
+		if cookie.Name == flowCookieName && options.flow != nil {
+			value = editFlow(t, value, options.flow)
+		}
+
+		request.AddCookie(&http.Cookie{Name: cookie.Name, Value: value})
+	}
+
+	result, err := app.Test(request)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	body := make([]byte, 512)
+	n, _ := result.Body.Read(body)
+
+	return result.StatusCode, string(body[:n])
+}
+
+func editFlow(t *testing.T, value string, edit func(flow *Flow)) string {
+	t.Helper()
+
+	data, err := base64.RawURLEncoding.DecodeString(value)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var flow Flow
+
+	if err := json.Unmarshal(data, &flow); err != nil {
+		t.Fatal(err)
+	}
+
+	edit(&flow)
+
+	data, err = json.Marshal(flow)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func TestSignIn(t *testing.T) {
+	idp := newMockIdp(t)
+	app := newTestApp(newTestSso(t, idp, nil, ProviderConfig{}), false)
+
+	tests := []struct {
+		name    string
+		claims  map[string]any
+		nonce   string
+		options signIn
+		status  int
+		body    string
+	}{
+		{
+			name:   "valid login",
+			claims: map[string]any{"sub": "1", "email": "Jane@Example.com", "email_verified": true},
+			status: fiber.StatusOK,
+			body:   "jane@example.com",
+		},
+		{
+			name:   "preferred username fallback",
+			claims: map[string]any{"sub": "2", "preferred_username": "jane"},
+			status: fiber.StatusOK,
+			body:   "jane",
+		},
+		{
+			name:   "missing username",
+			claims: map[string]any{"sub": "3"},
+			status: fiber.StatusUnauthorized,
+			body:   ErrMissingUsername.Error(),
+		},
+		{
+			name:    "state mismatch",
+			claims:  map[string]any{"sub": "4", "email": "jane@example.com"},
+			options: signIn{state: "forged"},
+			status:  fiber.StatusBadRequest,
+			body:    ErrInvalidFlow.Error(),
+		},
+		{
+			name:    "flow for another provider",
+			claims:  map[string]any{"sub": "5", "email": "jane@example.com"},
+			options: signIn{flow: func(flow *Flow) { flow.Provider = "other" }},
+			status:  fiber.StatusBadRequest,
+			body:    ErrInvalidFlow.Error(),
+		},
+		{
+			name:   "nonce mismatch",
+			claims: map[string]any{"sub": "6", "email": "jane@example.com"},
+			nonce:  "replayed",
+			status: fiber.StatusUnauthorized,
+			body:   ErrInvalidNonce.Error(),
+		},
+		{
+			name:    "wrong pkce verifier",
+			claims:  map[string]any{"sub": "7", "email": "jane@example.com"},
+			options: signIn{flow: func(flow *Flow) { flow.Verifier = strings.Repeat("a", 43) }},
+			status:  fiber.StatusUnauthorized,
+			body:    "invalid_grant",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			idp.claims = test.claims
+			idp.nonce = test.nonce
+
+			status, body := signInWith(t, app, test.options)
+
+			if status != test.status {
+				t.Fatalf("got status %d (%s), want %d", status, body, test.status)
+			}
+
+			if !strings.Contains(body, test.body) {
+				t.Errorf("got body %q, want it to contain %q", body, test.body)
+			}
+		})
+	}
+}
+
+func TestProvision(t *testing.T) {
+	store := testdb.Open(t)
+	idp := newMockIdp(t)
+
+	signup := newTestApp(newTestSso(t, idp, store, ProviderConfig{
+		UsernameClaim: "email",
+		UserType:      models.CollectorUser,
+		AllowSignup:   true,
+	}), true)
+
+	closed := newTestApp(newTestSso(t, idp, store, ProviderConfig{
+		UsernameClaim: "email",
+		UserType:      models.CollectorUser,
+	}), true)
+
+	existing := models.User{
+		Name:     "Existing",
+		Username: strings.ToLower(uuid.NewString()) + "@example.com",
+		Type:     models.CollectorUser,
+	}
+
+	if err := store.Database().Create(&existing).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	subject := uuid.NewString()
+	username := strings.ToLower(uuid.NewString()) + "@example.com"
+
+	idp.claims = map[string]any{"sub": subject, "email": username, "name": "New User"}
+
+	status, first := signInWith(t, signup, signIn{})
+
+	if status != fiber.StatusOK {
+		t.Fatalf("jit provisioning returned %d: %s", status, first)
+	}
+
+	var user models.User
+
+	if err := store.Database().Where("username = ?", username).First(&user).Error; err != nil {
+		t.Fatalf("the user was not provisioned: %s", err.Error())
+	}
+
+	if user.Id.String() != first || user.Name != "New User" {
+		t.Errorf("provisioned %s %q, want %s %q", user.Id, user.Name, first, "New User")
+	}
+
+	status, second := signInWith(t, signup, signIn{})
+
+	if status != fiber.StatusOK || second != first {
+		t.Errorf("signing in again returned %d %s, want the linked user %s", status, second, first)
+	}
+
+	idp.claims = map[string]any{"sub": uuid.NewString(), "email": existing.Username}
+
+	if status, body := signInWith(t, signup, signIn{}); status != fiber.StatusConflict {
+		t.Errorf("an existing username returned %d (%s), want %d", status, body, fiber.StatusConflict)
+	}
+
+	var identities int64
+
+	if err := store.Database().Model(&models.UserIdentity{}).Where("user_id = ?", existing.Id).Count(&identities).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if identities != 0 {
+		t.Errorf("the existing user was linked to %d identities", identities)
+	}
+
+	idp.claims = map[string]any{"sub": uuid.NewString(), "email": strings.ToLower(uuid.NewString()) + "@example.com"}
+
+	if status, body := signInWith(t, closed, signIn{}); status != fiber.StatusForbidden {
+		t.Errorf("sign up on a closed provider returned %d (%s), want %d", status, body, fiber.StatusForbidden)
+	}
+}
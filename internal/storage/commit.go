@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// committingPool is the connection pool gorm uses. It begins transactions
+// that run their AfterCommit hooks once they commit; gorm picks it up as a
+// ConnPoolBeginner.
+type committingPool struct {
+	*sql.DB
+}
+
+func (p *committingPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.DB.BeginTx(ctx, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &committingTx{Tx: tx, db: p.DB}, nil
+}
+
+func (p *committingPool) GetDBConn() (*sql.DB, error) {
+	return p.DB, nil
+}
+
+type committingTx struct {
+	gorm.Tx
+
+	db    *sql.DB
+	mutex sync.Mutex
+	hooks []func()
+}
+
+func (t *committingTx) Commit() error {
+	if err := t.Tx.Commit(); err != nil {
+		return err
+	}
+
+	t.mutex.Lock()
+	hooks := t.hooks
+	t.hooks = nil
+	t.mutex.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+
+	return nil
+}
+
+func (t *committingTx) Rollback() error {
+	t.mutex.Lock()
+	t.hooks = nil
+	t.mutex.Unlock()
+
+	return t.Tx.Rollback()
+}
+
+func (t *committingTx) GetDBConn() (*sql.DB, error) {
+	return t.db, nil
+}
+
+// AfterCommit runs hook once the transaction tx is running in commits, and
+// drops it on rollback. Outside a transaction the write has already happened,
+// so hook runs straight away.
+func AfterCommit(tx *gorm.DB, hook func()) {
+	if committing, ok := tx.Statement.ConnPool.(*committingTx); ok {
+		committing.mutex.Lock()
+		committing.hooks = append(committing.hooks, hook)
+		committing.mutex.Unlock()
+
+		return
+	}
+
+	hook()
+}
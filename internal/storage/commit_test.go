@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+// fakeTx stands in for a *sql.Tx, only committing or rolling back.
+type fakeTx struct {
+	gorm.Tx
+}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+func TestAfterCommit(t *testing.T) {
+	tests := []struct {
+		name   string
+		finish func(tx *committingTx) error
+		ran    bool
+	}{
+		{name: "commit", finish: func(tx *committingTx) error { return tx.Commit() }, ran: true},
+		{name: "rollback", finish: func(tx *committingTx) error { return tx.Rollback() }, ran: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx := &committingTx{Tx: fakeTx{}}
+			db := &gorm.DB{Statement: &gorm.Statement{ConnPool: tx}}
+
+			ran := false
+
+			AfterCommit(db, func() { ran = true })
+
+			if ran {
+				t.Fatal("the hook ran before the transaction finished")
+			}
+
+			if err := test.finish(tx); err != nil {
+				t.Fatal(err)
+			}
+
+			if ran != test.ran {
+				t.Errorf("got ran %t, want %t", ran, test.ran)
+			}
+		})
+	}
+
+	t.Run("outside a transaction", func(t *testing.T) {
+		db := &gorm.DB{Statement: &gorm.Statement{ConnPool: &committingPool{}}}
+
+		ran := false
+
+		AfterCommit(db, func() { ran = true })
+
+		if !ran {
+			t.Error("the hook didn't run straight away")
+		}
+	})
+}
@@ -1,15 +1,24 @@
 package storage
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/connor-davis/threereco-nextgen/common"
 	"github.com/connor-davis/threereco-nextgen/internal/models"
 	"github.com/gofiber/fiber/v2/log"
 	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/shopspring/decimal"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Storage interface {
@@ -30,6 +39,11 @@ func New() Storage {
 
 	if err != nil {
 		log.Errorf("failed to connect database: %s", err.Error())
+	} else if sqlDB, ok := db.ConnPool.(*sql.DB); ok {
+		pool := &committingPool{DB: sqlDB}
+
+		db.ConnPool = pool
+		db.Statement.ConnPool = pool
 	}
 
 	return &storage{
@@ -42,6 +56,36 @@ func (s *storage) Database() *gorm.DB {
 }
 
 func (s *storage) Migrate() error {
+	if err := s.db.SetupJoinTable(&models.User{}, "Businesses", &models.Membership{}); err != nil {
+		log.Errorf("failed to setup memberships: %s", err.Error())
+
+		return err
+	}
+
+	if err := s.db.SetupJoinTable(&models.Business{}, "Users", &models.Membership{}); err != nil {
+		log.Errorf("failed to setup memberships: %s", err.Error())
+
+		return err
+	}
+
+	if err := s.migrateCarbonFactorColumns(); err != nil {
+		log.Errorf("failed to migrate carbon factors: %s", err.Error())
+
+		return err
+	}
+
+	if err := s.migrateOwnedLines(); err != nil {
+		log.Errorf("failed to migrate line ownership: %s", err.Error())
+
+		return err
+	}
+
+	if err := s.migrateVatRegistration(); err != nil {
+		log.Errorf("failed to migrate VAT registration: %s", err.Error())
+
+		return err
+	}
+
 	if err := s.db.AutoMigrate(
 		&models.Business{},
 		&models.User{},
@@ -51,15 +95,571 @@ func (s *storage) Migrate() error {
 		&models.CollectionMaterial{},
 		&models.Transaction{},
 		&models.TransactionMaterial{},
+		&models.PasswordHistory{},
+		&models.Lockout{},
+		&models.ApiToken{},
+		&models.UserSession{},
+		&models.UserIdentity{},
+		&models.Invitation{},
+		&models.Verification{},
+		&models.Impersonation{},
+		&models.AuditLog{},
+		&models.MembershipRole{},
+		&models.MaterialCarbonFactor{},
+		&models.MaterialPrice{},
+		&models.CollectorGrade{},
+		&models.StatusChange{},
+		&models.StockMovement{},
+		&models.PayoutRun{},
+		&models.PayoutStatement{},
+		&models.PayoutItem{},
+		&models.InvoiceSequence{},
+		&models.Invoice{},
+		&models.SchemaMigration{},
 	); err != nil {
 		log.Errorf("failed to migrate database: %s", err.Error())
 
 		return err
 	}
 
+	if err := s.migrateBusinessRoles(); err != nil {
+		log.Errorf("failed to migrate business roles: %s", err.Error())
+
+		return err
+	}
+
+	if err := s.migrateCarbonFactorHistory(); err != nil {
+		log.Errorf("failed to migrate carbon factor history: %s", err.Error())
+
+		return err
+	}
+
+	if err := s.migrateLineMaterials(); err != nil {
+		log.Errorf("failed to link lines to materials: %s", err.Error())
+
+		return err
+	}
+
+	if err := s.migrateStockMovements(); err != nil {
+		log.Errorf("failed to book stock movements: %s", err.Error())
+
+		return err
+	}
+
+	if err := s.once("total-transactions", migrateTransactionAmounts); err != nil {
+		log.Errorf("failed to total transactions: %s", err.Error())
+
+		return err
+	}
+
+	for _, grant := range roleGrants {
+		if err := s.once(grant.name, func(tx *gorm.DB) error {
+			return grantPermissions(tx, grant.permissions)
+		}); err != nil {
+			log.Errorf("failed to %s: %s", strings.ReplaceAll(grant.name, "-", " "), err.Error())
+
+			return err
+		}
+	}
+
+	if err := s.once("grant-invoice-issue", grantInvoiceIssue); err != nil {
+		log.Errorf("failed to grant the invoice issue permission: %s", err.Error())
+
+		return err
+	}
+
+	return nil
+}
+
+// once runs a data migration the first time Migrate reaches it and records it
+// in schema_migrations, for migrations that must not run again once their
+// effect could have been changed on purpose.
+func (s *storage) once(name string, migration func(tx *gorm.DB) error) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.SchemaMigration{
+			Name:      name,
+			AppliedAt: time.Now(),
+		})
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		return migration(tx)
+	})
+}
+
+// roleGrants are the permissions the seeded business roles gained after they
+// were first created, each granted by its own migration. Roles are only seeded
+// when they don't exist, so existing databases are granted these once instead.
+var roleGrants = []struct {
+	name        string
+	permissions map[string][]string
+}{
+	{
+		name: "grant-carbon-view",
+		permissions: map[string][]string{
+			"Business Owner": {"carbon.view"},
+			"Business Staff": {"carbon.view"},
+			"Business User":  {"carbon.view"},
+		},
+	},
+	{
+		name: "grant-service-account-permissions",
+		permissions: map[string][]string{
+			"Business Owner": {"businesses.serviceaccounts.*", "tokens.*"},
+		},
+	},
+	{
+		name: "grant-invitation-permissions",
+		permissions: map[string][]string{
+			"Business Owner": {"businesses.invitations.*"},
+		},
+	},
+	{
+		name: "grant-business-role-management",
+		permissions: map[string][]string{
+			"Business Owner": {"businesses.roles.create", "businesses.roles.update", "businesses.roles.delete"},
+		},
+	},
+	{
+		name: "grant-price-permissions",
+		permissions: map[string][]string{
+			"Business Owner": {"businesses.prices.*"},
+			"Business Staff": {"businesses.prices.view"},
+		},
+	},
+	{
+		name: "grant-status-permissions",
+		permissions: map[string][]string{
+			"Business Staff": {
+				"collections.status.submit",
+				"collections.status.weigh",
+				"collections.status.dispute",
+				"transactions.status.submit",
+				"transactions.status.weigh",
+				"transactions.status.dispute",
+			},
+		},
+	},
+	{
+		name: "grant-collector-dispute-permission",
+		permissions: map[string][]string{
+			"Business User": {"collections.status.dispute"},
+		},
+	},
+	{
+		name: "grant-stock-permissions",
+		permissions: map[string][]string{
+			"Business Owner": {"businesses.stock.*"},
+			"Business Staff": {"businesses.stock.view"},
+		},
+	},
+	{
+		name: "grant-payout-permissions",
+		permissions: map[string][]string{
+			"Business Owner": {"businesses.payouts.*"},
+		},
+	},
+	{
+		name: "grant-receipt-permissions",
+		permissions: map[string][]string{
+			"Business Staff": {"collections.receipts.view"},
+			"Business User":  {"collections.receipts.view"},
+		},
+	},
+	{
+		name: "grant-vat-permissions",
+		permissions: map[string][]string{
+			"Business Owner": {"businesses.vat.*"},
+		},
+	},
+}
+
+// grantPermissions adds the permissions each global role doesn't hold yet,
+// keeping the ones it has.
+func grantPermissions(tx *gorm.DB, grants map[string][]string) error {
+	for name, permissions := range grants {
+		if err := tx.Exec(`
+			UPDATE roles
+			SET permissions = COALESCE(permissions, '{}') || ARRAY(
+				SELECT granted FROM UNNEST(?::text[]) AS granted WHERE granted <> ALL(COALESCE(roles.permissions, '{}'))
+			), updated_at = NOW()
+			WHERE name = ? AND business_id IS NULL
+		`, pq.StringArray(permissions), name).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// grantInvoiceIssue gives the roles that may download invoices, which used to
+// issue an invoice on its first download, the permission to issue them.
+func grantInvoiceIssue(tx *gorm.DB) error {
+	return tx.Exec(`
+		UPDATE roles
+		SET permissions = permissions || ARRAY['transactions.invoices.issue'], updated_at = NOW()
+		WHERE 'transactions.invoices.view' = ANY(permissions) AND 'transactions.invoices.issue' <> ALL(permissions)
+	`).Error
+}
+
+// migrateCarbonFactorColumns converts the free-text carbon factors to
+// numbers before AutoMigrate changes the column types. A value must start
+// with a number, such as "0.5", "-1,25" or "1.5 kg CO2e/kg"; empty values
+// become zero. Values that can't be read as a number fail the migration and
+// are listed, so they can be corrected instead of silently becoming zero.
+func (s *storage) migrateCarbonFactorColumns() error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"materials", "collection_materials", "transaction_materials"} {
+			var dataType string
+
+			if err := tx.Raw(
+				"SELECT data_type FROM information_schema.columns WHERE table_schema = CURRENT_SCHEMA() AND table_name = ? AND column_name = 'carbon_factor'",
+				table,
+			).Scan(&dataType).Error; err != nil {
+				return err
+			}
+
+			if dataType != "text" {
+				continue
+			}
+
+			var values []*string
+
+			if err := tx.Table(table).Distinct("carbon_factor").Pluck("carbon_factor", &values).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN carbon_factor_number decimal(12,4) NOT NULL DEFAULT 0`, table)).Error; err != nil {
+				return err
+			}
+
+			unconvertible := []string{}
+
+			for _, value := range values {
+				if value == nil {
+					continue
+				}
+
+				factor, ok := parseCarbonFactor(*value)
+
+				if !ok {
+					unconvertible = append(unconvertible, strconv.Quote(*value))
+
+					continue
+				}
+
+				if err := tx.Exec(
+					fmt.Sprintf(`UPDATE %s SET carbon_factor_number = ? WHERE carbon_factor = ?`, table),
+					factor,
+					*value,
+				).Error; err != nil {
+					return err
+				}
+			}
+
+			if len(unconvertible) > 0 {
+				return fmt.Errorf("%s has carbon factors that aren't numbers, correct them and restart: %s", table, strings.Join(unconvertible, ", "))
+			}
+
+			if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s DROP COLUMN carbon_factor`, table)).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %s RENAME COLUMN carbon_factor_number TO carbon_factor`, table)).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// carbonFactorPattern is a number with an optional sign and decimal point,
+// optionally followed by a unit that doesn't start with a digit.
+var carbonFactorPattern = regexp.MustCompile(`^([-+]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+))(?:\s*[^0-9.,\s].*)?$`)
+
+// maxCarbonFactor is the first value that doesn't fit decimal(12,4).
+var maxCarbonFactor = decimal.New(1, 8)
+
+// parseCarbonFactor reads a free-text carbon factor. A single decimal comma is
+// accepted, any other comma makes the value unreadable.
+func parseCarbonFactor(value string) (decimal.Decimal, bool) {
+	value = strings.TrimSpace(value)
+
+	if value == "" {
+		return decimal.Zero, true
+	}
+
+	if strings.Count(value, ",") == 1 && !strings.Contains(value, ".") {
+		value = strings.Replace(value, ",", ".", 1)
+	}
+
+	match := carbonFactorPattern.FindStringSubmatch(value)
+
+	if match == nil {
+		return decimal.Zero, false
+	}
+
+	factor, err := decimal.NewFromString(match[1])
+
+	if err != nil || factor.Abs().GreaterThanOrEqual(maxCarbonFactor) {
+		return decimal.Zero, false
+	}
+
+	return factor.Round(4), true
+}
+
+// migrateCarbonFactorHistory starts the factor history of every material that
+// doesn't have one yet with its current factor, effective from when the
+// material was created.
+func (s *storage) migrateCarbonFactorHistory() error {
+	return s.db.Exec(`
+		INSERT INTO material_carbon_factors (material_id, factor, unit, effective_from, created_at, updated_at)
+		SELECT materials.id, materials.carbon_factor, materials.carbon_factor_unit, materials.created_at, NOW(), NOW()
+		FROM materials
+		WHERE NOT EXISTS (
+			SELECT 1 FROM material_carbon_factors WHERE material_carbon_factors.material_id = materials.id
+		)
+	`).Error
+}
+
+// migrateOwnedLines moves collection and transaction lines from the old
+// many-to-many join tables onto their parent. A line that was assigned to
+// several parents is copied so that each parent owns its own line, lines that
+// were never assigned are deleted, and the join tables are dropped.
+func (s *storage) migrateOwnedLines() error {
+	for _, owner := range []string{"collection", "transaction"} {
+		lines := fmt.Sprintf("%s_materials", owner)
+		joins := fmt.Sprintf("%ss_materials", owner)
+
+		if !s.db.Migrator().HasTable(joins) {
+			continue
+		}
+
+		columnTypes, err := s.db.Migrator().ColumnTypes(lines)
+
+		if err != nil {
+			return err
+		}
+
+		// The copies take every column of the original line except its id and
+		// owner.
+		columns := []string{}
+
+		for _, columnType := range columnTypes {
+			if name := columnType.Name(); name != "id" && name != fmt.Sprintf("%s_id", owner) {
+				columns = append(columns, name)
+			}
+		}
+
+		copied := strings.Join(columns, ", ")
+
+		if err := s.db.Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %[1]s ADD COLUMN IF NOT EXISTS %[2]s_id uuid`, lines, owner)).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(fmt.Sprintf(`
+				UPDATE %[1]s
+				SET %[3]s_id = links.%[3]s_id
+				FROM (
+					SELECT DISTINCT ON (%[3]s_material_id) %[3]s_material_id, %[3]s_id
+					FROM %[2]s
+					ORDER BY %[3]s_material_id, %[3]s_id
+				) AS links
+				WHERE %[1]s.id = links.%[3]s_material_id AND %[1]s.%[3]s_id IS NULL
+			`, lines, joins, owner)).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(fmt.Sprintf(`
+				INSERT INTO %[1]s (%[3]s_id, %[4]s)
+				SELECT links.%[3]s_id, %[5]s
+				FROM %[2]s AS links
+				JOIN %[1]s AS original ON original.id = links.%[3]s_material_id
+				WHERE links.%[3]s_id <> original.%[3]s_id
+			`, lines, joins, owner, copied, "original."+strings.Join(columns, ", original."))).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(fmt.Sprintf(`DELETE FROM %[1]s WHERE %[2]s_id IS NULL`, lines, owner)).Error; err != nil {
+				return err
+			}
+
+			if err := tx.Exec(fmt.Sprintf(`ALTER TABLE %[1]s ALTER COLUMN %[2]s_id SET NOT NULL`, lines, owner)).Error; err != nil {
+				return err
+			}
+
+			return tx.Exec(fmt.Sprintf(`DROP TABLE %s`, joins)).Error
+		}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// migrateLineMaterials links collection and transaction lines recorded before
+// lines referenced the catalogue to the material with the same GW code,
+// ignoring case and surrounding spaces. Lines that match no material, or more
+// than one, are left unlinked and listed by the unmatched lines endpoints.
+func (s *storage) migrateLineMaterials() error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"collection_materials", "transaction_materials"} {
+			if err := tx.Exec(fmt.Sprintf(`
+				UPDATE %[1]s
+				SET material_id = matches.material_id
+				FROM (
+					SELECT LOWER(TRIM(gw_code)) AS gw_code, MIN(id::text)::uuid AS material_id
+					FROM materials
+					GROUP BY LOWER(TRIM(gw_code))
+					HAVING COUNT(*) = 1
+				) AS matches
+				WHERE %[1]s.material_id IS NULL AND LOWER(TRIM(%[1]s.gw_code)) = matches.gw_code
+			`, table)).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// migrateStockMovements books the stock of collections and transactions that
+// were approved or paid before the inventory ledger existed, as of when they
+// were last updated. Lines that already have movements are left alone.
+func (s *storage) migrateStockMovements() error {
+	return s.db.Exec(`
+		INSERT INTO stock_movements (business_id, material_id, quantity, type, source_id, line_id, created_at, updated_at)
+		SELECT collections.buyer_id, lines.material_id, lines.weight, 'collection', collections.id, lines.id, collections.updated_at, NOW()
+		FROM collection_materials AS lines
+		JOIN collections ON collections.id = lines.collection_id
+		WHERE collections.status IN ('approved', 'paid') AND lines.material_id IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM stock_movements WHERE stock_movements.line_id = lines.id)
+		UNION ALL
+		SELECT sides.business_id, lines.material_id, sides.sign * lines.weight, 'transaction', transactions.id, lines.id, transactions.updated_at, NOW()
+		FROM transaction_materials AS lines
+		JOIN transactions ON transactions.id = lines.transaction_id
+		CROSS JOIN LATERAL (VALUES (transactions.seller_id, -1), (transactions.buyer_id, 1)) AS sides (business_id, sign)
+		WHERE transactions.status IN ('approved', 'paid') AND lines.material_id IS NOT NULL
+			AND NOT EXISTS (SELECT 1 FROM stock_movements WHERE stock_movements.line_id = lines.id)
+	`).Error
+}
+
+// migrateVatRegistration registers the businesses that recorded a VAT
+// number before VAT registration was a setting of its own, since their
+// invoices charged VAT. It only runs while the column doesn't exist yet, so
+// businesses that deregister later keep their number.
+func (s *storage) migrateVatRegistration() error {
+	if !s.db.Migrator().HasColumn("businesses", "vat_number") || s.db.Migrator().HasColumn("businesses", "vat_registered") {
+		return nil
+	}
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("ALTER TABLE businesses ADD COLUMN vat_registered boolean NOT NULL DEFAULT false").Error; err != nil {
+			return err
+		}
+
+		return tx.Exec("UPDATE businesses SET vat_registered = true WHERE vat_number IS NOT NULL AND TRIM(vat_number) <> ''").Error
+	})
+}
+
+// migrateTransactionAmounts totals the transactions recorded before
+// transactions carried their net, VAT and gross amounts. Invoiced
+// transactions take the VAT rate and amounts of their invoice; the rest are
+// totalled from their lines at their own VAT rate, which is zero for the
+// transactions recorded before VAT rates were. The VAT of every line is
+// worked out again at its transaction's rate.
+func migrateTransactionAmounts(tx *gorm.DB) error {
+	if err := tx.Exec(`
+		UPDATE transactions
+		SET vat_rate = invoices.vat_rate, net = invoices.net, vat = invoices.vat, gross = invoices.gross, currency = invoices.currency
+		FROM invoices
+		WHERE invoices.transaction_id = transactions.id
+	`).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Exec(`
+		UPDATE transaction_materials
+		SET vat = ROUND(transaction_materials.value * transactions.vat_rate / 100, 2)
+		FROM transactions
+		WHERE transactions.id = transaction_materials.transaction_id
+	`).Error; err != nil {
+		return err
+	}
+
+	return tx.Exec(`
+		UPDATE transactions
+		SET net = totals.net, vat = totals.vat, gross = totals.net + totals.vat
+		FROM (
+			SELECT transaction_id, SUM(value) AS net, SUM(vat) AS vat
+			FROM transaction_materials
+			GROUP BY transaction_id
+		) AS totals
+		WHERE totals.transaction_id = transactions.id
+			AND NOT EXISTS (SELECT 1 FROM invoices WHERE invoices.transaction_id = transactions.id)
+	`).Error
+}
+
+// migrateBusinessRoles replaces the old globally unique role name index with
+// one that only applies to global roles, so that businesses can define their
+// own roles with any name, and moves the business roles that used to be
+// granted globally into the membership of the user's business.
+func (s *storage) migrateBusinessRoles() error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DROP INDEX IF EXISTS idx_roles_name").Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_roles_global_name ON roles (name) WHERE business_id IS NULL").Error; err != nil {
+			return err
+		}
+
+		businessRoles := []string{"Business Owner", "Business Staff", "Business User"}
+
+		if err := tx.Exec(`
+			INSERT INTO businesses_users (business_id, user_id, created_at)
+			SELECT DISTINCT users.business_id, users.id, NOW()
+			FROM users_roles
+			JOIN users ON users.id = users_roles.user_id
+			JOIN roles ON roles.id = users_roles.role_id
+			WHERE users.business_id IS NOT NULL AND roles.business_id IS NULL AND roles.name IN ?
+			ON CONFLICT DO NOTHING
+		`, businessRoles).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			INSERT INTO businesses_users_roles (business_id, user_id, role_id, created_at)
+			SELECT users.business_id, users.id, roles.id, NOW()
+			FROM users_roles
+			JOIN users ON users.id = users_roles.user_id
+			JOIN roles ON roles.id = users_roles.role_id
+			WHERE users.business_id IS NOT NULL AND roles.business_id IS NULL AND roles.name IN ?
+			ON CONFLICT DO NOTHING
+		`, businessRoles).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			DELETE FROM users_roles
+			USING users, roles
+			WHERE users.id = users_roles.user_id
+			AND roles.id = users_roles.role_id
+			AND users.business_id IS NOT NULL
+			AND roles.business_id IS NULL
+			AND roles.name IN ?
+		`, businessRoles).Error
+	})
+}
+
 func (s *storage) SeedAdmin() error {
 	adminUserId := uuid.New()
 	adminRoleId := uuid.New()
@@ -153,6 +753,7 @@ func (s *storage) SeedDefaultBusiness() error {
 		Description: &businessOwnerRoleDescription,
 		Permissions: []string{
 			"materials.view",
+			"carbon.view",
 			"collections.*",
 			"transactions.*",
 			"users.view.self",
@@ -164,9 +765,19 @@ func (s *storage) SeedDefaultBusiness() error {
 			"businesses.roles.assign",
 			"businesses.roles.unassign",
 			"businesses.roles.view",
+			"businesses.roles.create",
+			"businesses.roles.update",
+			"businesses.roles.delete",
+			"businesses.prices.*",
+			"businesses.stock.*",
+			"businesses.payouts.*",
+			"businesses.vat.*",
 			"businesses.users.assign",
 			"businesses.users.unassign",
 			"businesses.users.view",
+			"businesses.serviceaccounts.*",
+			"businesses.invitations.*",
+			"tokens.*",
 		},
 		Default: false,
 	}
@@ -179,17 +790,27 @@ func (s *storage) SeedDefaultBusiness() error {
 		Description: &businessStaffRoleDescription,
 		Permissions: []string{
 			"materials.view",
+			"carbon.view",
 			"collections.view",
+			"collections.receipts.view",
 			"collections.create",
 			"collections.update",
+			"collections.status.submit",
+			"collections.status.weigh",
+			"collections.status.dispute",
 			"transactions.view",
 			"transactions.create",
 			"transactions.update",
+			"transactions.status.submit",
+			"transactions.status.weigh",
+			"transactions.status.dispute",
 			"users.view.self",
 			"users.update.self",
 			"users.delete.self",
 			"businesses.view",
 			"businesses.users.view",
+			"businesses.prices.view",
+			"businesses.stock.view",
 		},
 		Default: false,
 	}
@@ -202,7 +823,10 @@ func (s *storage) SeedDefaultBusiness() error {
 		Description: &businessUserRoleDescription,
 		Permissions: []string{
 			"materials.view",
+			"carbon.view",
 			"collections.view",
+			"collections.receipts.view",
+			"collections.status.dispute",
 			"transactions.view",
 			"users.view.self",
 			"users.update.self",
@@ -216,12 +840,9 @@ func (s *storage) SeedDefaultBusiness() error {
 		Base: models.Base{
 			Id: businessOwnerId,
 		},
-		Name:     common.EnvString("APP_DEFAULT_BUSINESS_NAME", "Demo Business"),
-		Username: common.EnvString("APP_DEFAULT_BUSINESS_EMAIL", "demo@3reco.co.za"),
-		Password: hashedPassword,
-		Roles: []models.Role{
-			businessOwnerRole,
-		},
+		Name:       common.EnvString("APP_DEFAULT_BUSINESS_NAME", "Demo Business"),
+		Username:   common.EnvString("APP_DEFAULT_BUSINESS_EMAIL", "demo@3reco.co.za"),
+		Password:   hashedPassword,
 		Type:       models.BusinessUser,
 		BusinessId: &businessId,
 	}
@@ -243,7 +864,7 @@ func (s *storage) SeedDefaultBusiness() error {
 	var existingBusinessUserRole models.Role
 	var existingBusiness models.Business
 
-	if err := s.db.Where("name = ?", businessOwnerRoleName).First(&existingBusinessOwnerRole).Error; err != nil {
+	if err := s.db.Where("name = ? AND business_id IS NULL", businessOwnerRoleName).First(&existingBusinessOwnerRole).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			if err := s.db.Create(&businessOwnerRole).Error; err != nil {
 				log.Errorf("failed to create business role: %s", err.Error())
@@ -253,7 +874,7 @@ func (s *storage) SeedDefaultBusiness() error {
 		}
 	}
 
-	if err := s.db.Where("name = ?", businessStaffRoleName).First(&existingBusinessStaffRole).Error; err != nil {
+	if err := s.db.Where("name = ? AND business_id IS NULL", businessStaffRoleName).First(&existingBusinessStaffRole).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			if err := s.db.Create(&businessStaffRole).Error; err != nil {
 				log.Errorf("failed to create business staff role: %s", err.Error())
@@ -263,7 +884,7 @@ func (s *storage) SeedDefaultBusiness() error {
 		}
 	}
 
-	if err := s.db.Where("name = ?", businessUserRoleName).First(&existingBusinessUserRole).Error; err != nil {
+	if err := s.db.Where("name = ? AND business_id IS NULL", businessUserRoleName).First(&existingBusinessUserRole).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			if err := s.db.Create(&businessUserRole).Error; err != nil {
 				log.Errorf("failed to create business user role: %s", err.Error())
@@ -287,6 +908,24 @@ func (s *storage) SeedDefaultBusiness() error {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			if err := s.db.Create(&business).Error; err != nil {
 				log.Errorf("failed to create default business: %s", err.Error())
+			} else {
+				if existingBusinessOwnerRole.Id != uuid.Nil {
+					businessOwnerRoleId = existingBusinessOwnerRole.Id
+				}
+
+				if existingBusinessOwner.Id != uuid.Nil {
+					businessOwnerId = existingBusinessOwner.Id
+				}
+
+				membershipRole := models.MembershipRole{
+					BusinessId: businessId,
+					UserId:     businessOwnerId,
+					RoleId:     businessOwnerRoleId,
+				}
+
+				if err := s.db.Create(&membershipRole).Error; err != nil {
+					log.Errorf("failed to assign business owner role: %s", err.Error())
+				}
 			}
 		} else {
 			log.Errorf("failed to query default business: %s", err.Error())
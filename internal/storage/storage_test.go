@@ -0,0 +1,122 @@
+package storage
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+func TestParseCarbonFactor(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+		ok    bool
+	}{
+		{value: "0.5", want: "0.5", ok: true},
+		{value: " 12 ", want: "12", ok: true},
+		{value: ".5", want: "0.5", ok: true},
+		{value: "5.", want: "5", ok: true},
+		{value: "-1.25", want: "-1.25", ok: true},
+		{value: "+2", want: "2", ok: true},
+		{value: "0,75", want: "0.75", ok: true},
+		{value: "-0,5", want: "-0.5", ok: true},
+		{value: "1.5 kg CO2e/kg", want: "1.5", ok: true},
+		{value: "1.5kg", want: "1.5", ok: true},
+		{value: "0.123456", want: "0.1235", ok: true},
+		{value: "", want: "0", ok: true},
+		{value: "   ", want: "0", ok: true},
+		{value: "n/a", ok: false},
+		{value: "about 2", ok: false},
+		{value: "1,234.5", ok: false},
+		{value: "1,2,3", ok: false},
+		{value: "1.5 2", ok: false},
+		{value: "1.2.3", ok: false},
+		{value: "-", ok: false},
+		{value: "100000000", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, ok := parseCarbonFactor(test.value)
+
+			if ok != test.ok {
+				t.Fatalf("got ok %t, want %t", ok, test.ok)
+			}
+
+			if ok && got.String() != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+// testStorage opens APP_TEST_DSN like testdb.Open, which this package can't
+// import.
+func testStorage(t *testing.T) *storage {
+	t.Helper()
+
+	dsn := os.Getenv("APP_TEST_DSN")
+
+	if dsn == "" {
+		t.Skip("APP_TEST_DSN is not set")
+	}
+
+	t.Setenv("APP_DSN", dsn)
+
+	store := New().(*storage)
+
+	if err := store.Migrate(); err != nil {
+		t.Fatalf("failed to migrate the test database: %s", err.Error())
+	}
+
+	return store
+}
+
+func TestGrantPermissionsOnce(t *testing.T) {
+	store := testStorage(t)
+
+	role := models.Role{Name: uuid.NewString(), Permissions: []string{"materials.view", "carbon.view"}}
+
+	if err := store.db.Create(&role).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	grants := map[string][]string{role.Name: {"carbon.view", "businesses.vat.*"}}
+	migration := "test-" + uuid.NewString()
+
+	grant := func() []string {
+		t.Helper()
+
+		if err := store.once(migration, func(tx *gorm.DB) error {
+			return grantPermissions(tx, grants)
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		var granted models.Role
+
+		if err := store.db.First(&granted, "id = ?", role.Id).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		return granted.Permissions
+	}
+
+	if got, want := grant(), []string{"materials.view", "carbon.view", "businesses.vat.*"}; !slices.Equal(got, want) {
+		t.Errorf("the first run granted %q, want %q", got, want)
+	}
+
+	// A permission removed after the grant stays removed on the next boot.
+	if err := store.db.Model(&role).Update("permissions", pq.StringArray{"materials.view"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := grant(), []string{"materials.view"}; !slices.Equal(got, want) {
+		t.Errorf("the second run left %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,108 @@
+// Package testdb opens the database used by tests that need postgres and
+// creates the records they build on. Tests using it are skipped unless
+// APP_TEST_DSN points at a database that may be migrated and written to.
+package testdb
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"github.com/google/uuid"
+)
+
+func Open(t testing.TB) storage.Storage {
+	t.Helper()
+
+	dsn := os.Getenv("APP_TEST_DSN")
+
+	if dsn == "" {
+		t.Skip("APP_TEST_DSN is not set")
+	}
+
+	t.Setenv("APP_DSN", dsn)
+
+	store := storage.New()
+
+	if store.Database() == nil {
+		t.Fatal("failed to connect to the test database")
+	}
+
+	if err := store.Migrate(); err != nil {
+		t.Fatalf("failed to migrate the test database: %s", err.Error())
+	}
+
+	return store
+}
+
+// User creates a user of the given type with a unique username.
+func User(t testing.TB, store storage.Storage, userType models.UserType) models.User {
+	t.Helper()
+
+	user := models.User{
+		Name:     "Test User",
+		Username: fmt.Sprintf("%s@example.com", uuid.NewString()),
+		Type:     userType,
+	}
+
+	if err := store.Database().Create(&user).Error; err != nil {
+		t.Fatalf("failed to create a user: %s", err.Error())
+	}
+
+	return user
+}
+
+// Business creates a business owned by owner, who is also made a member.
+func Business(t testing.TB, store storage.Storage, owner models.User) models.Business {
+	t.Helper()
+
+	business := models.Business{
+		Name:    "Test Business",
+		OwnerId: owner.Id,
+		Users:   []models.User{owner},
+	}
+
+	if err := store.Database().Omit("Users.*").Create(&business).Error; err != nil {
+		t.Fatalf("failed to create a business: %s", err.Error())
+	}
+
+	if err := store.Database().First(&business, "id = ?", business.Id).Error; err != nil {
+		t.Fatalf("failed to load the business: %s", err.Error())
+	}
+
+	return business
+}
+
+// Material creates a catalogue material with a unique name and GW code.
+func Material(t testing.TB, store storage.Storage) models.Material {
+	t.Helper()
+
+	material := models.Material{
+		Name:   uuid.NewString(),
+		GWCode: uuid.NewString(),
+	}
+
+	if err := store.Database().Create(&material).Error; err != nil {
+		t.Fatalf("failed to create a material: %s", err.Error())
+	}
+
+	return material
+}
+
+// Collection creates a draft collection sold by seller to buyer.
+func Collection(t testing.TB, store storage.Storage, seller models.User, buyer models.Business) models.Collection {
+	t.Helper()
+
+	collection := models.Collection{
+		SellerId: seller.Id,
+		BuyerId:  buyer.Id,
+	}
+
+	if err := store.Database().Create(&collection).Error; err != nil {
+		t.Fatalf("failed to create a collection: %s", err.Error())
+	}
+
+	return collection
+}
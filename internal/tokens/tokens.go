@@ -0,0 +1,177 @@
+package tokens
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/connor-davis/threereco-nextgen/common"
+	"github.com/connor-davis/threereco-nextgen/internal/models"
+	"github.com/connor-davis/threereco-nextgen/internal/permissions"
+	"github.com/connor-davis/threereco-nextgen/internal/storage"
+	"gorm.io/gorm"
+)
+
+const TokenPrefix = "3r_"
+
+var (
+	ErrInvalidToken    = errors.New("the api token is invalid, expired or revoked")
+	ErrNoPermissions   = errors.New("an api token must have at least one permission")
+	ErrInvalidExpiry   = errors.New("the api token expiry must be in the future and within the maximum lifetime")
+	ErrPermissionsHeld = errors.New("an api token can only be granted permissions that its owner already has")
+)
+
+type Tokens interface {
+	Issue(owner *models.User, issuer *models.User, payload models.CreateApiTokenPayload) (*models.ApiToken, string, error)
+	Resolve(raw string) (*models.ApiToken, error)
+	Touch(token *models.ApiToken) error
+}
+
+type tokens struct {
+	storage         storage.Storage
+	defaultLifetime time.Duration
+	maxLifetime     time.Duration
+}
+
+func New(storage storage.Storage) Tokens {
+	return &tokens{
+		storage:         storage,
+		defaultLifetime: common.EnvDuration("APP_API_TOKEN_DEFAULT_LIFETIME", 90*24*time.Hour),
+		maxLifetime:     common.EnvDuration("APP_API_TOKEN_MAX_LIFETIME", 365*24*time.Hour),
+	}
+}
+
+func hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func random(size int) ([]byte, error) {
+	bytes := make([]byte, size)
+
+	if _, err := rand.Read(bytes); err != nil {
+		return nil, err
+	}
+
+	return bytes, nil
+}
+
+func (t *tokens) Issue(owner *models.User, issuer *models.User, payload models.CreateApiTokenPayload) (*models.ApiToken, string, error) {
+	if len(payload.Permissions) == 0 {
+		return nil, "", ErrNoPermissions
+	}
+
+	ownerPermissions := permissions.Effective(owner)
+	issuerPermissions := permissions.Effective(issuer)
+
+	for _, permission := range payload.Permissions {
+		if !permissions.Allows(ownerPermissions, permission) || !permissions.Allows(issuerPermissions, permission) {
+			return nil, "", fmt.Errorf("%w: %s", ErrPermissionsHeld, permission)
+		}
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(t.defaultLifetime)
+
+	if payload.ExpiresAt != nil {
+		expiresAt = *payload.ExpiresAt
+	}
+
+	if !expiresAt.After(now) || expiresAt.Sub(now) > t.maxLifetime {
+		return nil, "", ErrInvalidExpiry
+	}
+
+	prefixBytes, err := random(4)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	secretBytes, err := random(32)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	prefix := hex.EncodeToString(prefixBytes)
+	secret := base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	token := models.ApiToken{
+		Name:        payload.Name,
+		Prefix:      prefix,
+		Hash:        hash(secret),
+		Permissions: payload.Permissions,
+		ExpiresAt:   &expiresAt,
+		UserId:      owner.Id,
+		CreatedById: issuer.Id,
+	}
+
+	if err := t.storage.Database().Create(&token).Error; err != nil {
+		return nil, "", err
+	}
+
+	return &token, fmt.Sprintf("%s%s_%s", TokenPrefix, prefix, secret), nil
+}
+
+func (t *tokens) Resolve(raw string) (*models.ApiToken, error) {
+	if !strings.HasPrefix(raw, TokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	prefix, secret, found := strings.Cut(strings.TrimPrefix(raw, TokenPrefix), "_")
+
+	if !found || prefix == "" || secret == "" {
+		return nil, ErrInvalidToken
+	}
+
+	var token models.ApiToken
+
+	if err := t.storage.Database().
+		Where("prefix = ?", prefix).
+		First(&token).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, ErrInvalidToken
+		}
+
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hash(secret)), []byte(token.Hash)) != 1 {
+		return nil, ErrInvalidToken
+	}
+
+	if token.RevokedAt != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if token.ExpiresAt != nil && !token.ExpiresAt.After(time.Now()) {
+		return nil, ErrInvalidToken
+	}
+
+	return &token, nil
+}
+
+// Touch records when a token was last used. Writes are limited to once a
+// minute per token so that busy integrations don't update the row on every
+// request.
+func (t *tokens) Touch(token *models.ApiToken) error {
+	now := time.Now()
+
+	if token.LastUsedAt != nil && now.Sub(*token.LastUsedAt) < time.Minute {
+		return nil
+	}
+
+	token.LastUsedAt = &now
+
+	return t.storage.Database().
+		Model(&models.ApiToken{}).
+		Where("id = ?", token.Id).
+		Update("last_used_at", now).Error
+}
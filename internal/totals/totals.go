@@ -0,0 +1,79 @@
+package totals
+
+import (
+	"errors"
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrNoLines        = errors.New("a record needs at least one line")
+	ErrInvalidWeight  = errors.New("every line's weight must be a number more than zero")
+	ErrInvalidValue   = errors.New("every line's value must be an amount of at least zero, to the cent")
+	ErrTooLarge       = errors.New("the total weight and value must be less than 100000000")
+	ErrWeightMismatch = errors.New("the total weight doesn't match the sum of the lines' weights")
+	ErrValueMismatch  = errors.New("the total value doesn't match the sum of the lines' values")
+)
+
+// maxTotal is the largest amount that fits the decimal(10,2) columns.
+const maxTotal = 99999999.99
+
+// tolerance absorbs the rounding of weights to two decimals.
+const tolerance = 0.005
+
+// maxValue is maxTotal as an exact amount.
+var maxValue = decimal.RequireFromString("99999999.99")
+
+// Line is the weight and value of a collection or transaction line.
+type Line struct {
+	Weight float64
+	Value  decimal.Decimal
+}
+
+// Totals is the sum of a record's lines.
+type Totals struct {
+	Weight float64
+	Value  decimal.Decimal
+}
+
+// Validate checks a new record's lines and sums them. Totals the client
+// declared are optional; when given they must match the sum of the lines,
+// which catches lines lost or mistyped on the way. Values are amounts to the
+// cent, so their sum is exact and the declared value must equal it.
+func Validate(lines []Line, declaredWeight *float64, declaredValue *decimal.Decimal) (*Totals, error) {
+	if len(lines) == 0 {
+		return nil, ErrNoLines
+	}
+
+	totals := Totals{}
+
+	for _, line := range lines {
+		if math.IsNaN(line.Weight) || line.Weight <= 0 {
+			return nil, ErrInvalidWeight
+		}
+
+		if line.Value.IsNegative() || !line.Value.Equal(line.Value.Round(2)) {
+			return nil, ErrInvalidValue
+		}
+
+		totals.Weight += line.Weight
+		totals.Value = totals.Value.Add(line.Value)
+	}
+
+	totals.Weight = math.Round(totals.Weight*100) / 100
+
+	if totals.Weight > maxTotal || totals.Value.GreaterThan(maxValue) {
+		return nil, ErrTooLarge
+	}
+
+	if declaredWeight != nil && !(math.Abs(*declaredWeight-totals.Weight) <= tolerance) {
+		return nil, ErrWeightMismatch
+	}
+
+	if declaredValue != nil && !declaredValue.Equal(totals.Value) {
+		return nil, ErrValueMismatch
+	}
+
+	return &totals, nil
+}